@@ -124,6 +124,17 @@ func main() {
 				}).Info("Stopped pinging server")
 			}()
 
+			go func() {
+				if err := ag.Probe(ctx, agent.AgentProbeDefaultInterval); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"version":        AgentVersion,
+						"mode":           mode,
+						"tenant_id":      cfg.TenantID,
+						"server_address": cfg.ServerAddress,
+					}).Error("Failed to probe the agentless targets")
+				}
+			}()
+
 			log.WithFields(log.Fields{
 				"version":            AgentVersion,
 				"mode":               mode,
@@ -0,0 +1,158 @@
+// Package asciicast encodes recorded sessions in the asciicast v2 format, the file format used by asciinema to store
+// terminal sessions.
+//
+// An asciicast v2 file is a newline-delimited JSON stream. The first line is a header object with the terminal's
+// initial dimensions, and each following line is an event, represented as an array with the elapsed time in seconds
+// since the beginning of the recording, the event's type and its data.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/ for the complete specification.
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Version is the asciicast format version written by the encoder.
+const Version = 2
+
+// ContentType is the media type of an asciicast file.
+const ContentType = "application/x-asciicast"
+
+// EventType is the type of an asciicast event.
+type EventType string
+
+const (
+	// EventTypeOutput is the type of the event that represents data written to the terminal.
+	EventTypeOutput EventType = "o"
+	// EventTypeInput is the type of the event that represents data typed on the terminal.
+	EventTypeInput EventType = "i"
+	// EventTypeResize is the type of the event that represents a change on the terminal's dimensions. Its data is
+	// formatted as "{columns}x{rows}".
+	EventTypeResize EventType = "r"
+)
+
+// Header is the first line of an asciicast file.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event is an asciicast event.
+type Event struct {
+	// Time is the elapsed time since the beginning of the recording.
+	Time time.Duration
+	Type EventType
+	Data string
+}
+
+// MarshalJSON encodes the event as the array expected by the asciicast format.
+func (e Event) MarshalJSON() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode([]interface{}{e.Time.Seconds(), e.Type, e.Data}); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// Encoder writes an asciicast stream to an output.
+type Encoder struct {
+	encoder *json.Encoder
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	encoder := json.NewEncoder(w)
+	// Terminal output is full of characters like "<", ">" and "&", which doesn't need to be escaped.
+	encoder.SetEscapeHTML(false)
+
+	return &Encoder{encoder: encoder}
+}
+
+// WriteHeader writes the asciicast header. It must be called once, before any event.
+func (e *Encoder) WriteHeader(header Header) error {
+	if header.Version == 0 {
+		header.Version = Version
+	}
+
+	return e.encoder.Encode(header)
+}
+
+// WriteEvent writes an asciicast event.
+func (e *Encoder) WriteEvent(event Event) error {
+	return e.encoder.Encode(event)
+}
+
+// Encode writes a recorded session as an asciicast stream. The header uses the dimensions of the first frame and
+// each output frame turns into an output event, with its time relative to the first frame. Whenever the frame's dimension
+// differs from the previous one, a resize event is written before the output. The input frames turn into input events.
+//
+// The frames are sorted by time before encoding.
+func Encode(w io.Writer, term string, frames []models.RecordedSession) error {
+	frames = append([]models.RecordedSession(nil), frames...)
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].Time.Before(frames[j].Time)
+	})
+
+	header := Header{Version: Version}
+	if term != "" {
+		header.Env = map[string]string{"TERM": term}
+	}
+
+	if len(frames) > 0 {
+		header.Width = frames[0].Width
+		header.Height = frames[0].Height
+		header.Timestamp = frames[0].Time.Unix()
+	}
+
+	encoder := NewEncoder(w)
+	if err := encoder.WriteHeader(header); err != nil {
+		return err
+	}
+
+	width, height := header.Width, header.Height
+	for _, frame := range frames {
+		elapsed := frame.Time.Sub(frames[0].Time)
+
+		// The input doesn't change the terminal, so its dimension isn't considered.
+		if frame.Direction == models.RecordDirectionInput {
+			if err := encoder.WriteEvent(Event{Time: elapsed, Type: EventTypeInput, Data: frame.Message}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Frames without dimension keep the previous one.
+		if (frame.Width != 0 || frame.Height != 0) && (frame.Width != width || frame.Height != height) {
+			width, height = frame.Width, frame.Height
+
+			if err := encoder.WriteEvent(Event{
+				Time: elapsed,
+				Type: EventTypeResize,
+				Data: fmt.Sprintf("%dx%d", width, height),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := encoder.WriteEvent(Event{Time: elapsed, Type: EventTypeOutput, Data: frame.Message}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
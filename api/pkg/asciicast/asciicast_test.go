@@ -0,0 +1,86 @@
+package asciicast
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		term        string
+		frames      []models.RecordedSession
+		expected    string
+	}{
+		{
+			description: "succeeds with only the header when there are no frames",
+			term:        "",
+			frames:      []models.RecordedSession{},
+			expected:    "{\"version\":2,\"width\":0,\"height\":0}\n",
+		},
+		{
+			description: "succeeds writing output events relative to the first frame",
+			term:        "xterm",
+			frames: []models.RecordedSession{
+				{Message: "$ ls\r\n", Time: start, Width: 80, Height: 24},
+				{Message: "<file>\r\n", Time: start.Add(1500 * time.Millisecond), Width: 80, Height: 24},
+			},
+			expected: "{\"version\":2,\"width\":80,\"height\":24,\"timestamp\":1704067200,\"env\":{\"TERM\":\"xterm\"}}\n" +
+				"[0,\"o\",\"$ ls\\r\\n\"]\n" +
+				"[1.5,\"o\",\"<file>\\r\\n\"]\n",
+		},
+		{
+			description: "succeeds writing resize events when the dimension changes",
+			term:        "",
+			frames: []models.RecordedSession{
+				{Message: "a", Time: start, Width: 80, Height: 24},
+				{Message: "b", Time: start.Add(time.Second), Width: 120, Height: 40},
+				{Message: "c", Time: start.Add(2 * time.Second)},
+			},
+			expected: "{\"version\":2,\"width\":80,\"height\":24,\"timestamp\":1704067200}\n" +
+				"[0,\"o\",\"a\"]\n" +
+				"[1,\"r\",\"120x40\"]\n" +
+				"[1,\"o\",\"b\"]\n" +
+				"[2,\"o\",\"c\"]\n",
+		},
+		{
+			description: "succeeds sorting the frames by time",
+			term:        "",
+			frames: []models.RecordedSession{
+				{Message: "b", Time: start.Add(time.Second), Width: 80, Height: 24},
+				{Message: "a", Time: start, Width: 80, Height: 24},
+			},
+			expected: "{\"version\":2,\"width\":80,\"height\":24,\"timestamp\":1704067200}\n" +
+				"[0,\"o\",\"a\"]\n" +
+				"[1,\"o\",\"b\"]\n",
+		},
+		{
+			description: "succeeds with the participants' input",
+			term:        "",
+			frames: []models.RecordedSession{
+				{Message: "$ ", Time: start, Width: 80, Height: 24},
+				{Message: "ls\r", Time: start.Add(time.Second), Direction: models.RecordDirectionInput, Participant: "guest"},
+				{Message: "ls\r\n", Time: start.Add(time.Second), Width: 80, Height: 24},
+			},
+			expected: "{\"version\":2,\"width\":80,\"height\":24,\"timestamp\":1704067200}\n" +
+				"[0,\"o\",\"$ \"]\n" +
+				"[1,\"i\",\"ls\\r\"]\n" +
+				"[1,\"o\",\"ls\\r\\n\"]\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+
+			assert.NoError(t, Encode(buffer, tc.term, tc.frames))
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
@@ -17,7 +17,7 @@ type DeviceActions struct {
 }
 
 type SessionActions struct {
-	Play, Close, Remove, Details int
+	Play, Close, Remove, Details, Shadow, Share int
 }
 
 type FirewallActions struct {
@@ -29,7 +29,7 @@ type PublicKeyActions struct {
 }
 
 type NamespaceActions struct {
-	Update, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Update, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete, ViewAudit int
 }
 
 type BillingActions struct {
@@ -57,6 +57,8 @@ var Actions = AllActions{
 		Close:   SessionClose,
 		Remove:  SessionRemove,
 		Details: SessionDetails,
+		Shadow:  SessionShadow,
+		Share:   SessionShare,
 	},
 	Firewall: FirewallActions{
 		Create: FirewallCreate,
@@ -78,6 +80,7 @@ var Actions = AllActions{
 		EditMember:          NamespaceEditMember,
 		EnableSessionRecord: NamespaceEnableSessionRecord,
 		Delete:              NamespaceDelete,
+		ViewAudit:           NamespaceViewAudit,
 	},
 	Billing: BillingActions{
 		CreateCustomer:      BillingCreateCustomer,
@@ -366,6 +366,8 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Shadow,
+				Actions.Session.Share,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -380,6 +382,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.ViewAudit,
 			},
 			requiredMocks: func() {
 			},
@@ -406,6 +409,8 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Shadow,
+				Actions.Session.Share,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -421,6 +426,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
 				Actions.Namespace.Delete,
+				Actions.Namespace.ViewAudit,
 
 				Actions.Billing.AddPaymentMethod,
 				Actions.Billing.UpdatePaymentMethod,
@@ -21,6 +21,8 @@ const (
 	SessionClose
 	SessionRemove
 	SessionDetails
+	SessionShadow
+	SessionShare
 
 	FirewallCreate
 	FirewallEdit
@@ -44,6 +46,7 @@ const (
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
 	NamespaceDelete
+	NamespaceViewAudit
 
 	BillingCreateCustomer
 	BillingChooseDevices
@@ -100,6 +103,8 @@ var adminPermissions = Permissions{
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionShadow,
+	SessionShare,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -120,6 +125,7 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceViewAudit,
 }
 
 var ownerPermissions = Permissions{
@@ -143,6 +149,8 @@ var ownerPermissions = Permissions{
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionShadow,
+	SessionShare,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -164,6 +172,7 @@ var ownerPermissions = Permissions{
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
 	NamespaceDelete,
+	NamespaceViewAudit,
 
 	BillingCreateCustomer,
 	BillingChooseDevices,
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListAuditLogsURL = "/namespaces/:tenant/audit"
+)
+
+func (h *Handler) ListAuditLogs(c gateway.Context) error {
+	req := requests.AuditList{}
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := req.Filters.Unmarshal(); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	namespace, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || namespace == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	var logs []models.AuditLog
+	var count int
+	err = guard.EvaluateNamespace(namespace, uid, guard.Actions.Namespace.ViewAudit, func() error {
+		var err error
+		logs, count, err = h.service.ListAuditLogs(c.Ctx(), req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, logs)
+}
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		Owner:    "owner",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members: []models.Member{
+			{ID: "owner", Username: "owner", Role: guard.RoleOwner},
+			{ID: "observer", Username: "observer", Role: guard.RoleObserver},
+		},
+	}
+
+	cases := []struct {
+		title          string
+		uid            string
+		tenant         string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the tenant is not valid",
+			uid:            "owner",
+			tenant:         "id",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "fails when the namespace is not found",
+			uid:    "owner",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").Return(nil, nil).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:  "fails when the member is not allowed to view the audit logs",
+			uid:    "observer",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:  "success when the audit logs are listed",
+			uid:    "owner",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("ListAuditLogs", gomock.Anything, gomock.Anything).Return([]models.AuditLog{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/namespaces/"+tc.tenant+"/audit", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-ID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
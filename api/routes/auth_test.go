@@ -167,6 +167,8 @@ func TestAuthDevice(t *testing.T) {
 			}
 		})
 	}
+
+	mock.AssertExpectations(t)
 }
 
 func TestAuthUser(t *testing.T) {
@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListAutoAcceptRulesURL  = "/devices/auto-accept/rules"
+	GetAutoAcceptRuleURL    = "/devices/auto-accept/rules/:id"
+	CreateAutoAcceptRuleURL = "/devices/auto-accept/rules"
+	UpdateAutoAcceptRuleURL = "/devices/auto-accept/rules/:id"
+	DeleteAutoAcceptRuleURL = "/devices/auto-accept/rules/:id"
+)
+
+func (h *Handler) ListAutoAcceptRules(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rules, count, err := h.service.ListAutoAcceptRules(c.Ctx(), tenant, *paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetAutoAcceptRule(c gateway.Context) error {
+	var req requests.AutoAcceptRuleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rule, err := h.service.GetAutoAcceptRule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateAutoAcceptRule(c gateway.Context) error {
+	var req requests.AutoAcceptRuleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.AutoAcceptRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		rule, err = h.service.CreateAutoAcceptRule(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateAutoAcceptRule(c gateway.Context) error {
+	var req requests.AutoAcceptRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.AutoAcceptRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		rule, err = h.service.UpdateAutoAcceptRule(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteAutoAcceptRule(c gateway.Context) error {
+	var req requests.AutoAcceptRuleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		return h.service.DeleteAutoAcceptRule(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateAutoAcceptRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.AutoAcceptRuleCreate
+		requiredMocks  func(body requests.AutoAcceptRuleCreate)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the action is unknown",
+			role:  guard.RoleOwner,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{Action: "ignore"},
+			},
+			requiredMocks:  func(body requests.AutoAcceptRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the source IP is not a CIDR",
+			role:  guard.RoleOwner,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{
+					Action: "accept",
+					Filter: requests.AutoAcceptFilter{SourceIP: "10.0.0.1"},
+				},
+			},
+			requiredMocks:  func(body requests.AutoAcceptRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the hostname is not a valid regexp",
+			role:  guard.RoleOwner,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{
+					Action: "accept",
+					Filter: requests.AutoAcceptFilter{Hostname: "edge-(["},
+				},
+			},
+			requiredMocks:  func(body requests.AutoAcceptRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the filter has no criterion",
+			role:  guard.RoleOwner,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{Action: "accept"},
+			},
+			requiredMocks:  func(body requests.AutoAcceptRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is not allowed to manage the rules",
+			role:  guard.RoleOperator,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{
+					Action: "accept",
+					Filter: requests.AutoAcceptFilter{Platform: "docker"},
+				},
+			},
+			requiredMocks:  func(body requests.AutoAcceptRuleCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the rule is created",
+			role:  guard.RoleOwner,
+			body: requests.AutoAcceptRuleCreate{
+				AutoAcceptRuleFields: requests.AutoAcceptRuleFields{
+					Action: "accept",
+					Active: true,
+					Tags:   []string{"edge"},
+					Filter: requests.AutoAcceptFilter{SourceIP: "10.0.0.0/8", MACPrefix: "aa:bb:cc"},
+				},
+			},
+			requiredMocks: func(body requests.AutoAcceptRuleCreate) {
+				mock.On("CreateAutoAcceptRule", gomock.Anything, "tenant", body).Return(&models.AutoAcceptRule{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/auto-accept/rules", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
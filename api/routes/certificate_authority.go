@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListCertificateAuthoritiesURL         = "/sshkeys/certificate-authorities"
+	CreateCertificateAuthorityURL         = "/sshkeys/certificate-authorities"
+	DeleteCertificateAuthorityURL         = "/sshkeys/certificate-authorities/:fingerprint"
+	ListInternalCertificateAuthoritiesURL = "/sshkeys/certificate-authorities/:tenant"
+)
+
+func (h *Handler) ListCertificateAuthorities(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	cas, count, err := h.service.ListCertificateAuthorities(c.Ctx(), tenant, *paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, cas)
+}
+
+// ListInternalCertificateAuthorities lists every certificate authority trusted by the namespace, to the SSH server
+// evaluate the certificates used to authenticate.
+func (h *Handler) ListInternalCertificateAuthorities(c gateway.Context) error {
+	var req requests.CertificateAuthorityList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	cas, _, err := h.service.ListCertificateAuthorities(c.Ctx(), req.Tenant, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cas)
+}
+
+func (h *Handler) CreateCertificateAuthority(c gateway.Context) error {
+	var req requests.CertificateAuthorityCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var ca *models.CertificateAuthority
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Create, func() error {
+		var err error
+		ca, err = h.service.CreateCertificateAuthority(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+func (h *Handler) DeleteCertificateAuthority(c gateway.Context) error {
+	var req requests.CertificateAuthorityDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Remove, func() error {
+		return h.service.DeleteCertificateAuthority(c.Ctx(), tenant, req.Fingerprint)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateCertificateAuthority(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.CertificateAuthorityCreate
+		requiredMocks  func(body requests.CertificateAuthorityCreate)
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the name is missing",
+			role:           guard.RoleOwner,
+			body:           requests.CertificateAuthorityCreate{Data: []byte("ssh-ed25519 AAAA")},
+			requiredMocks:  func(body requests.CertificateAuthorityCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is not allowed to create public keys",
+			role:           guard.RoleObserver,
+			body:           requests.CertificateAuthorityCreate{Data: []byte("ssh-ed25519 AAAA"), Name: "ca"},
+			requiredMocks:  func(body requests.CertificateAuthorityCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the certificate authority is created",
+			role:  guard.RoleOwner,
+			body:  requests.CertificateAuthorityCreate{Data: []byte("ssh-ed25519 AAAA"), Name: "ca"},
+			requiredMocks: func(body requests.CertificateAuthorityCreate) {
+				mock.On("CreateCertificateAuthority", gomock.Anything, "tenant", body).Return(&models.CertificateAuthority{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/sshkeys/certificate-authorities", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListInternalCertificateAuthorities(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		tenant         string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the tenant is not a UUID",
+			tenant:         "tenant",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "success when the certificate authorities are listed",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("ListCertificateAuthorities", gomock.Anything, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.CertificateAuthority{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/sshkeys/certificate-authorities/"+tc.tenant, nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -21,9 +22,17 @@ const (
 	HeartbeatDeviceURL          = "/devices/:uid/heartbeat"
 	LookupDeviceURL             = "/lookup"
 	UpdateDeviceStatusURL       = "/devices/:uid/:status"
-	CreateTagURL                = "/devices/:uid/tags"      // Add a tag to a device.
-	UpdateTagURL                = "/devices/:uid/tags"      // Update device's tags with a new set.
-	RemoveTagURL                = "/devices/:uid/tags/:tag" // Delete a tag from a device.
+	CreateTagURL                = "/devices/:uid/tags"            // Add a tag to a device.
+	UpdateTagURL                = "/devices/:uid/tags"            // Update device's tags with a new set.
+	RemoveTagURL                = "/devices/:uid/tags/:tag"       // Delete a tag from a device.
+	SetDeviceAttributesURL      = "/devices/:uid/attributes"      // Set attributes on a device.
+	UnsetDeviceAttributeURL     = "/devices/:uid/attributes/:key" // Delete an attribute from a device.
+	SetDeviceViaURL             = "/devices/:uid/via"             // Set the device which the connections jump from.
+	UnsetDeviceViaURL           = "/devices/:uid/via"             // Make the device reached by its own agent.
+	CreateDeviceTargetURL       = "/devices/targets"              // Register an agentless target.
+	GetDeviceCredentialsURL     = "/devices/:uid/credentials"     // Get the credentials stored for an agentless target.
+	ListDeviceTargetsURL        = "/targets"                      // List the agentless targets probed by an agent.
+	ProbeDeviceTargetsURL       = "/targets/probes"               // Report the agent's probes to agentless targets.
 	UpdateDevice                = "/devices/:uid"
 )
 
@@ -36,6 +45,7 @@ const (
 func (h *Handler) GetDeviceList(c gateway.Context) error {
 	type Query struct {
 		Status models.DeviceStatus `query:"status"`
+		Group  string              `query:"group"`
 		query.Paginator
 		query.Sorter
 		query.Filters
@@ -65,6 +75,7 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 		query.Status,
 		query.Paginator,
 		query.Filters,
+		query.Group,
 		query.Sorter,
 	)
 	if err != nil {
@@ -307,6 +318,165 @@ func (h *Handler) UpdateDeviceTag(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) SetDeviceAttributes(c gateway.Context) error {
+	var req requests.DeviceSetAttributes
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.SetDeviceAttributes(c.Ctx(), c.Tenant().ID, models.UID(req.UID), req.Attributes)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UnsetDeviceAttribute(c gateway.Context) error {
+	var req requests.DeviceUnsetAttribute
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.UnsetDeviceAttribute(c.Ctx(), c.Tenant().ID, models.UID(req.UID), req.Key)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) SetDeviceVia(c gateway.Context) error {
+	var req requests.DeviceSetVia
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	via := models.DeviceVia{Device: req.Device, Host: req.Host, Port: req.Port}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.SetDeviceVia(c.Ctx(), c.Tenant().ID, models.UID(req.UID), via)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UnsetDeviceVia(c gateway.Context) error {
+	var req requests.DeviceUnsetVia
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.UnsetDeviceVia(c.Ctx(), c.Tenant().ID, models.UID(req.UID))
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) CreateDeviceTarget(c gateway.Context) error {
+	var req requests.DeviceTargetCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var device *models.Device
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Accept, func() error {
+		var err error
+		device, err = h.service.CreateDeviceTarget(c.Ctx(), c.Tenant().ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
+func (h *Handler) GetDeviceCredentials(c gateway.Context) error {
+	var req requests.DeviceGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	credentials, err := h.service.GetDeviceCredentials(c.Ctx(), models.UID(req.UID))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, credentials)
+}
+
+// ListDeviceTargets lists the agentless targets to be probed by the agent of the device authenticated by its token.
+func (h *Handler) ListDeviceTargets(c gateway.Context) error {
+	req := requests.DeviceTargetList{Gateway: c.Request().Header.Get(client.DeviceUIDHeader)}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	targets, err := h.service.ListDeviceTargets(c.Ctx(), models.UID(req.Gateway))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, targets)
+}
+
+// ProbeDeviceTargets receives the probes to the agentless targets made by the agent of the device authenticated by its
+// token.
+func (h *Handler) ProbeDeviceTargets(c gateway.Context) error {
+	var req requests.DeviceTargetProbe
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	req.Gateway = c.Request().Header.Get(client.DeviceUIDHeader)
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ProbeDeviceTargets(c.Ctx(), models.UID(req.Gateway), req.Probes); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) UpdateDevice(c gateway.Context) error {
 	var req requests.DeviceUpdate
 	if err := c.Bind(&req); err != nil {
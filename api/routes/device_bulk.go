@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers/pkg/converter"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const BulkDevicesURL = "/devices/bulk"
+
+// BulkDevices applies an operation to a set of the namespace's devices. Each device goes through the same service
+// method as the single-device route, so the limits and side effects are the same, and the operation requires the same
+// permission. A failure on a device doesn't stop the operation; it's reported on the device's result.
+func (h *Handler) BulkDevices(c gateway.Context) error {
+	var req requests.DeviceBulk
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	ctx := c.Ctx()
+
+	operations := map[string]struct {
+		action int
+		apply  func(device *models.Device) error
+	}{
+		"accept": {guard.Actions.Device.Accept, func(device *models.Device) error {
+			return h.service.UpdateDeviceStatus(ctx, tenant, models.UID(device.UID), models.DeviceStatusAccepted)
+		}},
+		"reject": {guard.Actions.Device.Reject, func(device *models.Device) error {
+			return h.service.UpdateDeviceStatus(ctx, tenant, models.UID(device.UID), models.DeviceStatusRejected)
+		}},
+		"remove": {guard.Actions.Device.Remove, func(device *models.Device) error {
+			return h.service.DeleteDevice(ctx, models.UID(device.UID), tenant)
+		}},
+		"rename": {guard.Actions.Device.Rename, func(device *models.Device) error {
+			return h.service.RenameDevice(ctx, models.UID(device.UID), strings.ReplaceAll(req.Name, "{name}", device.Name), tenant)
+		}},
+		"add_tag": {guard.Actions.Device.CreateTag, func(device *models.Device) error {
+			return h.service.CreateDeviceTag(ctx, models.UID(device.UID), req.Tag)
+		}},
+		"remove_tag": {guard.Actions.Device.RemoveTag, func(device *models.Device) error {
+			return h.service.RemoveDeviceTag(ctx, models.UID(device.UID), req.Tag)
+		}},
+	}
+
+	operation := operations[req.Operation]
+
+	var results []responses.DeviceBulkResult
+	err := guard.EvaluatePermission(c.Role(), operation.action, func() error {
+		devices, err := h.service.SelectDevices(ctx, tenant, req.UIDs, query.Filters{Data: req.Filter})
+		if err != nil {
+			return err
+		}
+
+		selected := make(map[string]*models.Device, len(devices))
+		for i := range devices {
+			selected[devices[i].UID] = &devices[i]
+		}
+
+		uids := req.UIDs
+		if len(uids) == 0 {
+			for _, device := range devices {
+				uids = append(uids, device.UID)
+			}
+		}
+
+		results = make([]responses.DeviceBulkResult, 0, len(uids))
+		for _, uid := range uids {
+			device, ok := selected[uid]
+			if !ok {
+				results = append(results, deviceBulkResult(uid, svc.NewErrDeviceNotFound(models.UID(uid), nil)))
+
+				continue
+			}
+
+			results = append(results, deviceBulkResult(uid, operation.apply(device)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// deviceBulkResult converts the error of an operation on a device to its result, with the status code the
+// single-device route would have answered with.
+func deviceBulkResult(uid string, err error) responses.DeviceBulkResult {
+	if err == nil {
+		return responses.DeviceBulkResult{UID: uid, Status: http.StatusOK}
+	}
+
+	var e errors.Error
+	if errors.As(err, &e) && e.Layer == svc.ErrLayer {
+		return responses.DeviceBulkResult{UID: uid, Status: converter.FromErrServiceToHTTPStatus(e.Code), Error: e.Message}
+	}
+
+	return responses.DeviceBulkResult{UID: uid, Status: http.StatusInternalServerError, Error: http.StatusText(http.StatusInternalServerError)}
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestBulkDevices(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title           string
+		role            string
+		body            string
+		requiredMocks   func()
+		expectedStatus  int
+		expectedResults []responses.DeviceBulkResult
+	}{
+		{
+			title:          "fails when the devices are selected by UIDs and filter",
+			role:           guard.RoleOwner,
+			body:           `{"uids":["a"],"filter":[{"type":"property","params":{"name":"name","operator":"eq","value":"a"}}],"operation":"accept"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when no device is selected",
+			role:           guard.RoleOwner,
+			body:           `{"operation":"accept"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the operation is unknown",
+			role:           guard.RoleOwner,
+			body:           `{"uids":["a"],"operation":"connect"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the tag is missing",
+			role:           guard.RoleOwner,
+			body:           `{"uids":["a"],"operation":"add_tag"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the name template doesn't keep the device's name",
+			role:           guard.RoleOwner,
+			body:           `{"uids":["a"],"operation":"rename","name":"device"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is not allowed to apply the operation",
+			role:           guard.RoleObserver,
+			body:           `{"uids":["a"],"operation":"remove"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success with a result for each device selected by UIDs",
+			role:  guard.RoleOwner,
+			body:  `{"uids":["a","b","c"],"operation":"accept"}`,
+			requiredMocks: func() {
+				mock.On("SelectDevices", gomock.Anything, "tenant", []string{"a", "b", "c"}, gomock.Anything).
+					Return([]models.Device{{UID: "a"}, {UID: "c"}}, nil).Once()
+				mock.On("UpdateDeviceStatus", gomock.Anything, "tenant", models.UID("a"), models.DeviceStatusAccepted).
+					Return(nil).Once()
+				mock.On("UpdateDeviceStatus", gomock.Anything, "tenant", models.UID("c"), models.DeviceStatusAccepted).
+					Return(svc.NewErrDeviceMaxDevicesReached(1)).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedResults: []responses.DeviceBulkResult{
+				{UID: "a", Status: http.StatusOK},
+				{UID: "b", Status: http.StatusNotFound, Error: svc.ErrDeviceNotFound.Error()},
+				{UID: "c", Status: http.StatusForbidden, Error: svc.ErrMaxDeviceCountReached.Error()},
+			},
+		},
+		{
+			title: "success renaming the devices selected by filter",
+			role:  guard.RoleOwner,
+			body:  `{"filter":[{"type":"property","params":{"name":"info.platform","operator":"eq","value":"docker"}}],"operation":"rename","name":"prod-{name}"}`,
+			requiredMocks: func() {
+				mock.On("SelectDevices", gomock.Anything, "tenant", []string(nil), gomock.Anything).
+					Return([]models.Device{{UID: "a", Name: "edge"}}, nil).Once()
+				mock.On("RenameDevice", gomock.Anything, models.UID("a"), "prod-edge", "tenant").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedResults: []responses.DeviceBulkResult{
+				{UID: "a", Status: http.StatusOK},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/bulk", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expectedResults != nil {
+				var results []responses.DeviceBulkResult
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&results))
+				assert.Equal(t, tc.expectedResults, results)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
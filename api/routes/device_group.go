@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListDeviceGroupsURL  = "/devices/groups"
+	GetDeviceGroupURL    = "/devices/groups/:id"
+	CreateDeviceGroupURL = "/devices/groups"
+	UpdateDeviceGroupURL = "/devices/groups/:id"
+	DeleteDeviceGroupURL = "/devices/groups/:id"
+)
+
+func (h *Handler) ListDeviceGroups(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	groups, count, err := h.service.ListDeviceGroups(c.Ctx(), tenant, *paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *Handler) GetDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	group, err := h.service.GetDeviceGroup(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) CreateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		group, err = h.service.CreateDeviceGroup(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) UpdateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		group, err = h.service.UpdateDeviceGroup(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) DeleteDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		return h.service.DeleteDeviceGroup(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceGroup(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.DeviceGroupCreate
+		requiredMocks  func(body requests.DeviceGroupCreate)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the name is empty",
+			role:  guard.RoleOwner,
+			body: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: ""},
+			},
+			requiredMocks:  func(body requests.DeviceGroupCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the parent is not a valid ID",
+			role:  guard.RoleOwner,
+			body: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "lisbon", Parent: "europe"},
+			},
+			requiredMocks:  func(body requests.DeviceGroupCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is not allowed to manage the groups",
+			role:  guard.RoleOperator,
+			body: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "lisbon"},
+			},
+			requiredMocks:  func(body requests.DeviceGroupCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the name is already used",
+			role:  guard.RoleOwner,
+			body: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "lisbon"},
+			},
+			requiredMocks: func(body requests.DeviceGroupCreate) {
+				mock.On("CreateDeviceGroup", gomock.Anything, "tenant", body).
+					Return(nil, svc.NewErrDeviceGroupDuplicated("lisbon", nil)).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			title: "success when the group is created",
+			role:  guard.RoleOwner,
+			body: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{
+					Name:    "lisbon",
+					Parent:  "507f1f77bcf86cd799439011",
+					Devices: []string{"uid"},
+					Filter: requests.DeviceGroupFilter{
+						Tags:       []string{"edge"},
+						Attributes: map[string]string{"site": "lisbon"},
+					},
+				},
+			},
+			requiredMocks: func(body requests.DeviceGroupCreate) {
+				mock.On("CreateDeviceGroup", gomock.Anything, "tenant", body).Return(&models.DeviceGroup{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/groups", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceListByGroup(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListDevices", gomock.Anything, "tenant", models.DeviceStatus(""), gomock.Anything, gomock.Anything, "507f1f77bcf86cd799439011", gomock.Anything).
+		Return([]models.Device{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices?group=507f1f77bcf86cd799439011", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleOwner)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
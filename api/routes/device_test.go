@@ -322,6 +322,7 @@ func TestGetDeviceList(t *testing.T) {
 					status,
 					paginator,
 					filters,
+					"",
 					sorter,
 				).Return(nil, 0, svc.ErrDeviceNotFound).Once()
 			},
@@ -356,6 +357,7 @@ func TestGetDeviceList(t *testing.T) {
 					status,
 					paginator,
 					filters,
+					"",
 					sorter,
 				).Return([]models.Device{}, 1, nil).Once()
 			},
@@ -1003,3 +1005,418 @@ func TestUpdateDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDeviceAttributes(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.DeviceSetAttributes
+		requiredMocks  func(req requests.DeviceSetAttributes)
+		expectedStatus int
+	}{
+		{
+			title: "fails when no attribute is set",
+			payload: requests.DeviceSetAttributes{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Attributes:  map[string]string{},
+			},
+			requiredMocks:  func(req requests.DeviceSetAttributes) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when an attribute key has a '.' within its characters",
+			payload: requests.DeviceSetAttributes{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Attributes:  map[string]string{"site.name": "lab"},
+			},
+			requiredMocks:  func(req requests.DeviceSetAttributes) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device attribute limit is reached",
+			payload: requests.DeviceSetAttributes{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Attributes:  map[string]string{"site": "lab"},
+			},
+			requiredMocks: func(req requests.DeviceSetAttributes) {
+				mock.On("SetDeviceAttributes", gomock.Anything, "tenant-id", models.UID("1234"), req.Attributes).
+					Return(svc.NewErrDeviceAttributeLimit(svc.DeviceMaxAttributes, nil)).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the attributes are set",
+			payload: requests.DeviceSetAttributes{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Attributes:  map[string]string{"site": "lab", "rack": "r12"},
+			},
+			requiredMocks: func(req requests.DeviceSetAttributes) {
+				mock.On("SetDeviceAttributes", gomock.Anything, "tenant-id", models.UID("1234"), req.Attributes).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.payload)
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/devices/%s/attributes", tc.payload.UID), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUnsetDeviceAttribute(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		key            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the attribute is not set",
+			key:   "rack",
+			requiredMocks: func() {
+				mock.On("UnsetDeviceAttribute", gomock.Anything, "tenant-id", models.UID("1234"), "rack").
+					Return(svc.NewErrDeviceAttributeNotFound("rack", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the attribute is unset",
+			key:   "site",
+			requiredMocks: func() {
+				mock.On("UnsetDeviceAttribute", gomock.Anything, "tenant-id", models.UID("1234"), "site").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/devices/1234/attributes/%s", tc.key), nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSetDeviceVia(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.DeviceSetVia
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device to jump from is not set",
+			payload: requests.DeviceSetVia{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Host:        "192.168.0.10",
+			},
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the host is invalid",
+			payload: requests.DeviceSetVia{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Device:      "5678",
+				Host:        "invalid host",
+			},
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device would be reached through itself",
+			payload: requests.DeviceSetVia{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Device:      "1234",
+				Host:        "192.168.0.10",
+			},
+			requiredMocks: func() {
+				mock.On("SetDeviceVia", gomock.Anything, "tenant-id", models.UID("1234"), models.DeviceVia{Device: "1234", Host: "192.168.0.10"}).
+					Return(svc.NewErrDeviceViaInvalid(map[string]interface{}{"device": "1234"}, nil)).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the via is set",
+			payload: requests.DeviceSetVia{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Device:      "5678",
+				Host:        "gateway.lan",
+				Port:        2222,
+			},
+			requiredMocks: func() {
+				mock.On("SetDeviceVia", gomock.Anything, "tenant-id", models.UID("1234"), models.DeviceVia{Device: "5678", Host: "gateway.lan", Port: 2222}).
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/devices/%s/via", tc.payload.UID), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUnsetDeviceVia(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("UnsetDeviceVia", gomock.Anything, "tenant-id", models.UID("1234")).
+					Return(svc.NewErrDeviceNotFound(models.UID("1234"), nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the via is unset",
+			requiredMocks: func() {
+				mock.On("UnsetDeviceVia", gomock.Anything, "tenant-id", models.UID("1234")).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/devices/1234/via", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDeviceTarget(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.DeviceTargetCreate
+		requiredMocks  func(req requests.DeviceTargetCreate)
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the gateway is not set",
+			payload:        requests.DeviceTargetCreate{Name: "switch", Host: "192.168.0.2"},
+			requiredMocks:  func(req requests.DeviceTargetCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the name is invalid",
+			payload:        requests.DeviceTargetCreate{Name: "switch 01", Gateway: "1234", Host: "192.168.0.2"},
+			requiredMocks:  func(req requests.DeviceTargetCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:   "fails when the gateway device is not found",
+			payload: requests.DeviceTargetCreate{Name: "switch", Gateway: "1234", Host: "192.168.0.2"},
+			requiredMocks: func(req requests.DeviceTargetCreate) {
+				mock.On("CreateDeviceTarget", gomock.Anything, "tenant-id", req).
+					Return(nil, svc.NewErrDeviceNotFound(models.UID("1234"), nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "success when the target is registered",
+			payload: requests.DeviceTargetCreate{Name: "switch", Gateway: "1234", Host: "192.168.0.2", Port: 2222, Password: "secret"},
+			requiredMocks: func(req requests.DeviceTargetCreate) {
+				mock.On("CreateDeviceTarget", gomock.Anything, "tenant-id", req).
+					Return(&models.Device{UID: "5678", Name: "switch", Agentless: true}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.payload)
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/targets", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceTargets(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		device         string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is not authenticated",
+			device:         "",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "success when the targets are listed",
+			device: "1234",
+			requiredMocks: func() {
+				mock.On("ListDeviceTargets", gomock.Anything, models.UID("1234")).
+					Return([]models.DeviceTarget{{UID: "5678", Host: "192.168.0.2", Port: 22}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/targets", nil)
+			req.Header.Set("X-Device-UID", tc.device)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestProbeDeviceTargets(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		device         string
+		probes         []models.DeviceTargetProbe
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is not authenticated",
+			device:         "",
+			probes:         []models.DeviceTargetProbe{{UID: "5678", Online: true}},
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "success when the probes are reported",
+			device: "1234",
+			probes: []models.DeviceTargetProbe{{UID: "5678", Online: true}},
+			requiredMocks: func() {
+				mock.On("ProbeDeviceTargets", gomock.Anything, models.UID("1234"), []models.DeviceTargetProbe{{UID: "5678", Online: true}}).
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(map[string]interface{}{"probes": tc.probes})
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/targets/probes", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Device-UID", tc.device)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
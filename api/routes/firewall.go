@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetFirewallRulesURL      = "/firewall/rules"
+	GetFirewallRuleURL       = "/firewall/rules/:id"
+	CreateFirewallRuleURL    = "/firewall/rules"
+	UpdateFirewallRuleURL    = "/firewall/rules/:id"
+	ReorderFirewallRulesURL  = "/firewall/rules/order" // Set the evaluation order of the firewall rules.
+	DeleteFirewallRuleURL    = "/firewall/rules/:id"
+	EvaluateFirewallRulesURL = "/firewall/evaluate"
+)
+
+func (h *Handler) GetFirewallRules(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	// TODO: normalize is not required when request is privileged
+	paginator.Normalize()
+
+	rules, count, err := h.service.ListFirewallRules(c.Ctx(), *paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rule, err := h.service.GetFirewallRule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Create, func() error {
+		var err error
+		rule, err = h.service.CreateFirewallRule(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Edit, func() error {
+		var err error
+		rule, err = h.service.UpdateFirewallRule(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) ReorderFirewallRules(c gateway.Context) error {
+	var req requests.FirewallRulesReorder
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Edit, func() error {
+		return h.service.ReorderFirewallRules(c.Ctx(), tenant, req.Rules)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) DeleteFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Remove, func() error {
+		return h.service.DeleteFirewallRule(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateFirewall(c gateway.Context) error {
+	var req requests.FirewallEvaluate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ok, err := h.service.EvaluateFirewall(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
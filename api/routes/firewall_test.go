@@ -0,0 +1,210 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateFirewallRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.FirewallRuleCreate
+		requiredMocks  func(body requests.FirewallRuleCreate)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the action is invalid",
+			role:  guard.RoleOwner,
+			body: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "reject",
+					SourceIP: ".*",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Hostname: ".*"},
+				},
+			},
+			requiredMocks:  func(body requests.FirewallRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the source IP is not a valid regexp",
+			role:  guard.RoleOwner,
+			body: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "allow",
+					SourceIP: "[",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Hostname: ".*"},
+				},
+			},
+			requiredMocks:  func(body requests.FirewallRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is not allowed to create firewall rules",
+			role:  guard.RoleObserver,
+			body: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "allow",
+					SourceIP: ".*",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Hostname: ".*"},
+				},
+			},
+			requiredMocks:  func(body requests.FirewallRuleCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the firewall rule is created",
+			role:  guard.RoleOwner,
+			body: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "allow",
+					SourceIP: ".*",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Hostname: ".*"},
+				},
+			},
+			requiredMocks: func(body requests.FirewallRuleCreate) {
+				mock.On("CreateFirewallRule", gomock.Anything, "tenant", body).Return(&models.FirewallRule{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/firewall/rules", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteFirewallRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		requiredMocks  func(id string)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the firewall rule is not found",
+			id:    "6504b7bd9b6c4a63a9ccc053",
+			requiredMocks: func(id string) {
+				mock.On("DeleteFirewallRule", gomock.Anything, "tenant", id).Return(svc.ErrFirewallRuleNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the firewall rule is deleted",
+			id:    "6504b7bd9b6c4a63a9ccc053",
+			requiredMocks: func(id string) {
+				mock.On("DeleteFirewallRule", gomock.Anything, "tenant", id).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.id)
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/firewall/rules/%s", tc.id), nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateFirewall(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		query          requests.FirewallEvaluate
+		requiredMocks  func(query requests.FirewallEvaluate)
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the lookup is incomplete",
+			query:          requests.FirewallEvaluate{Domain: "namespace"},
+			requiredMocks:  func(query requests.FirewallEvaluate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when a firewall rule denies the connection",
+			query: requests.FirewallEvaluate{Domain: "namespace", Name: "device", Username: "root", IPAddress: "127.0.0.1"},
+			requiredMocks: func(query requests.FirewallEvaluate) {
+				mock.On("EvaluateFirewall", gomock.Anything, query).Return(false, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the connection is allowed",
+			query: requests.FirewallEvaluate{Domain: "namespace", Name: "device", Username: "root", IPAddress: "127.0.0.1"},
+			requiredMocks: func(query requests.FirewallEvaluate) {
+				mock.On("EvaluateFirewall", gomock.Anything, query).Return(true, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.query)
+
+			url := fmt.Sprintf("/internal/firewall/evaluate?domain=%s&name=%s&username=%s&ip_address=%s", tc.query.Domain, tc.query.Name, tc.query.Username, tc.query.IPAddress)
+
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListForwardingRulesURL  = "/forwarding/rules"
+	GetForwardingRuleURL    = "/forwarding/rules/:id"
+	CreateForwardingRuleURL = "/forwarding/rules"
+	UpdateForwardingRuleURL = "/forwarding/rules/:id"
+	DeleteForwardingRuleURL = "/forwarding/rules/:id"
+	EvaluateForwardingURL   = "/forwarding/evaluate"
+	// EvaluateReverseForwardingURL is used by the agent, authenticated by its token, before accepting a remote port
+	// forwarding.
+	EvaluateReverseForwardingURL = "/forwarding/reverse"
+)
+
+func (h *Handler) ListForwardingRules(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rules, count, err := h.service.ListForwardingRules(c.Ctx(), tenant, *paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetForwardingRule(c gateway.Context) error {
+	var req requests.ForwardingRuleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rule, err := h.service.GetForwardingRule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateForwardingRule(c gateway.Context) error {
+	var req requests.ForwardingRuleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.ForwardingRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		rule, err = h.service.CreateForwardingRule(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateForwardingRule(c gateway.Context) error {
+	var req requests.ForwardingRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.ForwardingRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		rule, err = h.service.UpdateForwardingRule(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteForwardingRule(c gateway.Context) error {
+	var req requests.ForwardingRuleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		return h.service.DeleteForwardingRule(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateForwarding(c gateway.Context) error {
+	var req requests.ForwardingEvaluate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ok, err := h.service.EvaluateForwarding(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateReverseForwarding(c gateway.Context) error {
+	req := requests.ReverseForwardingEvaluate{UID: c.Request().Header.Get(client.DeviceUIDHeader)}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ok, err := h.service.EvaluateReverseForwarding(c.Ctx(), models.UID(req.UID))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
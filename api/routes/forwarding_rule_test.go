@@ -0,0 +1,215 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateForwardingRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.ForwardingRuleCreate
+		requiredMocks  func(body requests.ForwardingRuleCreate)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the host is not a CIDR",
+			role:  guard.RoleOwner,
+			body: requests.ForwardingRuleCreate{
+				ForwardingRuleFields: requests.ForwardingRuleFields{
+					Action: "allow",
+					Host:   "10.0.0.1",
+					Ports:  requests.ForwardingPortRange{Start: 22, End: 22},
+				},
+			},
+			requiredMocks:  func(body requests.ForwardingRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the port range is reversed",
+			role:  guard.RoleOwner,
+			body: requests.ForwardingRuleCreate{
+				ForwardingRuleFields: requests.ForwardingRuleFields{
+					Action: "allow",
+					Host:   "10.0.0.0/8",
+					Ports:  requests.ForwardingPortRange{Start: 8080, End: 80},
+				},
+			},
+			requiredMocks:  func(body requests.ForwardingRuleCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is not allowed to manage the rules",
+			role:  guard.RoleOperator,
+			body: requests.ForwardingRuleCreate{
+				ForwardingRuleFields: requests.ForwardingRuleFields{
+					Action: "deny",
+					Host:   "0.0.0.0/0",
+					Ports:  requests.ForwardingPortRange{Start: 1, End: 65535},
+				},
+			},
+			requiredMocks:  func(body requests.ForwardingRuleCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the rule is created",
+			role:  guard.RoleOwner,
+			body: requests.ForwardingRuleCreate{
+				ForwardingRuleFields: requests.ForwardingRuleFields{
+					Action: "allow",
+					Active: true,
+					Host:   "10.0.0.0/8",
+					Ports:  requests.ForwardingPortRange{Start: 5432, End: 5432},
+					Filter: requests.ForwardingFilter{Username: "^postgres$"},
+				},
+			},
+			requiredMocks: func(body requests.ForwardingRuleCreate) {
+				mock.On("CreateForwardingRule", gomock.Anything, "tenant", body).Return(&models.ForwardingRule{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/forwarding/rules", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateForwarding(t *testing.T) {
+	mock := new(mocks.Service)
+
+	req := requests.ForwardingEvaluate{
+		TenantID: "tenant",
+		Username: "root",
+		Host:     "10.0.0.1",
+		Port:     22,
+	}
+
+	cases := []struct {
+		title          string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the port is missing",
+			query:          "tenant_id=tenant&username=root&host=10.0.0.1",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the forwarding is denied",
+			query: "tenant_id=tenant&username=root&host=10.0.0.1&port=22",
+			requiredMocks: func() {
+				mock.On("EvaluateForwarding", gomock.Anything, req).Return(false, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the forwarding is allowed",
+			query: "tenant_id=tenant&username=root&host=10.0.0.1&port=22",
+			requiredMocks: func() {
+				mock.On("EvaluateForwarding", gomock.Anything, req).Return(true, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/forwarding/evaluate?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateReverseForwarding(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		device         string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is missing",
+			device:         "",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "fails when the reverse forwarding is denied",
+			device: "uid",
+			requiredMocks: func() {
+				mock.On("EvaluateReverseForwarding", gomock.Anything, models.UID("uid")).Return(false, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:  "success when the reverse forwarding is allowed",
+			device: "uid",
+			requiredMocks: func() {
+				mock.On("EvaluateReverseForwarding", gomock.Anything, models.UID("uid")).Return(true, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/forwarding/reverse", nil)
+			req.Header.Set("X-Device-UID", tc.device)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
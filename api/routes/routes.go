@@ -34,16 +34,23 @@ func NewRouter(service services.Service) *echo.Echo {
 	internalAPI.POST(OfflineDeviceURL, gateway.Handler(handler.OfflineDevice))
 	internalAPI.POST(HeartbeatDeviceURL, gateway.Handler(handler.HeartbeatDevice))
 	internalAPI.GET(LookupDeviceURL, gateway.Handler(handler.LookupDevice))
+	internalAPI.GET(GetDeviceCredentialsURL, gateway.Handler(handler.GetDeviceCredentials))
 
 	internalAPI.PATCH(SetSessionAuthenticatedURL, gateway.Handler(handler.SetSessionAuthenticated))
 	internalAPI.POST(CreateSessionURL, gateway.Handler(handler.CreateSession))
 	internalAPI.POST(FinishSessionURL, gateway.Handler(handler.FinishSession))
 	internalAPI.POST(KeepAliveSessionURL, gateway.Handler(handler.KeepAliveSession))
 	internalAPI.POST(RecordSessionURL, gateway.Handler(handler.RecordSession))
+	internalAPI.GET(ShadowSessionURL, gateway.Handler(handler.ShadowSession))
+	internalAPI.POST(CreateSessionEventURL, gateway.Handler(handler.CreateSessionEvent))
 
 	internalAPI.GET(GetPublicKeyURL, gateway.Handler(handler.GetPublicKey))
 	internalAPI.POST(CreatePrivateKeyURL, gateway.Handler(handler.CreatePrivateKey))
 	internalAPI.POST(EvaluateKeyURL, gateway.Handler(handler.EvaluateKey))
+	internalAPI.GET(ListInternalCertificateAuthoritiesURL, gateway.Handler(handler.ListInternalCertificateAuthorities))
+
+	internalAPI.GET(EvaluateFirewallRulesURL, gateway.Handler(handler.EvaluateFirewall))
+	internalAPI.GET(EvaluateForwardingURL, gateway.Handler(handler.EvaluateForwarding))
 
 	// Public routes for external access through API gateway
 	publicAPI := e.Group("/api")
@@ -72,10 +79,38 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice))
 	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice))
 	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus))
+	publicAPI.POST(BulkDevicesURL, gateway.Handler(handler.BulkDevices))
+
+	publicAPI.GET(ListAutoAcceptRulesURL, gateway.Handler(handler.ListAutoAcceptRules))
+	publicAPI.GET(GetAutoAcceptRuleURL, gateway.Handler(handler.GetAutoAcceptRule))
+	publicAPI.POST(CreateAutoAcceptRuleURL, gateway.Handler(handler.CreateAutoAcceptRule))
+	publicAPI.PUT(UpdateAutoAcceptRuleURL, gateway.Handler(handler.UpdateAutoAcceptRule))
+	publicAPI.DELETE(DeleteAutoAcceptRuleURL, gateway.Handler(handler.DeleteAutoAcceptRule))
+
+	publicAPI.GET(ListForwardingRulesURL, gateway.Handler(handler.ListForwardingRules))
+	publicAPI.GET(GetForwardingRuleURL, gateway.Handler(handler.GetForwardingRule))
+	publicAPI.POST(CreateForwardingRuleURL, gateway.Handler(handler.CreateForwardingRule))
+	publicAPI.PUT(UpdateForwardingRuleURL, gateway.Handler(handler.UpdateForwardingRule))
+	publicAPI.DELETE(DeleteForwardingRuleURL, gateway.Handler(handler.DeleteForwardingRule))
+	publicAPI.GET(EvaluateReverseForwardingURL, gateway.Handler(handler.EvaluateReverseForwarding))
+
+	publicAPI.GET(ListDeviceGroupsURL, gateway.Handler(handler.ListDeviceGroups))
+	publicAPI.GET(GetDeviceGroupURL, gateway.Handler(handler.GetDeviceGroup))
+	publicAPI.POST(CreateDeviceGroupURL, gateway.Handler(handler.CreateDeviceGroup))
+	publicAPI.PUT(UpdateDeviceGroupURL, gateway.Handler(handler.UpdateDeviceGroup))
+	publicAPI.DELETE(DeleteDeviceGroupURL, gateway.Handler(handler.DeleteDeviceGroup))
 
 	publicAPI.POST(CreateTagURL, gateway.Handler(handler.CreateDeviceTag))
 	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
+	publicAPI.PUT(SetDeviceAttributesURL, gateway.Handler(handler.SetDeviceAttributes))
+	publicAPI.DELETE(UnsetDeviceAttributeURL, gateway.Handler(handler.UnsetDeviceAttribute))
+	publicAPI.PUT(SetDeviceViaURL, gateway.Handler(handler.SetDeviceVia))
+	publicAPI.DELETE(UnsetDeviceViaURL, gateway.Handler(handler.UnsetDeviceVia))
+	publicAPI.POST(CreateDeviceTargetURL, gateway.Handler(handler.CreateDeviceTarget))
+
+	publicAPI.GET(ListDeviceTargetsURL, gateway.Handler(handler.ListDeviceTargets))
+	publicAPI.POST(ProbeDeviceTargetsURL, gateway.Handler(handler.ProbeDeviceTargets))
 
 	publicAPI.GET(GetTagsURL, gateway.Handler(handler.GetTags))
 	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag))
@@ -84,6 +119,11 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.GET(GetSessionsURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSessionList)))
 	publicAPI.GET(GetSessionURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSession)))
 	publicAPI.GET(PlaySessionURL, gateway.Handler(handler.PlaySession))
+	publicAPI.GET(ExportSessionRecordURL, gateway.Handler(handler.ExportSessionRecord))
+	publicAPI.POST(CloseSessionURL, gateway.Handler(handler.CloseSession))
+	publicAPI.POST(ShareSessionURL, gateway.Handler(handler.ShareSession))
+	publicAPI.PATCH(ShareSessionInputURL, gateway.Handler(handler.SetSessionShareInput))
+	publicAPI.GET(ListSessionEventsURL, gateway.Handler(handler.ListSessionEvents))
 	publicAPI.DELETE(RecordSessionURL, gateway.Handler(handler.DeleteRecordedSession))
 
 	publicAPI.GET(GetStatsURL, apiMiddleware.Authorize(gateway.Handler(handler.GetStats)))
@@ -95,10 +135,28 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.PUT(UpdatePublicKeyURL, gateway.Handler(handler.UpdatePublicKey))
 	publicAPI.DELETE(DeletePublicKeyURL, gateway.Handler(handler.DeletePublicKey))
 
+	publicAPI.GET(ListCertificateAuthoritiesURL, gateway.Handler(handler.ListCertificateAuthorities))
+	publicAPI.POST(CreateCertificateAuthorityURL, gateway.Handler(handler.CreateCertificateAuthority))
+	publicAPI.DELETE(DeleteCertificateAuthorityURL, gateway.Handler(handler.DeleteCertificateAuthority))
+
 	publicAPI.POST(AddPublicKeyTagURL, gateway.Handler(handler.AddPublicKeyTag))
 	publicAPI.DELETE(RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag))
 	publicAPI.PUT(UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags))
 
+	publicAPI.GET(GetFirewallRulesURL, apiMiddleware.Authorize(gateway.Handler(handler.GetFirewallRules)))
+	publicAPI.GET(GetFirewallRuleURL, apiMiddleware.Authorize(gateway.Handler(handler.GetFirewallRule)))
+	publicAPI.POST(CreateFirewallRuleURL, gateway.Handler(handler.CreateFirewallRule))
+	publicAPI.PUT(ReorderFirewallRulesURL, gateway.Handler(handler.ReorderFirewallRules))
+	publicAPI.PUT(UpdateFirewallRuleURL, gateway.Handler(handler.UpdateFirewallRule))
+	publicAPI.DELETE(DeleteFirewallRuleURL, gateway.Handler(handler.DeleteFirewallRule))
+
+	publicAPI.GET(ListWebhooksURL, gateway.Handler(handler.ListWebhooks))
+	publicAPI.GET(GetWebhookURL, gateway.Handler(handler.GetWebhook))
+	publicAPI.POST(CreateWebhookURL, gateway.Handler(handler.CreateWebhook))
+	publicAPI.PATCH(UpdateWebhookURL, gateway.Handler(handler.UpdateWebhook))
+	publicAPI.DELETE(DeleteWebhookURL, gateway.Handler(handler.DeleteWebhook))
+	publicAPI.GET(ListWebhookDeliveriesURL, gateway.Handler(handler.ListWebhookDeliveries))
+
 	publicAPI.GET(ListNamespaceURL, gateway.Handler(handler.GetNamespaceList))
 	publicAPI.GET(GetNamespaceURL, gateway.Handler(handler.GetNamespace))
 	publicAPI.POST(CreateNamespaceURL, gateway.Handler(handler.CreateNamespace))
@@ -107,6 +165,7 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.POST(AddNamespaceUserURL, gateway.Handler(handler.AddNamespaceUser))
 	publicAPI.DELETE(RemoveNamespaceUserURL, gateway.Handler(handler.RemoveNamespaceUser))
 	publicAPI.PATCH(EditNamespaceUserURL, gateway.Handler(handler.EditNamespaceUser))
+	publicAPI.GET(ListAuditLogsURL, gateway.Handler(handler.ListAuditLogs))
 	publicAPI.GET(HealthCheckURL, gateway.Handler(handler.EvaluateHealth))
 
 	return e
@@ -1,12 +1,17 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/asciicast"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -19,6 +24,13 @@ const (
 	KeepAliveSessionURL        = "/sessions/:uid/keepalive"
 	RecordSessionURL           = "/sessions/:uid/record"
 	PlaySessionURL             = "/sessions/:uid/play"
+	ExportSessionRecordURL     = "/sessions/:uid/record.cast" // Export the session's record as an asciicast file.
+	CloseSessionURL            = "/sessions/:uid/close"
+	ShadowSessionURL           = "/sessions/:uid/shadow"
+	ShareSessionURL            = "/sessions/:uid/share"
+	ShareSessionInputURL       = "/sessions/:uid/share/:guest"
+	CreateSessionEventURL      = "/sessions/:uid/events"
+	ListSessionEventsURL       = "/sessions/:uid/events"
 )
 
 const (
@@ -103,9 +115,108 @@ func (h *Handler) FinishSession(c gateway.Context) error {
 		return err
 	}
 
+	// NOTICE: The reason is set before deactivating the session to be part of the session finished events.
+	if req.Reason != "" {
+		if err := h.service.SetSessionCloseReason(c.Ctx(), models.UID(req.UID), models.SessionCloseReason(req.Reason)); err != nil {
+			return err
+		}
+	}
+
 	return h.service.DeactivateSession(c.Ctx(), models.UID(req.UID))
 }
 
+func (h *Handler) CloseSession(c gateway.Context) error {
+	var req requests.SessionClose
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var user string
+	if c.ID() != nil {
+		user = c.ID().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Close, func() error {
+		return h.service.CloseSession(c.Ctx(), models.UID(req.UID), user)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ShadowSession authorizes the user to shadow a session, watching its terminal output while it is open.
+func (h *Handler) ShadowSession(c gateway.Context) error {
+	var req requests.SessionGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Shadow, func() error {
+		_, err := h.service.GetSession(c.Ctx(), models.UID(req.UID))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ShareSession(c gateway.Context) error {
+	var req requests.SessionShare
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var share *responses.SessionShare
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Share, func() error {
+		var err error
+		share, err = h.service.ShareSession(c.Ctx(), req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, share)
+}
+
+func (h *Handler) SetSessionShareInput(c gateway.Context) error {
+	var req requests.SessionShareInput
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Share, func() error {
+		return h.service.SetSessionShareInput(c.Ctx(), req)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) KeepAliveSession(c gateway.Context) error {
 	var req requests.SessionKeepAlive
 	if err := c.Bind(&req); err != nil {
@@ -119,6 +230,58 @@ func (h *Handler) KeepAliveSession(c gateway.Context) error {
 	return h.service.KeepAliveSession(c.Ctx(), models.UID(req.UID))
 }
 
+func (h *Handler) CreateSessionEvent(c gateway.Context) error {
+	var req requests.SessionEventCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.CreateSessionEvent(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListSessionEvents lists what was done in the session besides its terminal, from the oldest to the newest event.
+func (h *Handler) ListSessionEvents(c gateway.Context) error {
+	var req requests.SessionEventList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var events []models.SessionEvent
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Details, func() error {
+		var err error
+		events, count, err = h.service.ListSessionEvents(c.Ctx(), tenant, models.UID(req.UID), req.Paginator)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, events)
+}
+
 func (h *Handler) RecordSession(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
@@ -128,5 +291,54 @@ func (h *Handler) PlaySession(c gateway.Context) error {
 }
 
 func (h *Handler) DeleteRecordedSession(c gateway.Context) error {
+	var req requests.SessionGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Remove, func() error {
+		return h.service.DeleteRecordedSession(c.Ctx(), models.UID(req.UID))
+	})
+	if err != nil {
+		return err
+	}
+
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) ExportSessionRecord(c gateway.Context) error {
+	var req requests.SessionGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var session *models.Session
+	var frames []models.RecordedSession
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		if session, err = h.service.GetSession(c.Ctx(), models.UID(req.UID)); err != nil {
+			return err
+		}
+
+		frames, err = h.service.GetSessionRecordFrames(c.Ctx(), models.UID(req.UID))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, asciicast.ContentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", req.UID+".cast"))
+	c.Response().WriteHeader(http.StatusOK)
+
+	return asciicast.Encode(c.Response(), session.Term, frames)
+}
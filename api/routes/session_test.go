@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	svc "github.com/shellhub-io/shellhub/api/services"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/services/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	gomock "github.com/stretchr/testify/mock"
@@ -267,6 +269,7 @@ func TestFinishSession(t *testing.T) {
 	cases := []struct {
 		title          string
 		uid            string
+		body           string
 		requiredMocks  func()
 		expectedStatus int
 	}{
@@ -292,13 +295,30 @@ func TestFinishSession(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			title:          "fails when the close reason is unknown",
+			uid:            "123",
+			body:           `{"reason": "unknown"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when try to finishing an existing session closed due to inactivity",
+			uid:   "12345",
+			body:  `{"reason": "idle_timeout"}`,
+			requiredMocks: func() {
+				mock.On("SetSessionCloseReason", gomock.Anything, models.UID("12345"), models.SessionCloseReasonIdleTimeout).Return(nil).Once()
+				mock.On("DeactivateSession", gomock.Anything, models.UID("12345")).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.title, func(t *testing.T) {
 			tc.requiredMocks()
 
-			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/finish", tc.uid), nil)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/finish", tc.uid), strings.NewReader(tc.body))
 			req.Header.Set("Content-Type", "application/json")
 			req.Header.Set("X-Role", guard.RoleOwner)
 			rec := httptest.NewRecorder()
@@ -312,3 +332,469 @@ func TestFinishSession(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestCreateSessionEvent(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the event's type is unknown",
+			uid:            "123",
+			body:           `{"type": "unknown"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the session is not found",
+			uid:   "1234",
+			body:  `{"type": "exec", "data": {"command": "uptime"}}`,
+			requiredMocks: func() {
+				mock.On("CreateSessionEvent", gomock.Anything, requests.SessionEventCreate{
+					SessionIDParam: requests.SessionIDParam{UID: "1234"},
+					Type:           "exec",
+					Data:           map[string]interface{}{"command": "uptime"},
+				}).Return(svc.ErrSessionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the event is created",
+			uid:   "123",
+			body:  `{"type": "sftp", "timestamp": "2023-01-01T12:00:00Z", "data": {"operation": "remove", "path": "/tmp/file"}}`,
+			requiredMocks: func() {
+				mock.On("CreateSessionEvent", gomock.Anything, requests.SessionEventCreate{
+					SessionIDParam: requests.SessionIDParam{UID: "123"},
+					Type:           "sftp",
+					Timestamp:      time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					Data:           map[string]interface{}{"operation": "remove", "path": "/tmp/file"},
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/events", tc.uid), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCloseSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed to close sessions",
+			uid:            "123",
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the session does not exist",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CloseSession", gomock.Anything, models.UID("1234"), "user").Return(svc.ErrSessionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the session is closed",
+			uid:   "123",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CloseSession", gomock.Anything, models.UID("123"), "user").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/sessions/%s/close", tc.uid), nil)
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "user")
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestShadowSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed to shadow sessions",
+			uid:            "123",
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the session does not exist",
+			uid:   "1234",
+			role:  guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("GetSession", gomock.Anything, models.UID("1234")).
+					Return(nil, svc.NewErrSessionNotFound(models.UID("1234"), store.ErrNoDocuments)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the session exists",
+			uid:   "123",
+			role:  guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("GetSession", gomock.Anything, models.UID("123")).
+					Return(&models.Session{UID: "123", Active: true}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/internal/sessions/%s/shadow", tc.uid), nil)
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListSessionEvents(t *testing.T) {
+	mock := new(mocks.Service)
+
+	timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		title          string
+		uid            string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+		expected       []models.SessionEvent
+	}{
+		{
+			title:          "fails when the role is unknown",
+			uid:            "123",
+			role:           "",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the session does not exist",
+			uid:   "1234",
+			role:  guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("ListSessionEvents", gomock.Anything, "00000000-0000-4000-0000-000000000000", models.UID("1234"), query.Paginator{Page: 1, PerPage: 10}).
+					Return(nil, 0, svc.NewErrSessionNotFound(models.UID("1234"), store.ErrNoDocuments)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the session exists",
+			uid:   "123",
+			role:  guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("ListSessionEvents", gomock.Anything, "00000000-0000-4000-0000-000000000000", models.UID("123"), query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.SessionEvent{
+						{Session: "123", Type: models.SessionEventTypeShell, Timestamp: timestamp},
+						{Session: "123", Type: models.SessionEventTypeExitStatus, Timestamp: timestamp, Data: map[string]interface{}{"status": float64(0)}},
+					}, 2, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected: []models.SessionEvent{
+				{Session: "123", Type: models.SessionEventTypeShell, Timestamp: timestamp},
+				{Session: "123", Type: models.SessionEventTypeExitStatus, Timestamp: timestamp, Data: map[string]interface{}{"status": float64(0)}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/sessions/%s/events?page=1&per_page=10", tc.uid), nil)
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var events []models.SessionEvent
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&events))
+				assert.Equal(t, tc.expected, events)
+				assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestShareSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		body   string
+		status int
+	}
+
+	cases := []struct {
+		title         string
+		uid           string
+		role          string
+		body          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			title:         "fails when the role is not allowed to share sessions",
+			uid:           "123",
+			role:          guard.RoleOperator,
+			body:          `{"guest":"guest"}`,
+			requiredMocks: func() {},
+			expected:      Expected{body: "", status: http.StatusForbidden},
+		},
+		{
+			title:         "fails when the guest is not set",
+			uid:           "123",
+			role:          guard.RoleOwner,
+			body:          `{}`,
+			requiredMocks: func() {},
+			expected:      Expected{body: "", status: http.StatusBadRequest},
+		},
+		{
+			title: "fails when the session is closed",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			body:  `{"guest":"guest"}`,
+			requiredMocks: func() {
+				mock.On("ShareSession", gomock.Anything, requests.SessionShare{SessionIDParam: requests.SessionIDParam{UID: "1234"}, Guest: "guest"}).
+					Return(nil, svc.NewErrSessionClosed("1234", nil)).Once()
+			},
+			expected: Expected{body: "", status: http.StatusBadRequest},
+		},
+		{
+			title: "success when the session is shared",
+			uid:   "123",
+			role:  guard.RoleOwner,
+			body:  `{"guest":"guest","input":true}`,
+			requiredMocks: func() {
+				mock.On("ShareSession", gomock.Anything, requests.SessionShare{SessionIDParam: requests.SessionIDParam{UID: "123"}, Guest: "guest", Input: true}).
+					Return(&responses.SessionShare{Token: "token", Guest: "guest", Input: true}, nil).Once()
+			},
+			expected: Expected{body: "{\"token\":\"token\",\"guest\":\"guest\",\"input\":true}\n", status: http.StatusOK},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/sessions/%s/share", tc.uid), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+			if tc.expected.body != "" {
+				assert.Equal(t, tc.expected.body, rec.Body.String())
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSetSessionShareInput(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		guest          string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed to share sessions",
+			uid:            "123",
+			guest:          "guest",
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the session does not exist",
+			uid:   "1234",
+			guest: "guest",
+			role:  guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("SetSessionShareInput", gomock.Anything, requests.SessionShareInput{SessionIDParam: requests.SessionIDParam{UID: "1234"}, Guest: "guest"}).
+					Return(svc.ErrSessionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the guest's input is revoked",
+			uid:   "123",
+			guest: "guest",
+			role:  guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("SetSessionShareInput", gomock.Anything, requests.SessionShareInput{SessionIDParam: requests.SessionIDParam{UID: "123"}, Guest: "guest"}).
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/sessions/%s/share/%s", tc.uid, tc.guest), strings.NewReader(`{"input":false}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestExportSessionRecord(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		body   string
+		status int
+	}
+
+	cases := []struct {
+		title         string
+		uid           string
+		role          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			title:         "fails when the role is not allowed to play sessions",
+			uid:           "1234",
+			role:          "",
+			requiredMocks: func() {},
+			expected:      Expected{body: "", status: http.StatusForbidden},
+		},
+		{
+			title: "fails when the session does not exist",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("GetSession", gomock.Anything, models.UID("1234")).
+					Return(nil, svc.NewErrSessionNotFound(models.UID("1234"), store.ErrNoDocuments)).Once()
+			},
+			expected: Expected{body: "", status: http.StatusNotFound},
+		},
+		{
+			title: "success when the session exists",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("GetSession", gomock.Anything, models.UID("1234")).
+					Return(&models.Session{UID: "1234", Term: "xterm"}, nil).Once()
+				mock.On("GetSessionRecordFrames", gomock.Anything, models.UID("1234")).
+					Return([]models.RecordedSession{
+						{UID: "1234", Message: "ls", Time: time.Unix(0, 0), Width: 80, Height: 24},
+					}, nil).Once()
+			},
+			expected: Expected{
+				body:   "{\"version\":2,\"width\":80,\"height\":24,\"env\":{\"TERM\":\"xterm\"}}\n[0,\"o\",\"ls\"]\n",
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/sessions/%s/record.cast", tc.uid), nil)
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+			assert.Equal(t, tc.expected.body, rec.Body.String())
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,197 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListWebhooksURL          = "/webhooks"
+	GetWebhookURL            = "/webhooks/:id"
+	CreateWebhookURL         = "/webhooks"
+	UpdateWebhookURL         = "/webhooks/:id"
+	DeleteWebhookURL         = "/webhooks/:id"
+	ListWebhookDeliveriesURL = "/webhooks/:id/deliveries"
+)
+
+func (h *Handler) ListWebhooks(c gateway.Context) error {
+	paginator := query.NewPaginator()
+	if err := c.Bind(paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhooks []models.Webhook
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		webhooks, count, err = h.service.ListWebhooks(c.Ctx(), tenant, *paginator)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) GetWebhook(c gateway.Context) error {
+	var req requests.WebhookGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhook *models.Webhook
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		webhook, err = h.service.GetWebhook(c.Ctx(), tenant, req.ID)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) CreateWebhook(c gateway.Context) error {
+	var req requests.WebhookCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhook *models.Webhook
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		webhook, err = h.service.CreateWebhook(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) UpdateWebhook(c gateway.Context) error {
+	var req requests.WebhookUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhook *models.Webhook
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		webhook, err = h.service.UpdateWebhook(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) DeleteWebhook(c gateway.Context) error {
+	var req requests.WebhookDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		return h.service.DeleteWebhook(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListWebhookDeliveries(c gateway.Context) error {
+	var req requests.WebhookDeliveryList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var deliveries []models.WebhookDelivery
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.Update, func() error {
+		var err error
+		deliveries, count, err = h.service.ListWebhookDeliveries(c.Ctx(), tenant, req.ID, req.Paginator)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, deliveries)
+}
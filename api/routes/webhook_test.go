@@ -0,0 +1,151 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           requests.WebhookCreate
+		requiredMocks  func(body requests.WebhookCreate)
+		expectedStatus int
+	}{
+		{
+			title: "fails when the URL is invalid",
+			role:  guard.RoleOwner,
+			body: requests.WebhookCreate{
+				URL:    "ftp://example.com",
+				Events: []string{"device.online"},
+			},
+			requiredMocks:  func(body requests.WebhookCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the event is unknown",
+			role:  guard.RoleOwner,
+			body: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.renamed"},
+			},
+			requiredMocks:  func(body requests.WebhookCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the secret is too short",
+			role:  guard.RoleOwner,
+			body: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online"},
+				Secret: "secret",
+			},
+			requiredMocks:  func(body requests.WebhookCreate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is not allowed to manage webhooks",
+			role:  guard.RoleOperator,
+			body: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online"},
+			},
+			requiredMocks:  func(body requests.WebhookCreate) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the webhook is created",
+			role:  guard.RoleOwner,
+			body: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online", "session.finished"},
+			},
+			requiredMocks: func(body requests.WebhookCreate) {
+				mock.On("CreateWebhook", gomock.Anything, "tenant", body).Return(&models.Webhook{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.body)
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the webhook is not found",
+			requiredMocks: func() {
+				mock.On("ListWebhookDeliveries", gomock.Anything, "tenant", "id", gomock.Anything).
+					Return(nil, 0, svc.NewErrWebhookNotFound("id", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the deliveries are listed",
+			requiredMocks: func() {
+				mock.On("ListWebhookDeliveries", gomock.Anything, "tenant", "id", gomock.Anything).
+					Return([]models.WebhookDelivery{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/webhooks/id/deliveries", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
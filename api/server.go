@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers"
@@ -16,11 +18,13 @@ import (
 	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
+	"github.com/shellhub-io/shellhub/api/store/recording"
 	"github.com/shellhub-io/shellhub/api/workers"
 	requests "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 	"github.com/shellhub-io/shellhub/pkg/middleware"
+	"github.com/shellhub-io/shellhub/pkg/pubsub"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -56,6 +60,20 @@ var serverCmd = &cobra.Command{
 
 		log.Info("Connected to MongoDB")
 
+		if cfg.RecordStorage != "mongo" {
+			backend, err := newRecordingStore(cfg)
+			if err != nil {
+				log.WithError(err).WithField("storage", cfg.RecordStorage).Fatal("failed to create the recording store")
+			}
+
+			recordings := recording.NewStore(store, backend, recording.DefaultOptions)
+			go recordings.Start(ctx)
+
+			store = recordings
+
+			log.WithField("storage", cfg.RecordStorage).Info("Session recordings saved in the object storage")
+		}
+
 		worker, err := workers.New(store)
 		if err != nil {
 			log.WithError(err).Warn("Failed to create workers.")
@@ -94,6 +112,42 @@ type config struct {
 	SessionRecordCleanupSchedule string `env:"SESSION_RECORD_CLEANUP_SCHEDULE,default=@daily"`
 	// Sentry DSN.
 	SentryDSN string `env:"SENTRY_DSN,default="`
+	// Storage where the session recordings are saved: "mongo", "fs" or "s3".
+	//
+	// With "fs" and "s3", the frames are buffered in the API's memory and saved in chunks of up to 1000 frames or
+	// 1 MiB, when the session ends, or after 30 seconds without new frames. Until saved, the frames are only seen by
+	// the API instance recording them, and they are lost if it stops abruptly.
+	RecordStorage string `env:"RECORD_STORAGE,default=mongo"`
+	// Directory where the session recordings are saved when the storage is "fs".
+	RecordStoragePath string `env:"RECORD_STORAGE_PATH,default=/var/lib/shellhub/records"`
+	// S3-compatible endpoint, like AWS S3 or MinIO, where the session recordings are saved when the storage is "s3".
+	RecordStorageS3Endpoint string `env:"RECORD_STORAGE_S3_ENDPOINT,default="`
+	// Bucket where the session recordings are saved when the storage is "s3".
+	RecordStorageS3Bucket string `env:"RECORD_STORAGE_S3_BUCKET,default="`
+	// Region of the bucket.
+	RecordStorageS3Region string `env:"RECORD_STORAGE_S3_REGION,default=us-east-1"`
+	// Access key ID used to access the bucket.
+	RecordStorageS3AccessKey string `env:"RECORD_STORAGE_S3_ACCESS_KEY,default="`
+	// Secret access key used to access the bucket.
+	RecordStorageS3SecretKey string `env:"RECORD_STORAGE_S3_SECRET_KEY,default="`
+}
+
+// newRecordingStore creates the object storage where the session recordings are saved.
+func newRecordingStore(cfg *config) (store.RecordingStore, error) {
+	switch cfg.RecordStorage {
+	case "fs":
+		return recording.NewFilesystem(cfg.RecordStoragePath)
+	case "s3":
+		return recording.NewS3(recording.S3Options{
+			Endpoint:  cfg.RecordStorageS3Endpoint,
+			Region:    cfg.RecordStorageS3Region,
+			Bucket:    cfg.RecordStorageS3Bucket,
+			AccessKey: cfg.RecordStorageS3AccessKey,
+			SecretKey: cfg.RecordStorageS3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown record storage %q", cfg.RecordStorage)
+	}
 }
 
 func init() {
@@ -157,7 +211,26 @@ func startServer(ctx context.Context, cfg *config, store store.Store, cache stor
 		locator = geoip.NewNullGeoLite()
 	}
 
-	service := services.NewService(store, nil, nil, cache, requestClient, locator)
+	redis, err := asynq.ParseRedisURI(cfg.RedisURI)
+	if err != nil {
+		log.WithError(err).Error("Failed to parse redis URI")
+
+		return err
+	}
+
+	tasks := asynq.NewClient(redis)
+	defer tasks.Close()
+
+	signals, err := pubsub.NewRedis(cfg.RedisURI)
+	if err != nil {
+		log.WithError(err).Error("Failed to create the session signals publisher")
+
+		return err
+	}
+
+	var service services.Service = services.NewService(store, nil, nil, cache, requestClient, locator)
+	service = services.WithWebhooks(services.WithAutoAccept(services.WithAudit(service, store), store), store, tasks)
+	service = services.WithSessionSignals(service, signals)
 
 	e := routes.NewRouter(service)
 	e.Use(middleware.Log)
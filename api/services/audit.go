@@ -0,0 +1,913 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditService interface {
+	// ListAuditLogs lists the namespace's audit logs, from the newest to the oldest, matching the request's filters.
+	ListAuditLogs(ctx context.Context, req requests.AuditList) ([]models.AuditLog, int, error)
+}
+
+func (s *service) ListAuditLogs(ctx context.Context, req requests.AuditList) ([]models.AuditLog, int, error) {
+	return s.store.AuditList(ctx, req.Tenant, req.Paginator, req.Filters)
+}
+
+// audited is a Service that records an audit log for each mutation of a namespace's resource. The resource is read
+// from the store before and after the mutation, and the log keeps the fields changed by it.
+type audited struct {
+	Service
+	store store.Store
+}
+
+// WithAudit wraps service to record an audit log, in store, for the mutations on devices, device groups, tags,
+// namespaces, members, API keys, public keys, certificate authorities, firewall and forwarding rules, auto-accept
+// rules, webhooks and sessions. Mutations that fail aren't recorded.
+func WithAudit(service Service, store store.Store) Service {
+	return &audited{Service: service, store: store}
+}
+
+// record saves the audit log of a mutation. As the mutation was already done, a failure to save the log is only
+// reported.
+func (a *audited) record(ctx context.Context, tenant string, action models.AuditAction, target models.AuditTarget, before, after interface{}) {
+	log := &models.AuditLog{
+		TenantID:  tenant,
+		Action:    action,
+		Target:    target,
+		Changes:   auditChanges(before, after),
+		CreatedAt: clock.Now(),
+	}
+
+	if id := gateway.IDFromContext(ctx); id != nil {
+		log.Actor.ID = id.ID
+	}
+
+	if username := gateway.UsernameFromContext(ctx); username != nil {
+		log.Actor.Username = username.ID
+	}
+
+	if err := a.store.AuditCreate(ctx, log); err != nil {
+		logrus.
+			WithError(err).
+			WithFields(logrus.Fields{
+				"tenant_id": tenant,
+				"action":    action,
+				"target":    target.ID,
+			}).Error("Failed to record the audit log")
+	}
+}
+
+// mutate runs the mutation, recording its audit log when it succeeds. The get function reads the target's state
+// before and after the mutation.
+func (a *audited) mutate(ctx context.Context, tenant string, action models.AuditAction, target models.AuditTarget, get func() interface{}, mutation func() error) error {
+	before := get()
+
+	if err := mutation(); err != nil {
+		return err
+	}
+
+	a.record(ctx, tenant, action, target, before, get())
+
+	return nil
+}
+
+func (a *audited) device(ctx context.Context, uid models.UID, tenant string) func() interface{} {
+	return func() interface{} {
+		device, err := a.store.DeviceGetByUID(ctx, uid, tenant)
+		if err != nil {
+			return nil
+		}
+
+		return device
+	}
+}
+
+func (a *audited) namespace(ctx context.Context, tenant string) func() interface{} {
+	return func() interface{} {
+		namespace, err := a.store.NamespaceGet(ctx, tenant)
+		if err != nil {
+			return nil
+		}
+
+		return namespace
+	}
+}
+
+func (a *audited) member(ctx context.Context, tenant, id string) func() interface{} {
+	return func() interface{} {
+		namespace, err := a.store.NamespaceGet(ctx, tenant)
+		if err != nil {
+			return nil
+		}
+
+		member, ok := namespace.FindMember(id)
+		if !ok {
+			return nil
+		}
+
+		return member
+	}
+}
+
+func (a *audited) publicKey(ctx context.Context, fingerprint, tenant string) func() interface{} {
+	return func() interface{} {
+		key, err := a.store.PublicKeyGet(ctx, fingerprint, tenant)
+		if err != nil {
+			return nil
+		}
+
+		return key
+	}
+}
+
+func (a *audited) firewallRule(ctx context.Context, id string) func() interface{} {
+	return func() interface{} {
+		rule, err := a.store.FirewallRuleGet(ctx, id)
+		if err != nil {
+			return nil
+		}
+
+		return rule
+	}
+}
+
+// webhook reads the webhook without its secret, which must not be kept on the audit logs.
+func (a *audited) webhook(ctx context.Context, tenant, id string) func() interface{} {
+	return func() interface{} {
+		webhook, err := a.store.WebhookGet(ctx, tenant, id)
+		if err != nil {
+			return nil
+		}
+
+		webhook.Secret = ""
+
+		return webhook
+	}
+}
+
+func (a *audited) autoAcceptRule(ctx context.Context, tenant, id string) func() interface{} {
+	return func() interface{} {
+		rule, err := a.store.AutoAcceptRuleGet(ctx, tenant, id)
+		if err != nil {
+			return nil
+		}
+
+		return rule
+	}
+}
+
+func (a *audited) deviceGroup(ctx context.Context, tenant, id string) func() interface{} {
+	return func() interface{} {
+		group, err := a.store.DeviceGroupGet(ctx, tenant, id)
+		if err != nil {
+			return nil
+		}
+
+		return group
+	}
+}
+
+func (a *audited) forwardingRule(ctx context.Context, tenant, id string) func() interface{} {
+	return func() interface{} {
+		rule, err := a.store.ForwardingRuleGet(ctx, tenant, id)
+		if err != nil {
+			return nil
+		}
+
+		return rule
+	}
+}
+
+func (a *audited) certificateAuthority(ctx context.Context, tenant, fingerprint string) func() interface{} {
+	return func() interface{} {
+		authority, err := a.store.CertificateAuthorityGet(ctx, tenant, fingerprint)
+		if err != nil {
+			return nil
+		}
+
+		return authority
+	}
+}
+
+// apiKey reads the API key without its ID, which is the key itself and must not be kept on the audit logs.
+func (a *audited) apiKey(ctx context.Context, id string) func() interface{} {
+	return func() interface{} {
+		key, err := a.store.APIKeyGetByUID(ctx, id)
+		if err != nil {
+			return nil
+		}
+
+		key.ID = ""
+
+		return key
+	}
+}
+
+func (a *audited) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdateStatus, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.UpdateDeviceStatus(ctx, tenant, uid, status)
+	})
+}
+
+func (a *audited) RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.RenameDevice(ctx, uid, name, tenant)
+	})
+}
+
+func (a *audited) UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.UpdateDevice(ctx, tenant, uid, name, publicURL)
+	})
+}
+
+func (a *audited) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []string) error {
+	device, err := a.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return a.Service.UpdateDeviceTag(ctx, uid, tags)
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, device.TenantID, models.AuditActionDeviceUpdateTags, target, a.device(ctx, uid, device.TenantID), func() error {
+		return a.Service.UpdateDeviceTag(ctx, uid, tags)
+	})
+}
+
+func (a *audited) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
+	device, err := a.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return a.Service.CreateDeviceTag(ctx, uid, tag)
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, device.TenantID, models.AuditActionDeviceUpdateTags, target, a.device(ctx, uid, device.TenantID), func() error {
+		return a.Service.CreateDeviceTag(ctx, uid, tag)
+	})
+}
+
+func (a *audited) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
+	device, err := a.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return a.Service.RemoveDeviceTag(ctx, uid, tag)
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, device.TenantID, models.AuditActionDeviceUpdateTags, target, a.device(ctx, uid, device.TenantID), func() error {
+		return a.Service.RemoveDeviceTag(ctx, uid, tag)
+	})
+}
+
+func (a *audited) SetDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.SetDeviceAttributes(ctx, tenant, uid, attributes)
+	})
+}
+
+func (a *audited) UnsetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.UnsetDeviceAttribute(ctx, tenant, uid, key)
+	})
+}
+
+func (a *audited) SetDeviceVia(ctx context.Context, tenant string, uid models.UID, via models.DeviceVia) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.SetDeviceVia(ctx, tenant, uid, via)
+	})
+}
+
+func (a *audited) UnsetDeviceVia(ctx context.Context, tenant string, uid models.UID) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceUpdate, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.UnsetDeviceVia(ctx, tenant, uid)
+	})
+}
+
+func (a *audited) CreateDeviceTarget(ctx context.Context, tenant string, req requests.DeviceTargetCreate) (*models.Device, error) {
+	device, err := a.Service.CreateDeviceTarget(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}
+	a.record(ctx, tenant, models.AuditActionDeviceCreate, target, nil, device)
+
+	return device, nil
+}
+
+func (a *audited) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
+	target := models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceDelete, target, a.device(ctx, uid, tenant), func() error {
+		return a.Service.DeleteDevice(ctx, uid, tenant)
+	})
+}
+
+func (a *audited) CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	group, err := a.Service.CreateDeviceGroup(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	a.record(ctx, tenant, models.AuditActionDeviceGroupCreate, models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: group.ID}, nil, group)
+
+	return group, nil
+}
+
+func (a *audited) UpdateDeviceGroup(ctx context.Context, tenant, id string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	var group *models.DeviceGroup
+
+	target := models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: id}
+
+	err := a.mutate(ctx, tenant, models.AuditActionDeviceGroupUpdate, target, a.deviceGroup(ctx, tenant, id), func() error {
+		var err error
+		group, err = a.Service.UpdateDeviceGroup(ctx, tenant, id, req)
+
+		return err
+	})
+
+	return group, err
+}
+
+func (a *audited) DeleteDeviceGroup(ctx context.Context, tenant, id string) error {
+	target := models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: id}
+
+	return a.mutate(ctx, tenant, models.AuditActionDeviceGroupDelete, target, a.deviceGroup(ctx, tenant, id), func() error {
+		return a.Service.DeleteDeviceGroup(ctx, tenant, id)
+	})
+}
+
+func (a *audited) RenameTag(ctx context.Context, tenant, oldTag, newTag string) error {
+	if err := a.Service.RenameTag(ctx, tenant, oldTag, newTag); err != nil {
+		return err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetTag, ID: oldTag}
+	a.record(ctx, tenant, models.AuditActionTagRename, target, map[string]string{"name": oldTag}, map[string]string{"name": newTag})
+
+	return nil
+}
+
+func (a *audited) DeleteTag(ctx context.Context, tenant, tag string) error {
+	if err := a.Service.DeleteTag(ctx, tenant, tag); err != nil {
+		return err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetTag, ID: tag}
+	a.record(ctx, tenant, models.AuditActionTagDelete, target, map[string]string{"name": tag}, nil)
+
+	return nil
+}
+
+func (a *audited) CreateNamespace(ctx context.Context, namespace requests.NamespaceCreate, userID string) (*models.Namespace, error) {
+	created, err := a.Service.CreateNamespace(ctx, namespace, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetNamespace, ID: created.TenantID}
+	a.record(ctx, created.TenantID, models.AuditActionNamespaceCreate, target, nil, created)
+
+	return created, nil
+}
+
+func (a *audited) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
+	var namespace *models.Namespace
+
+	target := models.AuditTarget{Type: models.AuditTargetNamespace, ID: req.Tenant}
+
+	err := a.mutate(ctx, req.Tenant, models.AuditActionNamespaceUpdate, target, a.namespace(ctx, req.Tenant), func() error {
+		var err error
+		namespace, err = a.Service.EditNamespace(ctx, req)
+
+		return err
+	})
+
+	return namespace, err
+}
+
+func (a *audited) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
+	target := models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}
+
+	return a.mutate(ctx, tenantID, models.AuditActionNamespaceUpdate, target, a.namespace(ctx, tenantID), func() error {
+		return a.Service.EditSessionRecordStatus(ctx, sessionRecord, tenantID)
+	})
+}
+
+func (a *audited) DeleteNamespace(ctx context.Context, tenantID string) error {
+	target := models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}
+
+	return a.mutate(ctx, tenantID, models.AuditActionNamespaceDelete, target, a.namespace(ctx, tenantID), func() error {
+		return a.Service.DeleteNamespace(ctx, tenantID)
+	})
+}
+
+func (a *audited) AddNamespaceUser(ctx context.Context, memberUsername, memberRole, tenantID, userID string) (*models.Namespace, error) {
+	before, err := a.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return a.Service.AddNamespaceUser(ctx, memberUsername, memberRole, tenantID, userID)
+	}
+
+	namespace, err := a.Service.AddNamespaceUser(ctx, memberUsername, memberRole, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The added member is the one that wasn't in the namespace before.
+	if after, err := a.store.NamespaceGet(ctx, tenantID); err == nil {
+		for _, member := range after.Members {
+			if _, ok := before.FindMember(member.ID); ok {
+				continue
+			}
+
+			member.Username = memberUsername
+			a.record(ctx, tenantID, models.AuditActionNamespaceMemberAdd, models.AuditTarget{Type: models.AuditTargetMember, ID: member.ID}, nil, &member)
+		}
+	}
+
+	return namespace, nil
+}
+
+func (a *audited) RemoveNamespaceUser(ctx context.Context, tenantID, memberID, userID string) (*models.Namespace, error) {
+	var namespace *models.Namespace
+
+	target := models.AuditTarget{Type: models.AuditTargetMember, ID: memberID}
+
+	err := a.mutate(ctx, tenantID, models.AuditActionNamespaceMemberRemove, target, a.member(ctx, tenantID, memberID), func() error {
+		var err error
+		namespace, err = a.Service.RemoveNamespaceUser(ctx, tenantID, memberID, userID)
+
+		return err
+	})
+
+	return namespace, err
+}
+
+func (a *audited) EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error {
+	target := models.AuditTarget{Type: models.AuditTargetMember, ID: memberID}
+
+	return a.mutate(ctx, tenantID, models.AuditActionNamespaceMemberUpdate, target, a.member(ctx, tenantID, memberID), func() error {
+		return a.Service.EditNamespaceUser(ctx, tenantID, userID, memberID, memberNewRole)
+	})
+}
+
+func (a *audited) CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error) {
+	key, err := a.Service.CreatePublicKey(ctx, req, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: key.Fingerprint}
+	a.record(ctx, tenant, models.AuditActionPublicKeyCreate, target, nil, a.publicKey(ctx, key.Fingerprint, tenant)())
+
+	return key, nil
+}
+
+func (a *audited) UpdatePublicKey(ctx context.Context, fingerprint, tenant string, key requests.PublicKeyUpdate) (*models.PublicKey, error) {
+	var updated *models.PublicKey
+
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}
+
+	err := a.mutate(ctx, tenant, models.AuditActionPublicKeyUpdate, target, a.publicKey(ctx, fingerprint, tenant), func() error {
+		var err error
+		updated, err = a.Service.UpdatePublicKey(ctx, fingerprint, tenant, key)
+
+		return err
+	})
+
+	return updated, err
+}
+
+func (a *audited) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint string, tags []string) error {
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}
+
+	return a.mutate(ctx, tenant, models.AuditActionPublicKeyUpdateTags, target, a.publicKey(ctx, fingerprint, tenant), func() error {
+		return a.Service.UpdatePublicKeyTags(ctx, tenant, fingerprint, tags)
+	})
+}
+
+func (a *audited) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}
+
+	return a.mutate(ctx, tenant, models.AuditActionPublicKeyUpdateTags, target, a.publicKey(ctx, fingerprint, tenant), func() error {
+		return a.Service.AddPublicKeyTag(ctx, tenant, fingerprint, tag)
+	})
+}
+
+func (a *audited) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}
+
+	return a.mutate(ctx, tenant, models.AuditActionPublicKeyUpdateTags, target, a.publicKey(ctx, fingerprint, tenant), func() error {
+		return a.Service.RemovePublicKeyTag(ctx, tenant, fingerprint, tag)
+	})
+}
+
+func (a *audited) DeletePublicKey(ctx context.Context, fingerprint, tenant string) error {
+	target := models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}
+
+	return a.mutate(ctx, tenant, models.AuditActionPublicKeyDelete, target, a.publicKey(ctx, fingerprint, tenant), func() error {
+		return a.Service.DeletePublicKey(ctx, fingerprint, tenant)
+	})
+}
+
+// CreateAPIKey records the created API key by its name, as its ID is the key itself.
+func (a *audited) CreateAPIKey(ctx context.Context, userID, tenant, key string, req *requests.CreateAPIKey) (string, error) {
+	id, err := a.Service.CreateAPIKey(ctx, userID, tenant, key, req)
+	if err != nil {
+		return "", err
+	}
+
+	a.record(ctx, tenant, models.AuditActionAPIKeyCreate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: req.Name}, nil, a.apiKey(ctx, id)())
+
+	return id, nil
+}
+
+func (a *audited) EditAPIKey(ctx context.Context, changes *requests.APIKeyChanges) (*models.APIKey, error) {
+	key, err := a.store.APIKeyGetByUID(ctx, changes.ID)
+	if err != nil {
+		return a.Service.EditAPIKey(ctx, changes)
+	}
+
+	var edited *models.APIKey
+
+	target := models.AuditTarget{Type: models.AuditTargetAPIKey, ID: key.Name}
+
+	err = a.mutate(ctx, key.TenantID, models.AuditActionAPIKeyUpdate, target, a.apiKey(ctx, changes.ID), func() error {
+		var err error
+		edited, err = a.Service.EditAPIKey(ctx, changes)
+
+		return err
+	})
+
+	return edited, err
+}
+
+func (a *audited) DeleteAPIKey(ctx context.Context, id, tenantID string) error {
+	key, err := a.store.APIKeyGetByUID(ctx, id)
+	if err != nil {
+		return a.Service.DeleteAPIKey(ctx, id, tenantID)
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetAPIKey, ID: key.Name}
+
+	return a.mutate(ctx, tenantID, models.AuditActionAPIKeyDelete, target, a.apiKey(ctx, id), func() error {
+		return a.Service.DeleteAPIKey(ctx, id, tenantID)
+	})
+}
+
+func (a *audited) CreateCertificateAuthority(ctx context.Context, tenant string, req requests.CertificateAuthorityCreate) (*models.CertificateAuthority, error) {
+	authority, err := a.Service.CreateCertificateAuthority(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetCertificateAuthority, ID: authority.Fingerprint}
+	a.record(ctx, tenant, models.AuditActionCertificateAuthorityCreate, target, nil, authority)
+
+	return authority, nil
+}
+
+func (a *audited) DeleteCertificateAuthority(ctx context.Context, tenant, fingerprint string) error {
+	target := models.AuditTarget{Type: models.AuditTargetCertificateAuthority, ID: fingerprint}
+
+	return a.mutate(ctx, tenant, models.AuditActionCertificateAuthorityDelete, target, a.certificateAuthority(ctx, tenant, fingerprint), func() error {
+		return a.Service.DeleteCertificateAuthority(ctx, tenant, fingerprint)
+	})
+}
+
+func (a *audited) CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error) {
+	rule, err := a.Service.CreateFirewallRule(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	a.record(ctx, tenant, models.AuditActionFirewallRuleCreate, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: rule.ID}, nil, rule)
+
+	return rule, nil
+}
+
+func (a *audited) UpdateFirewallRule(ctx context.Context, tenant, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	var rule *models.FirewallRule
+
+	target := models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}
+
+	err := a.mutate(ctx, tenant, models.AuditActionFirewallRuleUpdate, target, a.firewallRule(ctx, id), func() error {
+		var err error
+		rule, err = a.Service.UpdateFirewallRule(ctx, tenant, id, req)
+
+		return err
+	})
+
+	return rule, err
+}
+
+func (a *audited) ReorderFirewallRules(ctx context.Context, tenant string, ids []string) error {
+	// The order is represented by the rules' IDs sorted by priority.
+	order := func() interface{} {
+		rules, _, err := a.store.FirewallRuleList(ctx, query.Paginator{Page: -1, PerPage: -1})
+		if err != nil {
+			return nil
+		}
+
+		sort.SliceStable(rules, func(i, j int) bool {
+			return rules[i].Priority < rules[j].Priority
+		})
+
+		ids := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			if rule.TenantID == tenant {
+				ids = append(ids, rule.ID)
+			}
+		}
+
+		return map[string]interface{}{"rules": ids}
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetFirewallRule}
+
+	return a.mutate(ctx, tenant, models.AuditActionFirewallRuleReorder, target, order, func() error {
+		return a.Service.ReorderFirewallRules(ctx, tenant, ids)
+	})
+}
+
+func (a *audited) DeleteFirewallRule(ctx context.Context, tenant, id string) error {
+	target := models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}
+
+	return a.mutate(ctx, tenant, models.AuditActionFirewallRuleDelete, target, a.firewallRule(ctx, id), func() error {
+		return a.Service.DeleteFirewallRule(ctx, tenant, id)
+	})
+}
+
+func (a *audited) CreateForwardingRule(ctx context.Context, tenant string, req requests.ForwardingRuleCreate) (*models.ForwardingRule, error) {
+	rule, err := a.Service.CreateForwardingRule(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	a.record(ctx, tenant, models.AuditActionForwardingRuleCreate, models.AuditTarget{Type: models.AuditTargetForwardingRule, ID: rule.ID}, nil, rule)
+
+	return rule, nil
+}
+
+func (a *audited) UpdateForwardingRule(ctx context.Context, tenant, id string, req requests.ForwardingRuleUpdate) (*models.ForwardingRule, error) {
+	var rule *models.ForwardingRule
+
+	target := models.AuditTarget{Type: models.AuditTargetForwardingRule, ID: id}
+
+	err := a.mutate(ctx, tenant, models.AuditActionForwardingRuleUpdate, target, a.forwardingRule(ctx, tenant, id), func() error {
+		var err error
+		rule, err = a.Service.UpdateForwardingRule(ctx, tenant, id, req)
+
+		return err
+	})
+
+	return rule, err
+}
+
+func (a *audited) DeleteForwardingRule(ctx context.Context, tenant, id string) error {
+	target := models.AuditTarget{Type: models.AuditTargetForwardingRule, ID: id}
+
+	return a.mutate(ctx, tenant, models.AuditActionForwardingRuleDelete, target, a.forwardingRule(ctx, tenant, id), func() error {
+		return a.Service.DeleteForwardingRule(ctx, tenant, id)
+	})
+}
+
+func (a *audited) CreateAutoAcceptRule(ctx context.Context, tenant string, req requests.AutoAcceptRuleCreate) (*models.AutoAcceptRule, error) {
+	rule, err := a.Service.CreateAutoAcceptRule(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	a.record(ctx, tenant, models.AuditActionAutoAcceptRuleCreate, models.AuditTarget{Type: models.AuditTargetAutoAcceptRule, ID: rule.ID}, nil, rule)
+
+	return rule, nil
+}
+
+func (a *audited) UpdateAutoAcceptRule(ctx context.Context, tenant, id string, req requests.AutoAcceptRuleUpdate) (*models.AutoAcceptRule, error) {
+	var rule *models.AutoAcceptRule
+
+	target := models.AuditTarget{Type: models.AuditTargetAutoAcceptRule, ID: id}
+
+	err := a.mutate(ctx, tenant, models.AuditActionAutoAcceptRuleUpdate, target, a.autoAcceptRule(ctx, tenant, id), func() error {
+		var err error
+		rule, err = a.Service.UpdateAutoAcceptRule(ctx, tenant, id, req)
+
+		return err
+	})
+
+	return rule, err
+}
+
+func (a *audited) DeleteAutoAcceptRule(ctx context.Context, tenant, id string) error {
+	target := models.AuditTarget{Type: models.AuditTargetAutoAcceptRule, ID: id}
+
+	return a.mutate(ctx, tenant, models.AuditActionAutoAcceptRuleDelete, target, a.autoAcceptRule(ctx, tenant, id), func() error {
+		return a.Service.DeleteAutoAcceptRule(ctx, tenant, id)
+	})
+}
+
+func (a *audited) CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*models.Webhook, error) {
+	webhook, err := a.Service.CreateWebhook(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret is only returned to the webhook's creator.
+	logged := *webhook
+	logged.Secret = ""
+
+	a.record(ctx, tenant, models.AuditActionWebhookCreate, models.AuditTarget{Type: models.AuditTargetWebhook, ID: webhook.ID}, nil, &logged)
+
+	return webhook, nil
+}
+
+func (a *audited) UpdateWebhook(ctx context.Context, tenant, id string, req requests.WebhookUpdate) (*models.Webhook, error) {
+	var webhook *models.Webhook
+
+	target := models.AuditTarget{Type: models.AuditTargetWebhook, ID: id}
+
+	err := a.mutate(ctx, tenant, models.AuditActionWebhookUpdate, target, a.webhook(ctx, tenant, id), func() error {
+		var err error
+		webhook, err = a.Service.UpdateWebhook(ctx, tenant, id, req)
+
+		return err
+	})
+
+	return webhook, err
+}
+
+func (a *audited) DeleteWebhook(ctx context.Context, tenant, id string) error {
+	target := models.AuditTarget{Type: models.AuditTargetWebhook, ID: id}
+
+	return a.mutate(ctx, tenant, models.AuditActionWebhookDelete, target, a.webhook(ctx, tenant, id), func() error {
+		return a.Service.DeleteWebhook(ctx, tenant, id)
+	})
+}
+
+func (a *audited) CloseSession(ctx context.Context, uid models.UID, user string) error {
+	session, err := a.store.SessionGet(ctx, uid)
+	if err != nil {
+		return a.Service.CloseSession(ctx, uid, user)
+	}
+
+	if err := a.Service.CloseSession(ctx, uid, user); err != nil {
+		return err
+	}
+
+	// A session already closed isn't changed.
+	if session.Closed {
+		return nil
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetSession, ID: string(uid)}
+	a.record(ctx, session.TenantID, models.AuditActionSessionClose, target, map[string]bool{"closed": false}, map[string]bool{"closed": true})
+
+	return nil
+}
+
+func (a *audited) ShareSession(ctx context.Context, req requests.SessionShare) (*responses.SessionShare, error) {
+	session, err := a.store.SessionGet(ctx, models.UID(req.UID))
+	if err != nil {
+		return a.Service.ShareSession(ctx, req)
+	}
+
+	share, err := a.Service.ShareSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The invite's token is only returned to the member who shared the session.
+	target := models.AuditTarget{Type: models.AuditTargetSession, ID: req.UID}
+	a.record(ctx, session.TenantID, models.AuditActionSessionShare, target, nil, map[string]interface{}{"guest": share.Guest, "input": share.Input})
+
+	return share, nil
+}
+
+func (a *audited) SetSessionShareInput(ctx context.Context, req requests.SessionShareInput) error {
+	session, err := a.store.SessionGet(ctx, models.UID(req.UID))
+	if err != nil {
+		return a.Service.SetSessionShareInput(ctx, req)
+	}
+
+	if err := a.Service.SetSessionShareInput(ctx, req); err != nil {
+		return err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetSession, ID: req.UID}
+	a.record(ctx, session.TenantID, models.AuditActionSessionShareInput, target, nil, map[string]interface{}{"guest": req.Guest, "input": req.Input})
+
+	return nil
+}
+
+func (a *audited) DeleteRecordedSession(ctx context.Context, uid models.UID) error {
+	session, err := a.store.SessionGet(ctx, uid)
+	if err != nil {
+		return a.Service.DeleteRecordedSession(ctx, uid)
+	}
+
+	if err := a.Service.DeleteRecordedSession(ctx, uid); err != nil {
+		return err
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetSession, ID: string(uid)}
+	a.record(ctx, session.TenantID, models.AuditActionSessionRecordDelete, target, map[string]bool{"recorded": session.Recorded}, map[string]bool{"recorded": false})
+
+	return nil
+}
+
+// auditChanges returns the fields whose values differ between before and after. A nil before or after, like in
+// creations and deletions, has no fields.
+func auditChanges(before, after interface{}) []models.AuditChange {
+	b, a := auditFields(before), auditFields(after)
+
+	fields := make([]string, 0, len(b)+len(a))
+	for field := range b {
+		fields = append(fields, field)
+	}
+
+	for field := range a {
+		if _, ok := b[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	changes := make([]models.AuditChange, 0)
+	for _, field := range fields {
+		if reflect.DeepEqual(b[field], a[field]) {
+			continue
+		}
+
+		changes = append(changes, models.AuditChange{Field: field, Before: b[field], After: a[field]})
+	}
+
+	return changes
+}
+
+// auditFields flattens the JSON representation of a value, mapping the dot-separated path of each field to its value.
+// Arrays are kept as a single value.
+func auditFields(value interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fields
+	}
+
+	var flatten func(prefix string, value interface{})
+	flatten = func(prefix string, value interface{}) {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			if prefix != "" {
+				fields[prefix] = value
+			}
+
+			return
+		}
+
+		for key, v := range object {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			flatten(key, v)
+		}
+	}
+
+	flatten("", decoded)
+
+	return fields
+}
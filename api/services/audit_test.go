@@ -0,0 +1,541 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		logs  []models.AuditLog
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.AuditList
+		requiredMocks func(req requests.AuditList)
+		expected      Expected
+	}{
+		{
+			description: "fails when the store fails",
+			req: requests.AuditList{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(req requests.AuditList) {
+				mock.On("AuditList", ctx, req.Tenant, req.Paginator, req.Filters).
+					Return(nil, 0, errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, 0, errors.New("error")},
+		},
+		{
+			description: "succeeds",
+			req: requests.AuditList{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(req requests.AuditList) {
+				mock.On("AuditList", ctx, req.Tenant, req.Paginator, req.Filters).
+					Return([]models.AuditLog{{Action: models.AuditActionDeviceDelete}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.AuditLog{{Action: models.AuditActionDeviceDelete}}, 1, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.req)
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			logs, count, err := service.ListAuditLogs(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{logs, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWithAudit(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Status: models.DeviceStatusPending}
+	accepted := &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Status: models.DeviceStatusAccepted}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		run           func(service Service) error
+		expected      error
+	}{
+		{
+			description: "does not record when the mutation fails",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.UpdateDeviceStatus(ctx, "tenant", "uid", models.DeviceStatusAccepted)
+			},
+			expected: NewErrNamespaceNotFound("tenant", store.ErrNoDocuments),
+		},
+		{
+			description: "records the changed fields when the mutation succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(device, nil).
+					Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant"}, nil).
+					Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(device, nil).
+					Once()
+				mock.On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatusRejected).
+					Return(nil).
+					Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Name: "name", TenantID: "tenant", Status: models.DeviceStatusRejected}, nil).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					return log.TenantID == "tenant" &&
+						log.Action == models.AuditActionDeviceUpdateStatus &&
+						log.Target == models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"} &&
+						assert.ObjectsAreEqual([]models.AuditChange{{Field: "status", Before: "pending", After: "rejected"}}, log.Changes)
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.UpdateDeviceStatus(ctx, "tenant", "uid", models.DeviceStatusRejected)
+			},
+			expected: nil,
+		},
+		{
+			description: "does not fail when the audit log cannot be recorded",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).
+					Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).
+					Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant"}, nil).
+					Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				mock.On("DeviceDelete", ctx, models.UID("uid")).
+					Return(nil).
+					Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					return log.Action == models.AuditActionDeviceDelete && len(log.Changes) > 0
+				})).
+					Return(errors.New("error")).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.DeleteDevice(ctx, "uid", "tenant")
+			},
+			expected: nil,
+		},
+		{
+			description: "records the created webhook without its secret",
+			requiredMocks: func() {
+				mock.On("WebhookCreate", ctx, gomock.AnythingOfType("*models.Webhook")).
+					Return(nil).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					for _, change := range log.Changes {
+						if change.Field == "secret" {
+							return false
+						}
+					}
+
+					return log.Action == models.AuditActionWebhookCreate && len(log.Changes) > 0
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				webhook, err := service.CreateWebhook(ctx, "tenant", requests.WebhookCreate{
+					URL:    "https://example.com",
+					Events: []string{"device.online"},
+					Secret: "0123456789abcdef",
+				})
+				if err == nil {
+					assert.Equal(t, "0123456789abcdef", webhook.Secret)
+				}
+
+				return err
+			},
+			expected: nil,
+		},
+		{
+			description: "records the deleted certificate authority",
+			requiredMocks: func() {
+				mock.On("CertificateAuthorityGet", ctx, "tenant", "fingerprint").
+					Return(&models.CertificateAuthority{Fingerprint: "fingerprint", Name: "ca", TenantID: "tenant"}, nil).
+					Once()
+				mock.On("CertificateAuthorityDelete", ctx, "tenant", "fingerprint").
+					Return(nil).
+					Once()
+				mock.On("CertificateAuthorityGet", ctx, "tenant", "fingerprint").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					return log.Action == models.AuditActionCertificateAuthorityDelete &&
+						log.Target == models.AuditTarget{Type: models.AuditTargetCertificateAuthority, ID: "fingerprint"} &&
+						len(log.Changes) > 0
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.DeleteCertificateAuthority(ctx, "tenant", "fingerprint")
+			},
+			expected: nil,
+		},
+		{
+			description: "records the closed session",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).
+					Twice()
+				mock.On("SessionSetClosedBy", ctx, models.UID("uid"), "user").
+					Return(nil).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					return log.TenantID == "tenant" &&
+						log.Action == models.AuditActionSessionClose &&
+						assert.ObjectsAreEqual([]models.AuditChange{{Field: "closed", Before: false, After: true}}, log.Changes)
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.CloseSession(ctx, "uid", "user")
+			},
+			expected: nil,
+		},
+		{
+			description: "records the renamed tag",
+			requiredMocks: func() {
+				mock.On("TagsGet", ctx, "tenant").
+					Return([]string{"old"}, 1, nil).
+					Once()
+				mock.On("TagsRename", ctx, "tenant", "old", "new").
+					Return(int64(1), nil).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					return log.Action == models.AuditActionTagRename &&
+						log.Target == models.AuditTarget{Type: models.AuditTargetTag, ID: "old"} &&
+						assert.ObjectsAreEqual([]models.AuditChange{{Field: "name", Before: "old", After: "new"}}, log.Changes)
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.RenameTag(ctx, "tenant", "old", "new")
+			},
+			expected: nil,
+		},
+		{
+			description: "records the deleted API key by its name and without its ID",
+			requiredMocks: func() {
+				key := &models.APIKey{ID: "key", UserID: "user", TenantID: "tenant", Name: "name", ExpiresIn: -1}
+
+				mock.On("APIKeyGetByUID", ctx, "key").
+					Return(key, nil).
+					Once()
+				mock.On("APIKeyGetByUID", ctx, "key").
+					Return(&models.APIKey{ID: "key", UserID: "user", TenantID: "tenant", Name: "name", ExpiresIn: -1}, nil).
+					Once()
+				mock.On("APIKeyDelete", ctx, "key", "tenant").
+					Return(nil).
+					Once()
+				mock.On("APIKeyGetByUID", ctx, "key").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				mock.On("AuditCreate", ctx, gomock.MatchedBy(func(log *models.AuditLog) bool {
+					for _, change := range log.Changes {
+						if change.Before == "key" {
+							return false
+						}
+					}
+
+					return log.Action == models.AuditActionAPIKeyDelete &&
+						log.Target == models.AuditTarget{Type: models.AuditTargetAPIKey, ID: "name"} &&
+						len(log.Changes) > 0
+				})).
+					Return(nil).
+					Once()
+			},
+			run: func(service Service) error {
+				return service.DeleteAPIKey(ctx, "key", "tenant")
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			clockMock.On("Now").Return(now).Maybe()
+
+			service := WithAudit(NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil), mock)
+
+			assert.Equal(t, tc.expected, tc.run(service))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuditCoversMutations(t *testing.T) {
+	// mutations maps each of the Service's methods to whether it mutates a namespace's resource on behalf of a member,
+	// and so must be audited. A new method must be added here, failing the test until it's classified.
+	mutations := map[string]bool{
+		// Devices.
+		"UpdateDeviceStatus":   true,
+		"RenameDevice":         true,
+		"UpdateDevice":         true,
+		"UpdateDeviceTag":      true,
+		"CreateDeviceTag":      true,
+		"RemoveDeviceTag":      true,
+		"SetDeviceAttributes":  true,
+		"UnsetDeviceAttribute": true,
+		"SetDeviceVia":         true,
+		"UnsetDeviceVia":       true,
+		"CreateDeviceTarget":   true,
+		"DeleteDevice":         true,
+		"CreateDeviceGroup":    true,
+		"UpdateDeviceGroup":    true,
+		"DeleteDeviceGroup":    true,
+		"RenameTag":            true,
+		"DeleteTag":            true,
+
+		// Namespaces and members.
+		"CreateNamespace":         true,
+		"EditNamespace":           true,
+		"EditSessionRecordStatus": true,
+		"DeleteNamespace":         true,
+		"AddNamespaceUser":        true,
+		"EditNamespaceUser":       true,
+		"RemoveNamespaceUser":     true,
+		"CreateAPIKey":            true,
+		"EditAPIKey":              true,
+		"DeleteAPIKey":            true,
+
+		// Keys and rules.
+		"CreatePublicKey":            true,
+		"UpdatePublicKey":            true,
+		"UpdatePublicKeyTags":        true,
+		"AddPublicKeyTag":            true,
+		"RemovePublicKeyTag":         true,
+		"DeletePublicKey":            true,
+		"CreateCertificateAuthority": true,
+		"DeleteCertificateAuthority": true,
+		"CreateFirewallRule":         true,
+		"UpdateFirewallRule":         true,
+		"ReorderFirewallRules":       true,
+		"DeleteFirewallRule":         true,
+		"CreateForwardingRule":       true,
+		"UpdateForwardingRule":       true,
+		"DeleteForwardingRule":       true,
+		"CreateAutoAcceptRule":       true,
+		"UpdateAutoAcceptRule":       true,
+		"DeleteAutoAcceptRule":       true,
+		"CreateWebhook":              true,
+		"UpdateWebhook":              true,
+		"DeleteWebhook":              true,
+
+		// Sessions.
+		"CloseSession":          true,
+		"ShareSession":          true,
+		"SetSessionShareInput":  true,
+		"DeleteRecordedSession": true,
+
+		// Reads.
+		"GetAPIKeyByUID":              false,
+		"GetAutoAcceptRule":           false,
+		"GetDevice":                   false,
+		"GetDeviceByPublicURLAddress": false,
+		"GetDeviceCredentials":        false,
+		"GetDeviceGroup":              false,
+		"GetFirewallRule":             false,
+		"GetForwardingRule":           false,
+		"GetNamespace":                false,
+		"GetPublicKey":                false,
+		"GetSession":                  false,
+		"GetSessionRecord":            false,
+		"GetSessionRecordFrames":      false,
+		"GetStats":                    false,
+		"GetTags":                     false,
+		"GetWebhook":                  false,
+		"ListAPIKeys":                 false,
+		"ListAuditLogs":               false,
+		"ListAutoAcceptRules":         false,
+		"ListCertificateAuthorities":  false,
+		"ListDeviceGroups":            false,
+		"ListDeviceTargets":           false,
+		"ListDevices":                 false,
+		"ListFirewallRules":           false,
+		"ListForwardingRules":         false,
+		"ListNamespaces":              false,
+		"ListPublicKeys":              false,
+		"ListSessionEvents":           false,
+		"ListSessions":                false,
+		"ListWebhookDeliveries":       false,
+		"ListWebhooks":                false,
+		"LookupDevice":                false,
+		"SelectDevices":               false,
+		"PublicKey":                   false,
+		"SystemGetInfo":               false,
+		"SystemDownloadInstallScript": false,
+		"EvaluateFirewall":            false,
+		"EvaluateForwarding":          false,
+		"EvaluateReverseForwarding":   false,
+		"EvaluateKeyFilter":           false,
+		"EvaluateKeyUsername":         false,
+		"BillingEvaluate":             false,
+		"ProbeDeviceTargets":          false,
+		"CreatePrivateKey":            false,
+
+		// Authentication.
+		"AuthDevice":         false,
+		"AuthUser":           false,
+		"AuthUserInfo":       false,
+		"AuthGetToken":       false,
+		"AuthSwapToken":      false,
+		"AuthMFA":            false,
+		"AuthPublicKey":      false,
+		"AuthCacheToken":     false,
+		"AuthIsCacheToken":   false,
+		"AuthUncacheToken":   false,
+		"UpdateDataUser":     false,
+		"UpdatePasswordUser": false,
+		"Setup":              false,
+
+		// State reported by the devices and the SSH server.
+		"DeviceHeartbeat":         false,
+		"OffineDevice":            false,
+		"SetDevicePosition":       false,
+		"CreateSession":           false,
+		"CreateSessionEvent":      false,
+		"DeactivateSession":       false,
+		"KeepAliveSession":        false,
+		"SetSessionAuthenticated": false,
+		"SetSessionCloseReason":   false,
+		"BillingReport":           false,
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "audit.go", nil, 0)
+	assert.NoError(t, err)
+
+	overridden := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil {
+			continue
+		}
+
+		if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+			if ident, ok := star.X.(*ast.Ident); ok && ident.Name == "audited" {
+				overridden[fn.Name.Name] = true
+			}
+		}
+	}
+
+	typ := reflect.TypeOf((*Service)(nil)).Elem()
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+
+		mutation, ok := mutations[name]
+		if !assert.True(t, ok, "%s must be classified as audited or not", name) {
+			continue
+		}
+
+		assert.Equal(t, mutation, overridden[name], "%s is audited only when it's a mutation", name)
+	}
+}
+
+func TestAuditChanges(t *testing.T) {
+	cases := []struct {
+		description string
+		before      interface{}
+		after       interface{}
+		expected    []models.AuditChange
+	}{
+		{
+			description: "has no changes when the values are equal",
+			before:      &models.Namespace{Name: "name", Settings: &models.NamespaceSettings{SessionRecord: true}},
+			after:       &models.Namespace{Name: "name", Settings: &models.NamespaceSettings{SessionRecord: true}},
+			expected:    []models.AuditChange{},
+		},
+		{
+			description: "has the nested fields that changed",
+			before:      &models.Namespace{Name: "name", Settings: &models.NamespaceSettings{SessionRecord: true}},
+			after:       &models.Namespace{Name: "other", Settings: &models.NamespaceSettings{SessionRecord: false}},
+			expected: []models.AuditChange{
+				{Field: "name", Before: "name", After: "other"},
+				{Field: "settings.session_record", Before: true, After: false},
+			},
+		},
+		{
+			description: "has every field as added when there is no before",
+			before:      nil,
+			after:       &models.Member{ID: "id", Username: "username", Role: "observer"},
+			expected: []models.AuditChange{
+				{Field: "id", Before: nil, After: "id"},
+				{Field: "role", Before: nil, After: "observer"},
+				{Field: "username", Before: nil, After: "username"},
+			},
+		},
+		{
+			description: "has every field as removed when there is no after",
+			before:      map[string]interface{}{"tags": []string{"tag"}},
+			after:       (*models.Device)(nil),
+			expected: []models.AuditChange{
+				{Field: "tags", Before: []interface{}{"tag"}, After: nil},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, auditChanges(tc.before, tc.after))
+		})
+	}
+}
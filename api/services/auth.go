@@ -36,23 +36,34 @@ type AuthService interface {
 	PublicKey() *rsa.PublicKey
 }
 
-func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
-	var identity *models.DeviceIdentity
-	if req.Identity != nil {
-		identity = &models.DeviceIdentity{
-			MAC: req.Identity.MAC,
-		}
+// deviceIdentity returns the identity of the device authenticating with req, if it has one.
+func deviceIdentity(req requests.DeviceAuth) *models.DeviceIdentity {
+	if req.Identity == nil {
+		return nil
 	}
+
+	return &models.DeviceIdentity{
+		MAC: req.Identity.MAC,
+	}
+}
+
+// deviceUID returns the UID of the device authenticating with req.
+func deviceUID(req requests.DeviceAuth) string {
 	auth := models.DeviceAuth{
 		Hostname:  req.Hostname,
-		Identity:  identity,
+		Identity:  deviceIdentity(req),
 		PublicKey: req.PublicKey,
 		TenantID:  req.TenantID,
 	}
 
 	uid := sha256.Sum256(structhash.Dump(auth, 1))
 
-	key := hex.EncodeToString(uid[:])
+	return hex.EncodeToString(uid[:])
+}
+
+func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
+	identity := deviceIdentity(req)
+	key := deviceUID(req)
 
 	token, err := jwttoken.New().
 		WithMethod(jwt.SigningMethodRS256).
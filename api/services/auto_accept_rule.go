@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type AutoAcceptRuleService interface {
+	// ListAutoAcceptRules lists the namespace's auto-accept rules in evaluation order.
+	ListAutoAcceptRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.AutoAcceptRule, int, error)
+	GetAutoAcceptRule(ctx context.Context, tenant, id string) (*models.AutoAcceptRule, error)
+	CreateAutoAcceptRule(ctx context.Context, tenant string, req requests.AutoAcceptRuleCreate) (*models.AutoAcceptRule, error)
+	UpdateAutoAcceptRule(ctx context.Context, tenant, id string, req requests.AutoAcceptRuleUpdate) (*models.AutoAcceptRule, error)
+	DeleteAutoAcceptRule(ctx context.Context, tenant, id string) error
+}
+
+func (s *service) ListAutoAcceptRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.AutoAcceptRule, int, error) {
+	return s.store.AutoAcceptRuleList(ctx, tenant, paginator)
+}
+
+func (s *service) GetAutoAcceptRule(ctx context.Context, tenant, id string) (*models.AutoAcceptRule, error) {
+	rule, err := s.store.AutoAcceptRuleGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrAutoAcceptRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+func (s *service) CreateAutoAcceptRule(ctx context.Context, tenant string, req requests.AutoAcceptRuleCreate) (*models.AutoAcceptRule, error) {
+	if err := checkAutoAcceptRuleFields(req.AutoAcceptRuleFields); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	rule := &models.AutoAcceptRule{
+		TenantID:             tenant,
+		AutoAcceptRuleFields: autoAcceptRuleFieldsFromRequest(req.AutoAcceptRuleFields),
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	if err := s.store.AutoAcceptRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) UpdateAutoAcceptRule(ctx context.Context, tenant, id string, req requests.AutoAcceptRuleUpdate) (*models.AutoAcceptRule, error) {
+	if err := checkAutoAcceptRuleFields(req.AutoAcceptRuleFields); err != nil {
+		return nil, err
+	}
+
+	fields := autoAcceptRuleFieldsFromRequest(req.AutoAcceptRuleFields)
+
+	rule, err := s.store.AutoAcceptRuleUpdate(ctx, tenant, id, &fields)
+	if err != nil {
+		return nil, NewErrAutoAcceptRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+func (s *service) DeleteAutoAcceptRule(ctx context.Context, tenant, id string) error {
+	if err := s.store.AutoAcceptRuleDelete(ctx, tenant, id); err != nil {
+		return NewErrAutoAcceptRuleNotFound(id, err)
+	}
+
+	return nil
+}
+
+// autoAccepted is a Service that applies the namespaces' auto-accept rules to the devices registering for the first
+// time. The rules are applied through the decorated service, so the device's changes are audited like a member's.
+type autoAccepted struct {
+	Service
+	store store.Store
+}
+
+// WithAutoAccept wraps service to apply, when a device authenticates for the first time, the first active auto-accept
+// rule of its namespace matching it. The device is left pending when no rule matches or when the rule can't be
+// applied, as when the namespace reached its maximum number of devices, without failing its authentication.
+func WithAutoAccept(service Service, store store.Store) Service {
+	return &autoAccepted{Service: service, store: store}
+}
+
+func (a *autoAccepted) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
+	uid := models.UID(deviceUID(req))
+
+	// The rules are only evaluated when the device registers for the first time.
+	_, err := a.store.DeviceGet(ctx, uid)
+	registered := err == nil
+
+	res, err := a.Service.AuthDevice(ctx, req, remoteAddr)
+	if err != nil || registered {
+		return res, err
+	}
+
+	device, err := a.store.DeviceGet(ctx, uid)
+	if err != nil || device.Status != models.DeviceStatusPending {
+		return res, nil
+	}
+
+	if rule := a.matchAutoAcceptRule(ctx, device, remoteAddr); rule != nil {
+		a.applyAutoAcceptRule(ctx, device, rule)
+	}
+
+	return res, nil
+}
+
+// matchAutoAcceptRule returns the first active auto-accept rule of the namespace matching a just registered device, or
+// nil when no rule matches.
+func (a *autoAccepted) matchAutoAcceptRule(ctx context.Context, device *models.Device, remoteAddr string) *models.AutoAcceptRule {
+	rules, _, err := a.store.AutoAcceptRuleList(ctx, device.TenantID, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"tenant_id": device.TenantID, "uid": device.UID}).
+			Error("Failed to list the auto-accept rules")
+
+		return nil
+	}
+
+	for _, rule := range rules {
+		if rule.Active && matchAutoAcceptFilter(&rule.Filter, device, remoteAddr) {
+			return &rule
+		}
+	}
+
+	return nil
+}
+
+// applyAutoAcceptRule accepts, with the rule's tags, or rejects the device. A failure is only reported, as the device
+// is still pending.
+func (a *autoAccepted) applyAutoAcceptRule(ctx context.Context, device *models.Device, rule *models.AutoAcceptRule) {
+	logger := log.WithFields(log.Fields{"tenant_id": device.TenantID, "uid": device.UID, "rule": rule.ID, "action": rule.Action})
+
+	status := models.DeviceStatusRejected
+	if rule.Action == models.AutoAcceptRuleActionAccept {
+		status = models.DeviceStatusAccepted
+
+		if len(rule.Tags) > 0 {
+			if err := a.Service.UpdateDeviceTag(ctx, models.UID(device.UID), rule.Tags); err != nil {
+				logger.WithError(err).Error("Failed to apply the auto-accept rule's tags")
+			}
+		}
+	}
+
+	if err := a.Service.UpdateDeviceStatus(ctx, device.TenantID, models.UID(device.UID), status); err != nil {
+		logger.WithError(err).Warn("Failed to apply the auto-accept rule")
+
+		return
+	}
+
+	logger.Info("Auto-accept rule applied")
+}
+
+// matchAutoAcceptFilter checks if the device, registered from remoteAddr, matches every criterion set on the filter.
+func matchAutoAcceptFilter(filter *models.AutoAcceptFilter, device *models.Device, remoteAddr string) bool {
+	info := device.Info
+	if info == nil {
+		info = &models.DeviceInfo{}
+	}
+
+	for _, field := range [][2]string{
+		{filter.ID, info.ID},
+		{filter.Version, info.Version},
+		{filter.Arch, info.Arch},
+		{filter.Platform, info.Platform},
+	} {
+		if field[0] != "" && field[0] != field[1] {
+			return false
+		}
+	}
+
+	if filter.MACPrefix != "" {
+		if device.Identity == nil || !strings.HasPrefix(strings.ToLower(device.Identity.MAC), strings.ToLower(filter.MACPrefix)) {
+			return false
+		}
+	}
+
+	if filter.Hostname != "" {
+		if ok, err := regexp.MatchString(filter.Hostname, device.Name); err != nil || !ok {
+			return false
+		}
+	}
+
+	if filter.SourceIP != "" {
+		_, network, err := net.ParseCIDR(filter.SourceIP)
+		if err != nil {
+			return false
+		}
+
+		if ip := net.ParseIP(remoteAddr); ip == nil || !network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkAutoAcceptRuleFields checks that only the rules accepting devices have tags.
+func checkAutoAcceptRuleFields(fields requests.AutoAcceptRuleFields) error {
+	if fields.Action == string(models.AutoAcceptRuleActionReject) && len(fields.Tags) > 0 {
+		return NewErrAutoAcceptRuleInvalid(map[string]interface{}{"action": fields.Action, "tags": fields.Tags}, nil)
+	}
+
+	return nil
+}
+
+func autoAcceptRuleFieldsFromRequest(fields requests.AutoAcceptRuleFields) models.AutoAcceptRuleFields {
+	return models.AutoAcceptRuleFields{
+		Priority: fields.Priority,
+		Action:   models.AutoAcceptRuleAction(fields.Action),
+		Active:   fields.Active,
+		Tags:     fields.Tags,
+		Filter: models.AutoAcceptFilter{
+			ID:        fields.Filter.ID,
+			Version:   fields.Filter.Version,
+			Arch:      fields.Filter.Arch,
+			Platform:  fields.Filter.Platform,
+			MACPrefix: fields.Filter.MACPrefix,
+			Hostname:  fields.Filter.Hostname,
+			SourceIP:  fields.Filter.SourceIP,
+		},
+	}
+}
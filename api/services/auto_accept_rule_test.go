@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocksService "github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestMatchAutoAcceptFilter(t *testing.T) {
+	device := &models.Device{
+		UID:      "uid",
+		Name:     "edge-01",
+		Identity: &models.DeviceIdentity{MAC: "AA:BB:CC:00:11:22"},
+		Info: &models.DeviceInfo{
+			ID:       "debian",
+			Version:  "v0.15.0",
+			Arch:     "arm64",
+			Platform: "docker",
+		},
+	}
+
+	cases := []struct {
+		description string
+		filter      models.AutoAcceptFilter
+		remoteAddr  string
+		expected    bool
+	}{
+		{
+			description: "matches when the filter is empty",
+			filter:      models.AutoAcceptFilter{},
+			remoteAddr:  "192.168.0.10",
+			expected:    true,
+		},
+		{
+			description: "matches when every criterion matches",
+			filter: models.AutoAcceptFilter{
+				ID:        "debian",
+				Version:   "v0.15.0",
+				Arch:      "arm64",
+				Platform:  "docker",
+				MACPrefix: "aa:bb:cc",
+				Hostname:  "^edge-[0-9]+$",
+				SourceIP:  "192.168.0.0/24",
+			},
+			remoteAddr: "192.168.0.10",
+			expected:   true,
+		},
+		{
+			description: "does not match when the device info differs",
+			filter:      models.AutoAcceptFilter{Arch: "amd64"},
+			remoteAddr:  "192.168.0.10",
+			expected:    false,
+		},
+		{
+			description: "does not match when the MAC prefix differs",
+			filter:      models.AutoAcceptFilter{MACPrefix: "aa:bb:cd"},
+			remoteAddr:  "192.168.0.10",
+			expected:    false,
+		},
+		{
+			description: "does not match when the hostname does not match the regexp",
+			filter:      models.AutoAcceptFilter{Hostname: "^core-"},
+			remoteAddr:  "192.168.0.10",
+			expected:    false,
+		},
+		{
+			description: "does not match when the source IP is out of the CIDR",
+			filter:      models.AutoAcceptFilter{SourceIP: "10.0.0.0/8"},
+			remoteAddr:  "192.168.0.10",
+			expected:    false,
+		},
+		{
+			description: "does not match when the source IP is unknown",
+			filter:      models.AutoAcceptFilter{SourceIP: "10.0.0.0/8"},
+			remoteAddr:  "",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchAutoAcceptFilter(&tc.filter, device, tc.remoteAddr))
+		})
+	}
+}
+
+func TestMatchAutoAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	device := &models.Device{
+		UID:      "uid",
+		Name:     "edge-01",
+		TenantID: "tenant",
+		Identity: &models.DeviceIdentity{MAC: "aa:bb:cc:00:11:22"},
+		Status:   models.DeviceStatusPending,
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      string
+	}{
+		{
+			description: "matches no rule when the rules can't be listed",
+			requiredMocks: func() {
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return(nil, 0, errors.New("error")).Once()
+			},
+			expected: "",
+		},
+		{
+			description: "matches no rule when no active rule matches",
+			requiredMocks: func() {
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.AutoAcceptRule{
+						{
+							ID: "inactive",
+							AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+								Action: models.AutoAcceptRuleActionAccept,
+								Active: false,
+								Filter: models.AutoAcceptFilter{Platform: "docker"},
+							},
+						},
+						{
+							ID: "unmatched",
+							AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+								Action: models.AutoAcceptRuleActionAccept,
+								Active: true,
+								Filter: models.AutoAcceptFilter{Hostname: "^core-"},
+							},
+						},
+					}, 2, nil).Once()
+			},
+			expected: "",
+		},
+		{
+			description: "matches the first active rule matching the device",
+			requiredMocks: func() {
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.AutoAcceptRule{
+						{
+							ID: "reject",
+							AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+								Action: models.AutoAcceptRuleActionReject,
+								Active: true,
+								Filter: models.AutoAcceptFilter{SourceIP: "10.0.0.0/8"},
+							},
+						},
+						{
+							ID: "accept",
+							AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+								Action: models.AutoAcceptRuleActionAccept,
+								Active: true,
+								Filter: models.AutoAcceptFilter{MACPrefix: "aa:bb:cc"},
+							},
+						},
+					}, 2, nil).Once()
+			},
+			expected: "reject",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := &autoAccepted{
+				Service: NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil),
+				store:   mock,
+			}
+
+			var id string
+			if rule := service.matchAutoAcceptRule(ctx, device, "10.0.0.1"); rule != nil {
+				id = rule.ID
+			}
+
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWithAutoAccept(t *testing.T) {
+	mock := new(mocks.Store)
+	serviceMock := new(mocksService.Service)
+
+	ctx := context.TODO()
+
+	req := requests.DeviceAuth{
+		Hostname: "edge-01",
+		Identity: &requests.DeviceIdentity{MAC: "aa:bb:cc:00:11:22"},
+		TenantID: "tenant",
+	}
+
+	uid := models.UID(deviceUID(req))
+
+	device := &models.Device{
+		UID:      string(uid),
+		Name:     "edge-01",
+		TenantID: "tenant",
+		Identity: &models.DeviceIdentity{MAC: "aa:bb:cc:00:11:22"},
+		Status:   models.DeviceStatusPending,
+	}
+
+	rules := []models.AutoAcceptRule{
+		{
+			ID: "rule",
+			AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+				Action: models.AutoAcceptRuleActionAccept,
+				Active: true,
+				Tags:   []string{"edge"},
+				Filter: models.AutoAcceptFilter{MACPrefix: "aa:bb:cc"},
+			},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the authentication fails",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(nil, errors.New("error")).Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "does not evaluate the rules for a registered device",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(device, nil).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "leaves the device pending when no rule matches",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).Return(device, nil).Once()
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.AutoAcceptRule{}, 0, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "applies the matching rule through the decorated service",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).Return(device, nil).Once()
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return(rules, 1, nil).Once()
+				serviceMock.On("UpdateDeviceTag", ctx, uid, []string{"edge"}).Return(nil).Once()
+				serviceMock.On("UpdateDeviceStatus", ctx, "tenant", uid, models.DeviceStatusAccepted).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "does not fail the authentication when the rule can't be applied",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).Return(device, nil).Once()
+				mock.On("AutoAcceptRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return(rules, 1, nil).Once()
+				serviceMock.On("UpdateDeviceTag", ctx, uid, []string{"edge"}).Return(nil).Once()
+				serviceMock.On("UpdateDeviceStatus", ctx, "tenant", uid, models.DeviceStatusAccepted).
+					Return(NewErrDeviceMaxDevicesReached(1)).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := WithAutoAccept(serviceMock, mock)
+
+			_, err := service.AuthDevice(ctx, req, "10.0.0.1")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateAutoAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	req := requests.AutoAcceptRuleCreate{
+		AutoAcceptRuleFields: requests.AutoAcceptRuleFields{
+			Priority: 1,
+			Action:   "accept",
+			Active:   true,
+			Tags:     []string{"edge"},
+			Filter:   requests.AutoAcceptFilter{Platform: "docker"},
+		},
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	mock.On("AutoAcceptRuleCreate", ctx, gomock.MatchedBy(func(rule *models.AutoAcceptRule) bool {
+		return rule.TenantID == "tenant" &&
+			rule.Action == models.AutoAcceptRuleActionAccept &&
+			rule.Filter.Platform == "docker" &&
+			rule.CreatedAt.Equal(now)
+	})).Return(nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	rule, err := service.CreateAutoAcceptRule(ctx, "tenant", req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"edge"}, rule.Tags)
+
+	req.Action = "reject"
+	_, err = service.CreateAutoAcceptRule(ctx, "tenant", req)
+	assert.Equal(t, NewErrAutoAcceptRuleInvalid(map[string]interface{}{"action": "reject", "tags": []string{"edge"}}, nil), err)
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteAutoAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the rule is not found",
+			requiredMocks: func() {
+				mock.On("AutoAcceptRuleDelete", ctx, "tenant", "id").Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrAutoAcceptRuleNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("AutoAcceptRuleDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.DeleteAutoAcceptRule(ctx, "tenant", "id"))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+type CertificateAuthorityService interface {
+	ListCertificateAuthorities(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error)
+	// CreateCertificateAuthority trusts a certificate authority on the namespace. The certificate authority's data must
+	// be a plain public key, not a certificate.
+	CreateCertificateAuthority(ctx context.Context, tenant string, req requests.CertificateAuthorityCreate) (*models.CertificateAuthority, error)
+	DeleteCertificateAuthority(ctx context.Context, tenant, fingerprint string) error
+}
+
+func (s *service) ListCertificateAuthorities(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error) {
+	return s.store.CertificateAuthorityList(ctx, tenant, paginator)
+}
+
+func (s *service) CreateCertificateAuthority(ctx context.Context, tenant string, req requests.CertificateAuthorityCreate) (*models.CertificateAuthority, error) {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(req.Data) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrCertAuthorityInvalid(req.Data, err)
+	}
+
+	if _, ok := pubKey.(*ssh.Certificate); ok {
+		return nil, NewErrCertAuthorityInvalid(req.Data, nil)
+	}
+
+	ca := &models.CertificateAuthority{
+		Data:        ssh.MarshalAuthorizedKey(pubKey),
+		Fingerprint: ssh.FingerprintLegacyMD5(pubKey),
+		Name:        req.Name,
+		TenantID:    tenant,
+		CreatedAt:   clock.Now(),
+	}
+
+	if err := s.store.CertificateAuthorityCreate(ctx, ca); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			return nil, NewErrCertAuthorityDuplicated([]string{ca.Fingerprint}, err)
+		}
+
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+func (s *service) DeleteCertificateAuthority(ctx context.Context, tenant, fingerprint string) error {
+	if err := s.store.CertificateAuthorityDelete(ctx, tenant, fingerprint); err != nil {
+		return NewErrCertAuthorityNotFound(fingerprint, err)
+	}
+
+	return nil
+}
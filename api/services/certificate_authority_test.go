@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateCertificateAuthority(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now)
+
+	pubKey, _ := ssh.NewPublicKey(publicKey)
+
+	signer, _ := ssh.NewSignerFromKey(privateKey)
+	cert := &ssh.Certificate{Key: pubKey, CertType: ssh.UserCert, ValidPrincipals: []string{"root"}}
+	_ = cert.SignCert(rand.Reader, signer)
+
+	ca := &models.CertificateAuthority{
+		Data:        ssh.MarshalAuthorizedKey(pubKey),
+		Fingerprint: ssh.FingerprintLegacyMD5(pubKey),
+		Name:        "ca",
+		TenantID:    "tenant",
+		CreatedAt:   now,
+	}
+
+	type Expected struct {
+		ca  *models.CertificateAuthority
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.CertificateAuthorityCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the data is not a public key",
+			req:           requests.CertificateAuthorityCreate{Data: []byte("invalid"), Name: "ca"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrCertAuthorityInvalid([]byte("invalid"), errors.New("ssh: no key found"))},
+		},
+		{
+			description:   "fails when the data is a certificate",
+			req:           requests.CertificateAuthorityCreate{Data: ssh.MarshalAuthorizedKey(cert), Name: "ca"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrCertAuthorityInvalid(ssh.MarshalAuthorizedKey(cert), nil)},
+		},
+		{
+			description: "fails when the namespace already trusts the certificate authority",
+			req:         requests.CertificateAuthorityCreate{Data: ssh.MarshalAuthorizedKey(pubKey), Name: "ca"},
+			requiredMocks: func() {
+				mock.On("CertificateAuthorityCreate", ctx, ca).Return(store.ErrDuplicate).Once()
+			},
+			expected: Expected{nil, NewErrCertAuthorityDuplicated([]string{ca.Fingerprint}, store.ErrDuplicate)},
+		},
+		{
+			description: "succeeds to create the certificate authority",
+			req:         requests.CertificateAuthorityCreate{Data: ssh.MarshalAuthorizedKey(pubKey), Name: "ca"},
+			requiredMocks: func() {
+				mock.On("CertificateAuthorityCreate", ctx, ca).Return(nil).Once()
+			},
+			expected: Expected{ca, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			created, err := service.CreateCertificateAuthority(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected.err, err)
+			assert.Equal(t, tc.expected.ca, created)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -20,7 +20,9 @@ import (
 const StatusAccepted = "accepted"
 
 type DeviceService interface {
-	ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, sorter query.Sorter) ([]models.Device, int, error)
+	// ListDevices lists the namespace's devices. When group is not empty, only the members of the device group
+	// identified by it, or of its descendants, are listed.
+	ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, group string, sorter query.Sorter) ([]models.Device, int, error)
 	GetDevice(ctx context.Context, uid models.UID) (*models.Device, error)
 	GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error)
 	DeleteDevice(ctx context.Context, uid models.UID, tenant string) error
@@ -31,14 +33,29 @@ type DeviceService interface {
 	SetDevicePosition(ctx context.Context, uid models.UID, ip string) error
 	DeviceHeartbeat(ctx context.Context, uid models.UID) error
 	UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error
+	// SelectDevices selects the namespace's devices targeted by a bulk operation: the ones with the given UIDs or, when
+	// no UID is given, the ones matching the filters. UIDs not found in the namespace are left out of the selection.
+	SelectDevices(ctx context.Context, tenant string, uids []string, filters query.Filters) ([]models.Device, error)
 }
 
-func (s *service) ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, sorter query.Sorter) ([]models.Device, int, error) {
+// DeviceBulkMax is the maximum number of devices a bulk operation can be applied to.
+const DeviceBulkMax = 1000
+
+func (s *service) ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, group string, sorter query.Sorter) ([]models.Device, int, error) {
 	ns, err := s.store.NamespaceGet(ctx, tenant)
 	if err != nil {
 		return nil, 0, NewErrNamespaceNotFound(tenant, err)
 	}
 
+	if group != "" {
+		members, err := s.deviceGroupMembers(ctx, tenant, group)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		filter = withDeviceGroupMembers(filter, members)
+	}
+
 	if status == models.DeviceStatusRemoved {
 		removed, count, err := s.store.DeviceRemovedList(ctx, tenant, paginator, filter, sorter)
 		if err != nil {
@@ -74,6 +91,51 @@ func (s *service) ListDevices(ctx context.Context, tenant string, status models.
 	return s.store.DeviceList(ctx, status, paginator, filter, sorter, store.DeviceAcceptableIfNotAccepted)
 }
 
+func (s *service) SelectDevices(ctx context.Context, tenant string, uids []string, filters query.Filters) ([]models.Device, error) {
+	if len(uids) > DeviceBulkMax {
+		return nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+	}
+
+	if len(uids) > 0 {
+		devices := make([]models.Device, 0, len(uids))
+		for _, uid := range uids {
+			device, err := s.store.DeviceGetByUID(ctx, models.UID(uid), tenant)
+			if err == store.ErrNoDocuments {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			devices = append(devices, *device)
+		}
+
+		return devices, nil
+	}
+
+	// The devices list is scoped to the tenant within the context, so it must contain the namespace's tenant.
+	ctx = context.WithValue(ctx, "tenant", tenant) //nolint:revive
+
+	list, count, err := s.store.DeviceList(ctx, "", query.Paginator{Page: 1, PerPage: DeviceBulkMax}, filters, *query.NewSorter(), store.DeviceAcceptableIfNotAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > DeviceBulkMax {
+		return nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+	}
+
+	devices := make([]models.Device, 0, len(list))
+	for _, device := range list {
+		if device.TenantID == tenant {
+			devices = append(devices, device)
+		}
+	}
+
+	return devices, nil
+}
+
 func (s *service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
 	device, err := s.store.DeviceGet(ctx, uid)
 	if err != nil {
@@ -181,7 +243,7 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 }
 
 func (s *service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
-	err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), online)
+	_, err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), online)
 	if err == store.ErrNoDocuments {
 		return NewErrDeviceNotFound(uid, err)
 	}
@@ -219,14 +281,19 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid mod
 
 	// NOTICE: when there is an already accepted device with the same MAC address, we need to update the device UID
 	// transfer the sessions and delete the old device.
-	sameMacDev, err := s.store.DeviceGetByMac(ctx, device.Identity.MAC, device.TenantID, models.DeviceStatusAccepted)
-	if err != nil && err != store.ErrNoDocuments {
-		return NewErrDeviceNotFound(models.UID(device.UID), err)
+	//
+	// Agentless targets have no identity, so they never replace another device.
+	var sameMacDev *models.Device
+	if device.Identity != nil {
+		sameMacDev, err = s.store.DeviceGetByMac(ctx, device.Identity.MAC, device.TenantID, models.DeviceStatusAccepted)
+		if err != nil && err != store.ErrNoDocuments {
+			return NewErrDeviceNotFound(models.UID(device.UID), err)
+		}
 	}
 
 	// TODO: move this logic to store's transactions.
 	if sameMacDev != nil && sameMacDev.UID != device.UID {
-		if sameName, err := s.store.DeviceGetByName(ctx, device.Name, device.TenantID, models.DeviceStatusAccepted); sameName != nil && sameName.Identity.MAC != device.Identity.MAC {
+		if sameName, err := s.store.DeviceGetByName(ctx, device.Name, device.TenantID, models.DeviceStatusAccepted); sameName != nil && (sameName.Identity == nil || sameName.Identity.MAC != device.Identity.MAC) {
 			return NewErrDeviceDuplicated(device.Name, err)
 		}
 
@@ -338,7 +405,7 @@ func (s *service) SetDevicePosition(ctx context.Context, uid models.UID, ip stri
 }
 
 func (s *service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
-	if err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), true); err != nil {
+	if _, err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), true); err != nil {
 		return NewErrDeviceNotFound(uid, err)
 	}
 
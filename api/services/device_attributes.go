@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceAttributes contains the service's function to manage device attributes.
+type DeviceAttributes interface {
+	SetDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error
+	UnsetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error
+}
+
+// DeviceMaxAttributes is the number of attributes that a device can have.
+const DeviceMaxAttributes = 32
+
+// SetDeviceAttributes sets attributes on a device, keeping the attributes already set and not present on attributes.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If the device would have more than DeviceMaxAttributes attributes, a NewErrDeviceAttributeLimit error will be returned.
+func (s *service) SetDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	count := len(device.Attributes)
+	for key := range attributes {
+		if _, ok := device.Attributes[key]; !ok {
+			count++
+		}
+	}
+
+	if count > DeviceMaxAttributes {
+		return NewErrDeviceAttributeLimit(DeviceMaxAttributes, nil)
+	}
+
+	return s.store.DeviceSetAttributes(ctx, uid, attributes)
+}
+
+// UnsetDeviceAttribute removes an attribute from a device.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If the attribute is not set on the device, a NewErrDeviceAttributeNotFound error will be returned.
+func (s *service) UnsetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if _, ok := device.Attributes[key]; !ok {
+		return NewErrDeviceAttributeNotFound(key, nil)
+	}
+
+	return s.store.DeviceUnsetAttribute(ctx, uid, key)
+}
+
+// hasAttributes checks if every attribute is set on the device with the same value.
+func hasAttributes(device *models.Device, attributes map[string]string) bool {
+	for key, value := range attributes {
+		if current, ok := device.Attributes[key]; !ok || current != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDeviceAttributes(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		attributes    map[string]string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			attributes:  map[string]string{"site": "lab"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device would exceed the attribute limit",
+			attributes:  map[string]string{"site": "lab"},
+			requiredMocks: func() {
+				attributes := make(map[string]string, DeviceMaxAttributes)
+				for i := 0; i < DeviceMaxAttributes; i++ {
+					attributes[string(rune('a'+i))] = "value"
+				}
+
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Attributes: attributes}, nil).Once()
+			},
+			expected: NewErrDeviceAttributeLimit(DeviceMaxAttributes, nil),
+		},
+		{
+			description: "succeeds when the attributes are set",
+			attributes:  map[string]string{"site": "lab"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Attributes: map[string]string{"site": "office"}}, nil).Once()
+				mock.On("DeviceSetAttributes", ctx, models.UID("uid"), map[string]string{"site": "lab"}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.SetDeviceAttributes(ctx, "tenant", models.UID("uid"), tc.attributes))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUnsetDeviceAttribute(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the attribute is not set",
+			key:         "rack",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Attributes: map[string]string{"site": "lab"}}, nil).Once()
+			},
+			expected: NewErrDeviceAttributeNotFound("rack", nil),
+		},
+		{
+			description: "succeeds when the attribute is unset",
+			key:         "site",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Attributes: map[string]string{"site": "lab"}}, nil).Once()
+				mock.On("DeviceUnsetAttribute", ctx, models.UID("uid"), "site").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.UnsetDeviceAttribute(ctx, "tenant", models.UID("uid"), tc.key))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupService interface {
+	ListDeviceGroups(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error)
+	GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error)
+	UpdateDeviceGroup(ctx context.Context, tenant, id string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	// DeleteDeviceGroup deletes a device group. Groups with child groups, or used to filter a firewall rule or a public
+	// key, can't be deleted.
+	DeleteDeviceGroup(ctx context.Context, tenant, id string) error
+}
+
+func (s *service) ListDeviceGroups(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error) {
+	return s.store.DeviceGroupList(ctx, tenant, paginator)
+}
+
+func (s *service) GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+func (s *service) CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	if err := s.checkDeviceGroupParent(ctx, tenant, "", req.Parent); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	group := &models.DeviceGroup{
+		TenantID:          tenant,
+		DeviceGroupFields: deviceGroupFieldsFromRequest(req.DeviceGroupFields),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.store.DeviceGroupCreate(ctx, group); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrDeviceGroupDuplicated(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *service) UpdateDeviceGroup(ctx context.Context, tenant, id string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	if err := s.checkDeviceGroupParent(ctx, tenant, id, req.Parent); err != nil {
+		return nil, err
+	}
+
+	fields := deviceGroupFieldsFromRequest(req.DeviceGroupFields)
+
+	group, err := s.store.DeviceGroupUpdate(ctx, tenant, id, &fields)
+	switch {
+	case err == store.ErrDuplicate:
+		return nil, NewErrDeviceGroupDuplicated(req.Name, err)
+	case err != nil:
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+func (s *service) DeleteDeviceGroup(ctx context.Context, tenant, id string) error {
+	groups, _, err := s.store.DeviceGroupList(ctx, tenant, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if group.Parent == id {
+			return NewErrDeviceGroupInvalid(map[string]interface{}{"id": id, "child": group.ID}, nil)
+		}
+	}
+
+	if err := s.checkDeviceGroupReferences(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	if err := s.store.DeviceGroupDelete(ctx, tenant, id); err != nil {
+		return NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return nil
+}
+
+// checkDeviceGroupParent checks that the parent group exists in the namespace and that setting it as the parent of the
+// group identified by id, empty for new groups, doesn't create a cycle.
+func (s *service) checkDeviceGroupParent(ctx context.Context, tenant, id, parent string) error {
+	if parent == "" {
+		return nil
+	}
+
+	groups, _, err := s.store.DeviceGroupList(ctx, tenant, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	parents := make(map[string]string, len(groups))
+	for _, group := range groups {
+		parents[group.ID] = group.Parent
+	}
+
+	if _, ok := parents[parent]; !ok {
+		return NewErrDeviceGroupNotFound(parent, nil)
+	}
+
+	for current := parent; current != ""; current = parents[current] {
+		if current == id {
+			return NewErrDeviceGroupInvalid(map[string]interface{}{"id": id, "parent": parent}, nil)
+		}
+	}
+
+	return nil
+}
+
+// checkDeviceGroupReferences checks that no firewall rule nor public key of the namespace filters by the device group
+// identified by id.
+func (s *service) checkDeviceGroupReferences(ctx context.Context, tenant, id string) error {
+	// The lists are scoped to the tenant within the context.
+	ctx = context.WithValue(ctx, "tenant", tenant) //nolint:revive
+
+	rules, _, err := s.store.FirewallRuleList(ctx, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if rule.TenantID == tenant && rule.Filter.Group == id {
+			return NewErrDeviceGroupInvalid(map[string]interface{}{"id": id, "firewall_rule": rule.ID}, nil)
+		}
+	}
+
+	keys, _, err := s.store.PublicKeyList(ctx, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if key.TenantID == tenant && key.Filter.Group == id {
+			return NewErrDeviceGroupInvalid(map[string]interface{}{"id": id, "public_key": key.Fingerprint}, nil)
+		}
+	}
+
+	return nil
+}
+
+// deviceGroupTree returns the device group identified by id and all of its descendants, or nil when the group doesn't
+// exist.
+func deviceGroupTree(groups []models.DeviceGroup, id string) []models.DeviceGroup {
+	var tree []models.DeviceGroup
+	for _, group := range groups {
+		if group.ID == id {
+			tree = append(tree, group)
+		}
+	}
+
+	// As groups can't have cycles, each group is added to the tree at most once.
+	for i := 0; i < len(tree); i++ {
+		for _, group := range groups {
+			if group.Parent == tree[i].ID {
+				tree = append(tree, group)
+			}
+		}
+	}
+
+	return tree
+}
+
+// isDeviceGroupMember checks if the device is a member of the device group identified by id or of its descendants. A
+// group that doesn't exist, as one deleted after being referenced, has no members.
+func (s *service) isDeviceGroupMember(ctx context.Context, tenant, id string, device *models.Device) (bool, error) {
+	groups, _, err := s.store.DeviceGroupList(ctx, tenant, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return false, err
+	}
+
+	return hasDeviceGroupMember(groups, id, device), nil
+}
+
+// hasDeviceGroupMember checks, within the namespace's groups, if the device is a member of the device group identified
+// by id or of its descendants.
+func hasDeviceGroupMember(groups []models.DeviceGroup, id string, device *models.Device) bool {
+	for _, group := range deviceGroupTree(groups, id) {
+		if group.HasMember(device) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deviceGroupMembers returns the UIDs of the members of the device group identified by id and of its descendants.
+func (s *service) deviceGroupMembers(ctx context.Context, tenant, id string) ([]models.UID, error) {
+	groups, _, err := s.store.DeviceGroupList(ctx, tenant, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	tree := deviceGroupTree(groups, id)
+	if len(tree) == 0 {
+		return nil, NewErrDeviceGroupNotFound(id, nil)
+	}
+
+	return s.store.DeviceGroupMembers(ctx, tenant, tree)
+}
+
+// withDeviceGroupMembers restricts the filters to the devices with the given UIDs. Trailing properties of the filters
+// are closed with the "or" operator, as they are when no restriction is applied.
+func withDeviceGroupMembers(filters query.Filters, uids []models.UID) query.Filters {
+	data := make([]query.Filter, len(filters.Data), len(filters.Data)+3)
+	copy(data, filters.Data)
+
+	if len(data) > 0 && data[len(data)-1].Type == query.FilterTypeProperty {
+		data = append(data, query.Filter{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "or"}})
+	}
+
+	values := make([]interface{}, 0, len(uids))
+	for _, uid := range uids {
+		values = append(values, string(uid))
+	}
+
+	data = append(data,
+		query.Filter{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "uid", Operator: "in", Value: values}},
+		query.Filter{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "and"}},
+	)
+
+	return query.Filters{Raw: filters.Raw, Data: data}
+}
+
+func deviceGroupFieldsFromRequest(fields requests.DeviceGroupFields) models.DeviceGroupFields {
+	devices := fields.Devices
+	if devices == nil {
+		devices = []string{}
+	}
+
+	return models.DeviceGroupFields{
+		Name:        fields.Name,
+		Description: fields.Description,
+		Parent:      fields.Parent,
+		Devices:     devices,
+		Filter: models.DeviceGroupFilter{
+			Tags:       fields.Filter.Tags,
+			Attributes: fields.Filter.Attributes,
+		},
+	}
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestDeviceGroupHasMember(t *testing.T) {
+	device := &models.Device{
+		UID:        "uid",
+		Tags:       []string{"edge"},
+		Attributes: map[string]string{"site": "lisbon", "env": "prod"},
+	}
+
+	cases := []struct {
+		description string
+		group       models.DeviceGroup
+		expected    bool
+	}{
+		{
+			description: "does not match when the group has no members",
+			group:       models.DeviceGroup{},
+			expected:    false,
+		},
+		{
+			description: "matches when the device is a static member",
+			group:       models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{Devices: []string{"uid"}}},
+			expected:    true,
+		},
+		{
+			description: "matches when the device has any of the filter's tags and all of its attributes",
+			group: models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{
+				Filter: models.DeviceGroupFilter{Tags: []string{"core", "edge"}, Attributes: map[string]string{"site": "lisbon"}},
+			}},
+			expected: true,
+		},
+		{
+			description: "does not match when the device has none of the filter's tags",
+			group: models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{
+				Filter: models.DeviceGroupFilter{Tags: []string{"core"}},
+			}},
+			expected: false,
+		},
+		{
+			description: "does not match when an attribute differs",
+			group: models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{
+				Filter: models.DeviceGroupFilter{Attributes: map[string]string{"site": "lisbon", "env": "dev"}},
+			}},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.group.HasMember(device))
+		})
+	}
+}
+
+func TestDeviceGroupTree(t *testing.T) {
+	groups := []models.DeviceGroup{
+		{ID: "europe"},
+		{ID: "portugal", DeviceGroupFields: models.DeviceGroupFields{Parent: "europe"}},
+		{ID: "lisbon", DeviceGroupFields: models.DeviceGroupFields{Parent: "portugal"}},
+		{ID: "america"},
+	}
+
+	ids := func(groups []models.DeviceGroup) []string {
+		list := make([]string, 0, len(groups))
+		for _, group := range groups {
+			list = append(list, group.ID)
+		}
+
+		return list
+	}
+
+	assert.Equal(t, []string{"europe", "portugal", "lisbon"}, ids(deviceGroupTree(groups, "europe")))
+	assert.Equal(t, []string{"lisbon"}, ids(deviceGroupTree(groups, "lisbon")))
+	assert.Empty(t, deviceGroupTree(groups, "asia"))
+}
+
+func TestCreateDeviceGroup(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	groups := []models.DeviceGroup{
+		{ID: "europe", TenantID: "tenant"},
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceGroupCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the parent group is not found",
+			req: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "lisbon", Parent: "asia"},
+			},
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(groups, 1, nil).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("asia", nil),
+		},
+		{
+			description: "fails when the name is already used",
+			req: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "europe"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGroupCreate", ctx, gomock.Anything).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrDeviceGroupDuplicated("europe", store.ErrDuplicate),
+		},
+		{
+			description: "succeeds",
+			req: requests.DeviceGroupCreate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "lisbon", Parent: "europe"},
+			},
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(groups, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGroupCreate", ctx, gomock.MatchedBy(func(group *models.DeviceGroup) bool {
+					return group.TenantID == "tenant" && group.Name == "lisbon" && group.Parent == "europe" &&
+						group.Devices != nil && group.CreatedAt.Equal(now)
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			_, err := service.CreateDeviceGroup(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceGroup(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	groups := []models.DeviceGroup{
+		{ID: "europe", TenantID: "tenant"},
+		{ID: "portugal", TenantID: "tenant", DeviceGroupFields: models.DeviceGroupFields{Parent: "europe"}},
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceGroupUpdate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the parent is a descendant of the group",
+			req: requests.DeviceGroupUpdate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "europe", Parent: "portugal"},
+			},
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(groups, 2, nil).Once()
+			},
+			expected: NewErrDeviceGroupInvalid(map[string]interface{}{"id": "europe", "parent": "portugal"}, nil),
+		},
+		{
+			description: "fails when the group is not found",
+			req: requests.DeviceGroupUpdate{
+				DeviceGroupFields: requests.DeviceGroupFields{Name: "europe"},
+			},
+			requiredMocks: func() {
+				mock.On("DeviceGroupUpdate", ctx, "tenant", "europe", gomock.Anything).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("europe", store.ErrNoDocuments),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			_, err := service.UpdateDeviceGroup(ctx, "tenant", "europe", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceGroup(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the group has child groups",
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{
+						{ID: "europe"},
+						{ID: "portugal", DeviceGroupFields: models.DeviceGroupFields{Parent: "europe"}},
+					}, 2, nil).Once()
+			},
+			expected: NewErrDeviceGroupInvalid(map[string]interface{}{"id": "europe", "child": "portugal"}, nil),
+		},
+		{
+			description: "fails when a firewall rule filters by the group",
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{{ID: "europe"}}, 1, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							ID:       "rule",
+							TenantID: "tenant",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Filter: models.FirewallFilter{Group: "europe"},
+							},
+						},
+					}, 1, nil).Once()
+			},
+			expected: NewErrDeviceGroupInvalid(map[string]interface{}{"id": "europe", "firewall_rule": "rule"}, nil),
+		},
+		{
+			description: "fails when a public key filters by the group",
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{{ID: "europe"}}, 1, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{}, 0, nil).Once()
+				mock.On("PublicKeyList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.PublicKey{
+						{
+							Fingerprint: "fingerprint",
+							TenantID:    "tenant",
+							PublicKeyFields: models.PublicKeyFields{
+								Filter: models.PublicKeyFilter{Group: "europe"},
+							},
+						},
+					}, 1, nil).Once()
+			},
+			expected: NewErrDeviceGroupInvalid(map[string]interface{}{"id": "europe", "public_key": "fingerprint"}, nil),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{{ID: "europe"}}, 1, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							ID:       "other",
+							TenantID: "other",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Filter: models.FirewallFilter{Group: "europe"},
+							},
+						},
+					}, 1, nil).Once()
+				mock.On("PublicKeyList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.PublicKey{}, 0, nil).Once()
+				mock.On("DeviceGroupDelete", ctx, "tenant", "europe").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.DeleteDeviceGroup(ctx, "tenant", "europe"))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWithDeviceGroupMembers(t *testing.T) {
+	property := query.Filter{
+		Type:   query.FilterTypeProperty,
+		Params: &query.FilterProperty{Name: "name", Operator: "contains", Value: "edge"},
+	}
+
+	filters := withDeviceGroupMembers(query.Filters{Data: []query.Filter{property}}, []models.UID{"uid"})
+
+	assert.Equal(t, []query.Filter{
+		property,
+		{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "or"}},
+		{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "uid", Operator: "in", Value: []interface{}{"uid"}}},
+		{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "and"}},
+	}, filters.Data)
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+// DeviceTargets contains the service's functions to manage the agentless targets, hosts that cannot run the agent but
+// sit on the network of a device that does.
+type DeviceTargets interface {
+	// CreateDeviceTarget registers an agentless target reached through the gateway device's agent, accepting it on
+	// the namespace.
+	CreateDeviceTarget(ctx context.Context, tenant string, req requests.DeviceTargetCreate) (*models.Device, error)
+	// ListDeviceTargets lists the agentless targets the gateway device's agent probes.
+	ListDeviceTargets(ctx context.Context, gateway models.UID) ([]models.DeviceTarget, error)
+	// ProbeDeviceTargets sets the online status of the agentless targets probed by the gateway device's agent.
+	ProbeDeviceTargets(ctx context.Context, gateway models.UID, probes []models.DeviceTargetProbe) error
+	// GetDeviceCredentials gets the credentials stored to authenticate on an agentless target.
+	GetDeviceCredentials(ctx context.Context, uid models.UID) (*models.DeviceCredentials, error)
+}
+
+// deviceTargetUID returns the UID of the agentless target on the address reached through the gateway device.
+func deviceTargetUID(tenant, gateway, address string) string {
+	uid := sha256.Sum256([]byte(strings.Join([]string{tenant, gateway, address}, "/")))
+
+	return hex.EncodeToString(uid[:])
+}
+
+// CreateDeviceTarget registers an agentless target reached through the gateway device's agent.
+//
+// If the gateway device does not exist, a NewErrDeviceNotFound error will be returned. If the gateway device is an
+// agentless target itself, a NewErrDeviceViaInvalid error will be returned. If the target is already registered, a
+// NewErrDeviceDuplicated error will be returned. Once registered, the target is accepted as any other device, being
+// subject to the namespace's device limit.
+func (s *service) CreateDeviceTarget(ctx context.Context, tenant string, req requests.DeviceTargetCreate) (*models.Device, error) {
+	gateway, err := s.store.DeviceGetByUID(ctx, models.UID(req.Gateway), tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.Gateway), err)
+	}
+
+	// NOTICE: The target is dialed and probed by the gateway device's agent, so the gateway must have one.
+	if gateway.Agentless {
+		return nil, NewErrDeviceViaInvalid(map[string]interface{}{"gateway": req.Gateway}, nil)
+	}
+
+	var credentials *models.DeviceCredentials
+	if req.Password != "" || req.PrivateKey != "" {
+		if req.PrivateKey != "" {
+			if _, err := ssh.ParsePrivateKey([]byte(req.PrivateKey)); err != nil {
+				return nil, NewErrDeviceCredentialsInvalid(err)
+			}
+		}
+
+		credentials, err = s.sealDeviceCredentials(&models.DeviceCredentials{Password: req.Password, PrivateKey: req.PrivateKey})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	port := req.Port
+	if port == 0 {
+		port = DeviceViaDefaultPort
+	}
+
+	uid := deviceTargetUID(tenant, req.Gateway, net.JoinHostPort(req.Host, strconv.FormatUint(uint64(port), 10)))
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(uid), tenant); err == nil {
+		return nil, NewErrDeviceDuplicated(req.Name, nil)
+	}
+
+	device := models.Device{
+		UID:         uid,
+		TenantID:    tenant,
+		LastSeen:    clock.Now(),
+		Via:         &models.DeviceVia{Device: req.Gateway, Host: req.Host, Port: port},
+		Agentless:   true,
+		Credentials: credentials,
+	}
+
+	if err := s.store.DeviceCreate(ctx, device, strings.ToLower(req.Name)); err != nil {
+		return nil, NewErrDeviceCreate(device, err)
+	}
+
+	if err := s.UpdateDeviceStatus(ctx, tenant, models.UID(uid), models.DeviceStatusAccepted); err != nil {
+		return nil, err
+	}
+
+	created, err := s.store.DeviceGet(ctx, models.UID(uid))
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(uid), err)
+	}
+
+	return created, nil
+}
+
+// ListDeviceTargets lists the agentless targets reached through the gateway device, with the address its agent probes.
+func (s *service) ListDeviceTargets(ctx context.Context, gateway models.UID) ([]models.DeviceTarget, error) {
+	devices, err := s.store.DeviceTargetList(ctx, gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]models.DeviceTarget, 0, len(devices))
+	for _, device := range devices {
+		targets = append(targets, models.DeviceTarget{UID: device.UID, Host: device.Via.Host, Port: device.Via.Port})
+	}
+
+	return targets, nil
+}
+
+// ProbeDeviceTargets sets the online status of the agentless targets probed by the gateway device's agent. The probes
+// to targets not reached through the gateway device are ignored.
+func (s *service) ProbeDeviceTargets(ctx context.Context, gateway models.UID, probes []models.DeviceTargetProbe) error {
+	devices, err := s.store.DeviceTargetList(ctx, gateway)
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		targets[device.UID] = true
+	}
+
+	for _, probe := range probes {
+		if !targets[probe.UID] {
+			continue
+		}
+
+		if _, err := s.store.DeviceSetOnline(ctx, models.UID(probe.UID), clock.Now(), probe.Online); err != nil && err != store.ErrNoDocuments {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDeviceCredentials gets the credentials stored to authenticate on an agentless target, decrypting them. When none
+// is stored, the credentials are empty.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned. If the credentials cannot be decrypted,
+// a NewErrDeviceCredentialsInvalid error will be returned.
+func (s *service) GetDeviceCredentials(ctx context.Context, uid models.UID) (*models.DeviceCredentials, error) {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Credentials == nil {
+		return &models.DeviceCredentials{}, nil
+	}
+
+	return s.openDeviceCredentials(device.Credentials)
+}
+
+// deviceCredentialsCipher returns the AES-GCM cipher which encrypts the credentials of the agentless targets at rest.
+// Its key is derived from the API's private key, so the credentials can't be read from the database alone. Replacing
+// the private key requires the targets' credentials to be set again.
+func (s *service) deviceCredentialsCipher() (cipher.AEAD, error) {
+	key := sha256.Sum256(x509.MarshalPKCS1PrivateKey(s.privKey))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealDeviceCredentials encrypts each non-empty credential, encoding it as the base64 of the nonce followed by the
+// ciphertext.
+func (s *service) sealDeviceCredentials(credentials *models.DeviceCredentials) (*models.DeviceCredentials, error) {
+	aead, err := s.deviceCredentialsCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	seal := func(plaintext string) (string, error) {
+		if plaintext == "" {
+			return "", nil
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return "", err
+		}
+
+		return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+	}
+
+	password, err := seal(credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := seal(credentials.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DeviceCredentials{Password: password, PrivateKey: privateKey}, nil
+}
+
+// openDeviceCredentials decrypts the credentials encrypted by [service.sealDeviceCredentials].
+func (s *service) openDeviceCredentials(credentials *models.DeviceCredentials) (*models.DeviceCredentials, error) {
+	aead, err := s.deviceCredentialsCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	open := func(sealed string) (string, error) {
+		if sealed == "" {
+			return "", nil
+		}
+
+		data, err := base64.StdEncoding.DecodeString(sealed)
+		if err != nil {
+			return "", err
+		}
+
+		if len(data) < aead.NonceSize() {
+			return "", errors.New("sealed credential is too short")
+		}
+
+		plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+		if err != nil {
+			return "", err
+		}
+
+		return string(plaintext), nil
+	}
+
+	password, err := open(credentials.Password)
+	if err != nil {
+		return nil, NewErrDeviceCredentialsInvalid(err)
+	}
+
+	privateKey, err := open(credentials.PrivateKey)
+	if err != nil {
+		return nil, NewErrDeviceCredentialsInvalid(err)
+	}
+
+	return &models.DeviceCredentials{Password: password, PrivateKey: privateKey}, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceTarget(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	req := requests.DeviceTargetCreate{Name: "Switch", Gateway: "gateway", Host: "192.168.0.2", Password: "secret"}
+	uid := deviceTargetUID("tenant", "gateway", "192.168.0.2:22")
+
+	type Expected struct {
+		device *models.Device
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceTargetCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the gateway device is not found",
+			req:         req,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("gateway"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the gateway device is an agentless target",
+			req:         req,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").
+					Return(&models.Device{UID: "gateway", Agentless: true}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceViaInvalid(map[string]interface{}{"gateway": "gateway"}, nil)},
+		},
+		{
+			description: "fails when the private key is invalid",
+			req:         requests.DeviceTargetCreate{Name: "switch", Gateway: "gateway", Host: "192.168.0.2", PrivateKey: "invalid"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(&models.Device{UID: "gateway"}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceCredentialsInvalid(errors.New("ssh: no key found"))},
+		},
+		{
+			description: "fails when the target is already registered",
+			req:         req,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(&models.Device{UID: "gateway"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), "tenant").Return(&models.Device{UID: uid}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceDuplicated("Switch", nil)},
+		},
+		{
+			description: "succeeds when the target is registered and accepted",
+			req:         req,
+			requiredMocks: func() {
+				device := models.Device{
+					UID:       uid,
+					TenantID:  "tenant",
+					LastSeen:  now,
+					Via:       &models.DeviceVia{Device: "gateway", Host: "192.168.0.2", Port: 22},
+					Agentless: true,
+				}
+
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(&models.Device{UID: "gateway"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), "tenant").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceCreate", ctx, gomock.MatchedBy(func(created models.Device) bool {
+					if created.Credentials == nil || created.Credentials.Password == "secret" {
+						return false
+					}
+
+					credentials, err := service.openDeviceCredentials(created.Credentials)
+					if err != nil || *credentials != (models.DeviceCredentials{Password: "secret"}) {
+						return false
+					}
+
+					created.Credentials = nil
+
+					return assert.ObjectsAreEqual(device, created)
+				}), "switch").Return(nil).Once()
+
+				pending := device
+				pending.Name = "switch"
+				pending.Status = models.DeviceStatusPending
+
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), "tenant").Return(&pending, nil).Once()
+				mock.On("DeviceGetByName", ctx, "switch", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+				mock.On("DeviceUpdateStatus", ctx, models.UID(uid), models.DeviceStatusAccepted).Return(nil).Once()
+
+				accepted := pending
+				accepted.Status = models.DeviceStatusAccepted
+
+				mock.On("DeviceGet", ctx, models.UID(uid)).Return(&accepted, nil).Once()
+			},
+			expected: Expected{
+				device: &models.Device{
+					UID:       uid,
+					Name:      "switch",
+					TenantID:  "tenant",
+					LastSeen:  now,
+					Status:    models.DeviceStatusAccepted,
+					Via:       &models.DeviceVia{Device: "gateway", Host: "192.168.0.2", Port: 22},
+					Agentless: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			device, err := service.CreateDeviceTarget(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, Expected{device, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceTargets(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	mock.On("DeviceTargetList", ctx, models.UID("gateway")).Return([]models.Device{
+		{UID: "switch", Via: &models.DeviceVia{Device: "gateway", Host: "192.168.0.2", Port: 22}},
+		{UID: "plc", Via: &models.DeviceVia{Device: "gateway", Host: "plc.lan", Port: 2222}},
+	}, nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+	targets, err := service.ListDeviceTargets(ctx, models.UID("gateway"))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DeviceTarget{
+		{UID: "switch", Host: "192.168.0.2", Port: 22},
+		{UID: "plc", Host: "plc.lan", Port: 2222},
+	}, targets)
+
+	mock.AssertExpectations(t)
+}
+
+func TestProbeDeviceTargets(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		probes        []models.DeviceTargetProbe
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the targets cannot be listed",
+			probes:      []models.DeviceTargetProbe{{UID: "switch", Online: true}},
+			requiredMocks: func() {
+				mock.On("DeviceTargetList", ctx, models.UID("gateway")).Return(nil, errors.New("error")).Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds ignoring the targets not reached through the gateway device",
+			probes: []models.DeviceTargetProbe{
+				{UID: "switch", Online: true},
+				{UID: "plc", Online: false},
+				{UID: "other", Online: true},
+			},
+			requiredMocks: func() {
+				mock.On("DeviceTargetList", ctx, models.UID("gateway")).
+					Return([]models.Device{{UID: "switch"}, {UID: "plc"}}, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				mock.On("DeviceSetOnline", ctx, models.UID("switch"), now, true).Return(true, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("plc"), now, false).Return(false, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.ProbeDeviceTargets(ctx, models.UID("gateway"), tc.probes))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceCredentials(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	sealed, err := service.sealDeviceCredentials(&models.DeviceCredentials{Password: "secret"})
+	assert.NoError(t, err)
+
+	type Expected struct {
+		credentials *models.DeviceCredentials
+		err         error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("switch")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("switch"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds with empty credentials when none is stored",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("switch")).Return(&models.Device{UID: "switch"}, nil).Once()
+			},
+			expected: Expected{&models.DeviceCredentials{}, nil},
+		},
+		{
+			description: "fails when the stored credentials are not encrypted",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("switch")).
+					Return(&models.Device{UID: "switch", Credentials: &models.DeviceCredentials{Password: "secret"}}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceCredentialsInvalid(base64.CorruptInputError(4))},
+		},
+		{
+			description: "succeeds decrypting the stored credentials",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("switch")).
+					Return(&models.Device{UID: "switch", Credentials: sealed}, nil).Once()
+			},
+			expected: Expected{&models.DeviceCredentials{Password: "secret"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			credentials, err := service.GetDeviceCredentials(ctx, models.UID("switch"))
+			assert.Equal(t, tc.expected, Expected{credentials, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -381,7 +381,7 @@ func TestListDevices_cloud(t *testing.T) {
 			tc.requiredMocks(tc.status, tc.pagination, tc.filter, tc.sorter)
 
 			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			devices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, tc.sorter)
+			devices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, "", tc.sorter)
 
 			assert.Equal(t, tc.expected.devices, devices)
 			assert.Equal(t, tc.expected.count, count)
@@ -690,7 +690,7 @@ func TestListDevices_enterprise(t *testing.T) {
 			tc.requiredMocks(tc.status, tc.pagination, tc.filter, tc.sorter)
 
 			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			returnedDevices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, tc.sorter)
+			returnedDevices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, "", tc.sorter)
 			assert.Equal(t, tc.expected, Expected{returnedDevices, count, err})
 		})
 	}
@@ -1146,7 +1146,7 @@ func TestListDevices_community(t *testing.T) {
 			tc.requiredMocks(tc.status, tc.pagination, tc.filter, tc.sorter)
 
 			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			returnedDevices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, tc.sorter)
+			returnedDevices, count, err := service.ListDevices(ctx, tc.tenant, tc.status, tc.pagination, tc.filter, "", tc.sorter)
 			assert.Equal(t, tc.expected, Expected{returnedDevices, count, err})
 		})
 	}
@@ -1633,7 +1633,7 @@ func TestOffineDevice(t *testing.T) {
 			requiredMocks: func() {
 				clockMock.On("Now").Return(now).Once()
 				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, false).
-					Return(errors.New("error", "", 0)).Once()
+					Return(false, errors.New("error", "", 0)).Once()
 			},
 			expected: errors.New("error", "", 0),
 		},
@@ -1645,7 +1645,7 @@ func TestOffineDevice(t *testing.T) {
 				online := true
 				clockMock.On("Now").Return(now).Once()
 				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, online).
-					Return(errors.New("error", "", 0)).Once()
+					Return(false, errors.New("error", "", 0)).Once()
 			},
 			expected: errors.New("error", "", 0),
 		},
@@ -3297,7 +3297,7 @@ func TestDeviceHeartbeat(t *testing.T) {
 
 	clockMock.On("Now").Return(now).Once()
 
-	mock.On("DeviceSetOnline", ctx, uid, now, true).Return(nil).Once()
+	mock.On("DeviceSetOnline", ctx, uid, now, true).Return(true, nil).Once()
 
 	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
 	err := service.DeviceHeartbeat(ctx, uid)
@@ -3713,3 +3713,64 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestSelectDevices(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	// The devices list is scoped to the tenant within the context.
+	tenantCtx := context.WithValue(ctx, "tenant", "tenant") //nolint:revive
+
+	type Expected struct {
+		devices []models.Device
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		uids          []string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "succeeds leaving out the UIDs not found in the namespace",
+			uids:        []string{"a", "b"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("a"), "tenant").
+					Return(&models.Device{UID: "a", TenantID: "tenant"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("b"), "tenant").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{[]models.Device{{UID: "a", TenantID: "tenant"}}, nil},
+		},
+		{
+			description: "fails when the filter selects too many devices",
+			requiredMocks: func() {
+				mock.On("DeviceList", tenantCtx, models.DeviceStatus(""), query.Paginator{Page: 1, PerPage: DeviceBulkMax}, query.Filters{}, *query.NewSorter(), store.DeviceAcceptableIfNotAccepted).
+					Return(make([]models.Device, DeviceBulkMax), DeviceBulkMax+1, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)},
+		},
+		{
+			description: "succeeds selecting the devices matching the filter",
+			requiredMocks: func() {
+				mock.On("DeviceList", tenantCtx, models.DeviceStatus(""), query.Paginator{Page: 1, PerPage: DeviceBulkMax}, query.Filters{}, *query.NewSorter(), store.DeviceAcceptableIfNotAccepted).
+					Return([]models.Device{{UID: "a", TenantID: "tenant"}}, 1, nil).Once()
+			},
+			expected: Expected{[]models.Device{{UID: "a", TenantID: "tenant"}}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			devices, err := service.SelectDevices(ctx, "tenant", tc.uids, query.Filters{})
+			assert.Equal(t, tc.expected, Expected{devices, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
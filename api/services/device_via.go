@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceVia contains the service's function to manage the devices reached through other devices.
+type DeviceVia interface {
+	SetDeviceVia(ctx context.Context, tenant string, uid models.UID, via models.DeviceVia) error
+	UnsetDeviceVia(ctx context.Context, tenant string, uid models.UID) error
+}
+
+const (
+	// DeviceMaxHops is the maximum number of devices the connections jump through to reach a device.
+	DeviceMaxHops = 4
+	// DeviceViaDefaultPort is the SSH port used when the device's via has no port.
+	DeviceViaDefaultPort = 22
+)
+
+// SetDeviceVia sets the device which the connections jump from to reach a device.
+//
+// If the device or the device to jump from does not exist, a NewErrDeviceNotFound error will be returned.
+// If the device would be reached through itself or through more than DeviceMaxHops devices, a NewErrDeviceViaInvalid
+// error will be returned.
+func (s *service) SetDeviceVia(ctx context.Context, tenant string, uid models.UID, via models.DeviceVia) error {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	// NOTICE: The chain of devices is walked from the device to jump from, until the one reached by its agent, to
+	// avoid loops and limit the number of hops.
+	for hops, next := 1, via.Device; next != ""; hops++ {
+		if next == string(uid) || hops > DeviceMaxHops {
+			return NewErrDeviceViaInvalid(map[string]interface{}{"device": via.Device}, nil)
+		}
+
+		device, err := s.store.DeviceGetByUID(ctx, models.UID(next), tenant)
+		if err != nil {
+			return NewErrDeviceNotFound(models.UID(next), err)
+		}
+
+		next = ""
+		if device.Via != nil {
+			next = device.Via.Device
+		}
+	}
+
+	if via.Port == 0 {
+		via.Port = DeviceViaDefaultPort
+	}
+
+	return s.store.DeviceSetVia(ctx, uid, &via)
+}
+
+// UnsetDeviceVia makes the device reached by its own agent again.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) UnsetDeviceVia(ctx context.Context, tenant string, uid models.UID) error {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.store.DeviceSetVia(ctx, uid, nil)
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDeviceVia(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		via           models.DeviceVia
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			via:         models.DeviceVia{Device: "gateway", Host: "192.168.0.10"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device to jump from is not found",
+			via:         models.DeviceVia{Device: "gateway", Host: "192.168.0.10"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("gateway"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device would be reached through itself",
+			via:         models.DeviceVia{Device: "gateway", Host: "192.168.0.10"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").
+					Return(&models.Device{UID: "gateway", Via: &models.DeviceVia{Device: "uid", Host: "192.168.0.20", Port: 22}}, nil).Once()
+			},
+			expected: NewErrDeviceViaInvalid(map[string]interface{}{"device": "gateway"}, nil),
+		},
+		{
+			description: "fails when the device would be reached through too many devices",
+			via:         models.DeviceVia{Device: "hop-1", Host: "192.168.0.10"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				for i := 1; i <= DeviceMaxHops; i++ {
+					hop := "hop-" + string(rune('0'+i))
+					next := "hop-" + string(rune('0'+i+1))
+
+					mock.On("DeviceGetByUID", ctx, models.UID(hop), "tenant").
+						Return(&models.Device{UID: hop, Via: &models.DeviceVia{Device: next, Host: "192.168.0.10", Port: 22}}, nil).Once()
+				}
+			},
+			expected: NewErrDeviceViaInvalid(map[string]interface{}{"device": "hop-1"}, nil),
+		},
+		{
+			description: "succeeds when the via is set with the default port",
+			via:         models.DeviceVia{Device: "gateway", Host: "192.168.0.10"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("gateway"), "tenant").Return(&models.Device{UID: "gateway"}, nil).Once()
+				mock.On("DeviceSetVia", ctx, models.UID("uid"), &models.DeviceVia{Device: "gateway", Host: "192.168.0.10", Port: DeviceViaDefaultPort}).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.SetDeviceVia(ctx, "tenant", models.UID("uid"), tc.via))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUnsetDeviceVia(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds when the via is unset",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Via: &models.DeviceVia{Device: "gateway", Host: "192.168.0.10", Port: 22}}, nil).Once()
+				mock.On("DeviceSetVia", ctx, models.UID("uid"), (*models.DeviceVia)(nil)).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.UnsetDeviceVia(ctx, "tenant", models.UID("uid")))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -126,6 +126,24 @@ var (
 	ErrSameTags                     = errors.New("trying to update tags with the same content", ErrLayer, ErrCodeNoContentChange)
 	ErrAPIKeyNotFound               = errors.New("APIKey not found", ErrLayer, ErrCodeNotFound)
 	ErrAPIKeyDuplicated             = errors.New("APIKey duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrFirewallRuleNotFound         = errors.New("firewall rule not found", ErrLayer, ErrCodeNotFound)
+	ErrFirewallRuleInvalid          = errors.New("firewall rule invalid", ErrLayer, ErrCodeInvalid)
+	ErrWebhookNotFound              = errors.New("webhook not found", ErrLayer, ErrCodeNotFound)
+	ErrAutoAcceptRuleNotFound       = errors.New("auto-accept rule not found", ErrLayer, ErrCodeNotFound)
+	ErrAutoAcceptRuleInvalid        = errors.New("auto-accept rule invalid", ErrLayer, ErrCodeInvalid)
+	ErrForwardingRuleNotFound       = errors.New("forwarding rule not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceBulkLimit              = errors.New("too many devices selected for the bulk operation", ErrLayer, ErrCodeInvalid)
+	ErrMaxDeviceAttributesReached   = errors.New("device attribute limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceAttributeNotFound      = errors.New("device attribute not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupNotFound          = errors.New("device group not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupDuplicated        = errors.New("device group duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceGroupInvalid           = errors.New("device group invalid", ErrLayer, ErrCodeInvalid)
+	ErrCertAuthorityNotFound        = errors.New("certificate authority not found", ErrLayer, ErrCodeNotFound)
+	ErrCertAuthorityDuplicated      = errors.New("certificate authority duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrCertAuthorityInvalid         = errors.New("certificate authority invalid", ErrLayer, ErrCodeInvalid)
+	ErrSessionClosed                = errors.New("session closed", ErrLayer, ErrCodeInvalid)
+	ErrDeviceViaInvalid             = errors.New("device via invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceCredentialsInvalid     = errors.New("device credentials invalid", ErrLayer, ErrCodeInvalid)
 )
 
 // NewErrNotFound returns an error with the ErrDataNotFound and wrap an error.
@@ -191,6 +209,66 @@ func NewErrAPIKeyDuplicated(next error) error {
 	return NewErrDuplicated(ErrAPIKeyDuplicated, nil, next)
 }
 
+// NewErrFirewallRuleNotFound returns an error when the firewall rule is not found.
+func NewErrFirewallRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrFirewallRuleNotFound, id, next)
+}
+
+// NewErrWebhookNotFound returns an error when the webhook is not found.
+func NewErrWebhookNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookNotFound, id, next)
+}
+
+// NewErrAutoAcceptRuleNotFound returns an error when the auto-accept rule is not found.
+func NewErrAutoAcceptRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAutoAcceptRuleNotFound, id, next)
+}
+
+// NewErrAutoAcceptRuleInvalid returns an error when the auto-accept rule is invalid.
+func NewErrAutoAcceptRuleInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrAutoAcceptRuleInvalid, data, next)
+}
+
+// NewErrForwardingRuleNotFound returns an error when the forwarding rule is not found.
+func NewErrForwardingRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrForwardingRuleNotFound, id, next)
+}
+
+// NewErrCertAuthorityNotFound returns an error when the certificate authority is not found.
+func NewErrCertAuthorityNotFound(fingerprint string, next error) error {
+	return NewErrNotFound(ErrCertAuthorityNotFound, fingerprint, next)
+}
+
+// NewErrCertAuthorityDuplicated returns an error when the namespace already trusts the certificate authority.
+func NewErrCertAuthorityDuplicated(values []string, next error) error {
+	return NewErrDuplicated(ErrCertAuthorityDuplicated, values, next)
+}
+
+// NewErrCertAuthorityInvalid returns an error when the certificate authority's public key is invalid.
+func NewErrCertAuthorityInvalid(value []byte, next error) error {
+	return NewErrInvalid(ErrCertAuthorityInvalid, map[string]interface{}{"data": value}, next)
+}
+
+// NewErrSessionClosed returns an error when the session is already closed.
+func NewErrSessionClosed(id models.UID, next error) error {
+	return NewErrInvalid(ErrSessionClosed, map[string]interface{}{"uid": string(id)}, next)
+}
+
+// NewErrDeviceViaInvalid returns an error when the device cannot be reached through the device set as its via.
+func NewErrDeviceViaInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceViaInvalid, data, next)
+}
+
+// NewErrDeviceCredentialsInvalid returns an error when the credentials stored for an agentless target are invalid.
+func NewErrDeviceCredentialsInvalid(next error) error {
+	return NewErrInvalid(ErrDeviceCredentialsInvalid, nil, next)
+}
+
+// NewErrFirewallRuleInvalid returns an error when the firewall rule is invalid.
+func NewErrFirewallRuleInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrFirewallRuleInvalid, data, next)
+}
+
 // NewErrTagInvalid returns an error when the tag is invalid.
 func NewErrTagInvalid(tag string, next error) error {
 	return NewErrInvalid(ErrTagInvalid, map[string]interface{}{"name": tag}, next)
@@ -256,6 +334,36 @@ func NewErrPublicKeyInvalid(data map[string]interface{}, next error) error {
 	return NewErrInvalid(ErrPublicKeyInvalid, data, next)
 }
 
+// NewErrDeviceBulkLimit returns an error when a bulk operation selects more devices than the limit.
+func NewErrDeviceBulkLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceBulkLimit, limit, next)
+}
+
+// NewErrDeviceAttributeLimit returns an error when the device attribute limit is reached.
+func NewErrDeviceAttributeLimit(limit int, next error) error {
+	return NewErrLimit(ErrMaxDeviceAttributesReached, limit, next)
+}
+
+// NewErrDeviceAttributeNotFound returns an error when the device attribute is not found.
+func NewErrDeviceAttributeNotFound(key string, next error) error {
+	return NewErrNotFound(ErrDeviceAttributeNotFound, key, next)
+}
+
+// NewErrDeviceGroupNotFound returns an error when the device group is not found.
+func NewErrDeviceGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceGroupNotFound, id, next)
+}
+
+// NewErrDeviceGroupDuplicated returns an error when the device group's name is already used in the namespace.
+func NewErrDeviceGroupDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrDeviceGroupDuplicated, []string{name}, next)
+}
+
+// NewErrDeviceGroupInvalid returns an error when the device group is invalid.
+func NewErrDeviceGroupInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceGroupInvalid, data, next)
+}
+
 // NewErrTagLimit returns an error when the tag limit is reached.
 func NewErrTagLimit(limit int, next error) error {
 	return NewErrLimit(ErrMaxTagReached, limit, next)
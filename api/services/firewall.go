@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type FirewallService interface {
+	ListFirewallRules(ctx context.Context, paginator query.Paginator) ([]models.FirewallRule, int, error)
+	GetFirewallRule(ctx context.Context, tenant, id string) (*models.FirewallRule, error)
+	CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error)
+	UpdateFirewallRule(ctx context.Context, tenant, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error)
+	ReorderFirewallRules(ctx context.Context, tenant string, ids []string) error
+	DeleteFirewallRule(ctx context.Context, tenant, id string) error
+	// EvaluateFirewall evaluates the namespace's firewall rules, in priority order, against a connection attempt. The
+	// first active rule matching the source IP, the username and the device's hostname or tags decides whether the
+	// connection is allowed. When no rule matches, the connection is allowed.
+	EvaluateFirewall(ctx context.Context, req requests.FirewallEvaluate) (bool, error)
+}
+
+func (s *service) ListFirewallRules(ctx context.Context, paginator query.Paginator) ([]models.FirewallRule, int, error) {
+	return s.store.FirewallRuleList(ctx, paginator)
+}
+
+func (s *service) GetFirewallRule(ctx context.Context, tenant, id string) (*models.FirewallRule, error) {
+	rule, err := s.store.FirewallRuleGet(ctx, id)
+	if err != nil || rule.TenantID != tenant {
+		return nil, NewErrFirewallRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+func (s *service) CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error) {
+	if err := s.checkFirewallFilterTags(ctx, tenant, req.Filter.Tags); err != nil {
+		return nil, err
+	}
+
+	if req.Filter.Group != "" {
+		if _, err := s.GetDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	rule := &models.FirewallRule{
+		TenantID:           tenant,
+		FirewallRuleFields: firewallRuleFieldsFromRequest(req.FirewallRuleFields),
+	}
+
+	if err := s.store.FirewallRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) UpdateFirewallRule(ctx context.Context, tenant, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	if _, err := s.GetFirewallRule(ctx, tenant, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFirewallFilterTags(ctx, tenant, req.Filter.Tags); err != nil {
+		return nil, err
+	}
+
+	if req.Filter.Group != "" {
+		if _, err := s.GetDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.store.FirewallRuleUpdate(ctx, id, models.FirewallRuleUpdate{
+		FirewallRuleFields: firewallRuleFieldsFromRequest(req.FirewallRuleFields),
+	})
+}
+
+// ReorderFirewallRules sets the priority of the firewall rules according to their position in ids, starting from 1.
+func (s *service) ReorderFirewallRules(ctx context.Context, tenant string, ids []string) error {
+	rules := make([]*models.FirewallRule, 0, len(ids))
+	for _, id := range ids {
+		rule, err := s.GetFirewallRule(ctx, tenant, id)
+		if err != nil {
+			return err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	for i, rule := range rules {
+		if rule.Priority == i+1 {
+			continue
+		}
+
+		rule.Priority = i + 1
+		if _, err := s.store.FirewallRuleUpdate(ctx, rule.ID, models.FirewallRuleUpdate{FirewallRuleFields: rule.FirewallRuleFields}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) DeleteFirewallRule(ctx context.Context, tenant, id string) error {
+	if _, err := s.GetFirewallRule(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	return s.store.FirewallRuleDelete(ctx, id)
+}
+
+func (s *service) EvaluateFirewall(ctx context.Context, req requests.FirewallEvaluate) (bool, error) {
+	device, err := s.store.DeviceLookup(ctx, req.Domain, req.Name)
+	if err != nil {
+		return false, NewErrDeviceLookupNotFound(req.Domain, req.Name, err)
+	}
+
+	// The rules list is scoped to the tenant within the context, so it must contain the device's tenant.
+	ctx = context.WithValue(ctx, "tenant", device.TenantID) //nolint:revive
+
+	rules, _, err := s.store.FirewallRuleList(ctx, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return false, err
+	}
+
+	// The namespace's device groups are loaded once, when the first rule filtering by a group is evaluated.
+	var groups []models.DeviceGroup
+	var loaded bool
+	loadGroups := func() ([]models.DeviceGroup, error) {
+		if !loaded {
+			list, _, err := s.store.DeviceGroupList(ctx, device.TenantID, query.Paginator{Page: -1, PerPage: -1})
+			if err != nil {
+				return nil, err
+			}
+
+			groups, loaded = list, true
+		}
+
+		return groups, nil
+	}
+
+	for _, rule := range rules {
+		if !rule.Active || rule.TenantID != device.TenantID {
+			continue
+		}
+
+		ok, err := evaluateFirewallRule(&rule, device, req, loadGroups)
+		if err != nil {
+			return false, NewErrFirewallRuleInvalid(map[string]interface{}{"id": rule.ID}, err)
+		}
+
+		if ok {
+			return rule.Action == models.FirewallRuleActionAllow, nil
+		}
+	}
+
+	return true, nil
+}
+
+// evaluateFirewallRule checks if a firewall rule matches the connection's source IP, username and device. The groups
+// function returns the namespace's device groups, for the rules filtering by a group.
+func evaluateFirewallRule(rule *models.FirewallRule, device *models.Device, req requests.FirewallEvaluate, groups func() ([]models.DeviceGroup, error)) (bool, error) {
+	if ok, err := matchFirewallPattern(rule.SourceIP, req.IPAddress); err != nil || !ok {
+		return false, err
+	}
+
+	if ok, err := matchFirewallPattern(rule.Username, req.Username); err != nil || !ok {
+		return false, err
+	}
+
+	switch {
+	case rule.Filter.Hostname != "":
+		return regexp.MatchString(rule.Filter.Hostname, device.Name)
+	case len(rule.Filter.Tags) > 0:
+		for _, tag := range device.Tags {
+			if contains(rule.Filter.Tags, tag) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case len(rule.Filter.Attributes) > 0:
+		return hasAttributes(device, rule.Filter.Attributes), nil
+	case rule.Filter.Group != "":
+		list, err := groups()
+		if err != nil {
+			return false, err
+		}
+
+		return hasDeviceGroupMember(list, rule.Filter.Group, device), nil
+	}
+
+	return true, nil
+}
+
+// matchFirewallPattern checks if the whole value matches the rule's pattern, so a rule for 10.0.0.1 doesn't match
+// 110.0.0.15 nor a rule for root match notroot.
+func matchFirewallPattern(pattern, value string) (bool, error) {
+	return regexp.MatchString("^(?:"+pattern+")$", value)
+}
+
+// checkFirewallFilterTags checks if all tags used on a firewall filter exist in the namespace.
+func (s *service) checkFirewallFilterTags(ctx context.Context, tenant string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	existing, _, err := s.store.TagsGet(ctx, tenant)
+	if err != nil {
+		return NewErrTagEmpty(tenant, err)
+	}
+
+	for _, tag := range tags {
+		if !contains(existing, tag) {
+			return NewErrTagNotFound(tag, nil)
+		}
+	}
+
+	return nil
+}
+
+func firewallRuleFieldsFromRequest(fields requests.FirewallRuleFields) models.FirewallRuleFields {
+	return models.FirewallRuleFields{
+		Priority: fields.Priority,
+		Action:   fields.Action,
+		Active:   fields.Active,
+		SourceIP: fields.SourceIP,
+		Username: fields.Username,
+		Filter: models.FirewallFilter{
+			Hostname:   fields.Filter.Hostname,
+			Tags:       fields.Filter.Tags,
+			Attributes: fields.Filter.Attributes,
+			Group:      fields.Filter.Group,
+		},
+	}
+}
@@ -0,0 +1,532 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestGetFirewallRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	type Expected struct {
+		rule *models.FirewallRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the firewall rule is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "6504b7bd9b6c4a63a9ccc053",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "6504b7bd9b6c4a63a9ccc053").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("6504b7bd9b6c4a63a9ccc053", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the firewall rule belongs to another namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "6504b7bd9b6c4a63a9ccc053",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "6504b7bd9b6c4a63a9ccc053").
+					Return(&models.FirewallRule{ID: "6504b7bd9b6c4a63a9ccc053", TenantID: "another"}, nil).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("6504b7bd9b6c4a63a9ccc053", nil)},
+		},
+		{
+			description: "succeeds",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "6504b7bd9b6c4a63a9ccc053",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "6504b7bd9b6c4a63a9ccc053").
+					Return(&models.FirewallRule{ID: "6504b7bd9b6c4a63a9ccc053", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+			},
+			expected: Expected{&models.FirewallRule{ID: "6504b7bd9b6c4a63a9ccc053", TenantID: "00000000-0000-4000-0000-000000000000"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			rule, err := s.GetFirewallRule(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateFirewallRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	type Expected struct {
+		rule *models.FirewallRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		req           requests.FirewallRuleCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when a filter tag does not exist",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			req: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "allow",
+					SourceIP: ".*",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Tags: []string{"production"}},
+				},
+			},
+			requiredMocks: func() {
+				mock.On("TagsGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]string{"development"}, 1, nil).Once()
+			},
+			expected: Expected{nil, NewErrTagNotFound("production", nil)},
+		},
+		{
+			description: "fails when the store fails",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			req: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Action:   "allow",
+					SourceIP: ".*",
+					Username: ".*",
+					Filter:   requests.FirewallFilter{Hostname: ".*"},
+				},
+			},
+			requiredMocks: func() {
+				mock.On("FirewallRuleCreate", ctx, gomock.AnythingOfType("*models.FirewallRule")).
+					Return(errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			req: requests.FirewallRuleCreate{
+				FirewallRuleFields: requests.FirewallRuleFields{
+					Priority: 1,
+					Action:   "deny",
+					Active:   true,
+					SourceIP: ".*",
+					Username: "root",
+					Filter:   requests.FirewallFilter{Tags: []string{"production"}},
+				},
+			},
+			requiredMocks: func() {
+				mock.On("TagsGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]string{"production"}, 1, nil).Once()
+				mock.On("FirewallRuleCreate", ctx, &models.FirewallRule{
+					TenantID: "00000000-0000-4000-0000-000000000000",
+					FirewallRuleFields: models.FirewallRuleFields{
+						Priority: 1,
+						Action:   "deny",
+						Active:   true,
+						SourceIP: ".*",
+						Username: "root",
+						Filter:   models.FirewallFilter{Tags: []string{"production"}},
+					},
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.FirewallRule{
+					TenantID: "00000000-0000-4000-0000-000000000000",
+					FirewallRuleFields: models.FirewallRuleFields{
+						Priority: 1,
+						Action:   "deny",
+						Active:   true,
+						SourceIP: ".*",
+						Username: "root",
+						Filter:   models.FirewallFilter{Tags: []string{"production"}},
+					},
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			rule, err := s.CreateFirewallRule(ctx, tc.tenant, tc.req)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestReorderFirewallRules(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	cases := []struct {
+		description   string
+		tenant        string
+		ids           []string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when a firewall rule is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			ids:         []string{"6504b7bd9b6c4a63a9ccc053"},
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "6504b7bd9b6c4a63a9ccc053").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrFirewallRuleNotFound("6504b7bd9b6c4a63a9ccc053", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds updating only the rules whose priority changed",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			ids:         []string{"e92f4a5d3e1a4f7b8b2b6e9a", "6504b7bd9b6c4a63a9ccc053"},
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "e92f4a5d3e1a4f7b8b2b6e9a").
+					Return(&models.FirewallRule{
+						ID:                 "e92f4a5d3e1a4f7b8b2b6e9a",
+						TenantID:           "00000000-0000-4000-0000-000000000000",
+						FirewallRuleFields: models.FirewallRuleFields{Priority: 1},
+					}, nil).Once()
+				mock.On("FirewallRuleGet", ctx, "6504b7bd9b6c4a63a9ccc053").
+					Return(&models.FirewallRule{
+						ID:                 "6504b7bd9b6c4a63a9ccc053",
+						TenantID:           "00000000-0000-4000-0000-000000000000",
+						FirewallRuleFields: models.FirewallRuleFields{Priority: 1},
+					}, nil).Once()
+				mock.On("FirewallRuleUpdate", ctx, "6504b7bd9b6c4a63a9ccc053", models.FirewallRuleUpdate{
+					FirewallRuleFields: models.FirewallRuleFields{Priority: 2},
+				}).Return(&models.FirewallRule{}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.ReorderFirewallRules(ctx, tc.tenant, tc.ids)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateFirewall(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	device := &models.Device{
+		UID:      "uid",
+		Name:     "device",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Tags:     []string{"production"},
+	}
+
+	req := requests.FirewallEvaluate{
+		Domain:    "namespace",
+		Name:      "device",
+		Username:  "root",
+		IPAddress: "192.168.1.10",
+	}
+
+	type Expected struct {
+		ok  bool
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{false, NewErrDeviceLookupNotFound("namespace", "device", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds allowing when no rule matches",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: "10.0.0.*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+					}, 1, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "succeeds skipping inactive rules",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "deny",
+								Active:   false,
+								SourceIP: ".*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+					}, 1, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "succeeds denying when the first matching rule denies",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: "192.168.1.*",
+								Username: "root",
+								Filter:   models.FirewallFilter{Tags: []string{"production"}},
+							},
+						},
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 2,
+								Action:   "allow",
+								Active:   true,
+								SourceIP: ".*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+					}, 2, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "succeeds allowing when the first matching rule allows",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "allow",
+								Active:   true,
+								SourceIP: ".*",
+								Username: "root",
+								Filter:   models.FirewallFilter{Hostname: "^dev"},
+							},
+						},
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 2,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: ".*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+					}, 2, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "succeeds allowing when the rule's source IP and username only match partially",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: "192.168.1.1",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 2,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: ".*",
+								Username: "roo",
+								Filter:   models.FirewallFilter{Hostname: ".*"},
+							},
+						},
+					}, 2, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "succeeds loading the device groups once for every rule filtering by a group",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleList", gomock.Anything, query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.FirewallRule{
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 1,
+								Action:   "allow",
+								Active:   true,
+								SourceIP: ".*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Group: "europe"},
+							},
+						},
+						{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							FirewallRuleFields: models.FirewallRuleFields{
+								Priority: 2,
+								Action:   "deny",
+								Active:   true,
+								SourceIP: ".*",
+								Username: ".*",
+								Filter:   models.FirewallFilter{Group: "portugal"},
+							},
+						},
+					}, 2, nil).Once()
+				mock.On("DeviceGroupList", gomock.Anything, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{
+						{ID: "europe"},
+						{ID: "portugal", DeviceGroupFields: models.DeviceGroupFields{Devices: []string{"uid"}}},
+					}, 2, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			ok, err := s.EvaluateFirewall(ctx, req)
+			assert.Equal(t, tc.expected, Expected{ok, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestMatchFirewallPattern(t *testing.T) {
+	cases := []struct {
+		description string
+		pattern     string
+		value       string
+		expected    bool
+	}{
+		{
+			description: "matches the same IP",
+			pattern:     "10.0.0.1",
+			value:       "10.0.0.1",
+			expected:    true,
+		},
+		{
+			description: "does not match an IP containing the pattern",
+			pattern:     "10.0.0.1",
+			value:       "110.0.0.15",
+			expected:    false,
+		},
+		{
+			description: "does not match an IP prefixed by the pattern",
+			pattern:     "10.0.0.1",
+			value:       "10.0.0.100",
+			expected:    false,
+		},
+		{
+			description: "matches an IP in the pattern's range",
+			pattern:     "10.0.0.*",
+			value:       "10.0.0.100",
+			expected:    true,
+		},
+		{
+			description: "does not match a username suffixed by the pattern",
+			pattern:     "root",
+			value:       "notroot",
+			expected:    false,
+		},
+		{
+			description: "matches any of the pattern's alternatives",
+			pattern:     "root|admin",
+			value:       "admin",
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ok, err := matchFirewallPattern(tc.pattern, tc.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
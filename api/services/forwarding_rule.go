@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"net"
+	"regexp"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type ForwardingRuleService interface {
+	// ListForwardingRules lists the namespace's forwarding rules in evaluation order.
+	ListForwardingRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error)
+	GetForwardingRule(ctx context.Context, tenant, id string) (*models.ForwardingRule, error)
+	CreateForwardingRule(ctx context.Context, tenant string, req requests.ForwardingRuleCreate) (*models.ForwardingRule, error)
+	UpdateForwardingRule(ctx context.Context, tenant, id string, req requests.ForwardingRuleUpdate) (*models.ForwardingRule, error)
+	DeleteForwardingRule(ctx context.Context, tenant, id string) error
+	// EvaluateForwarding evaluates the namespace's forwarding rules, in priority order, against a local port forwarding
+	// request. The first active rule matching the destination, the username and the public key decides whether the
+	// forwarding is allowed. When no rule matches, the forwarding is allowed.
+	EvaluateForwarding(ctx context.Context, req requests.ForwardingEvaluate) (bool, error)
+	// EvaluateReverseForwarding checks if the device's namespace allows its devices to accept remote port forwarding
+	// requests.
+	EvaluateReverseForwarding(ctx context.Context, uid models.UID) (bool, error)
+}
+
+func (s *service) ListForwardingRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error) {
+	return s.store.ForwardingRuleList(ctx, tenant, paginator)
+}
+
+func (s *service) GetForwardingRule(ctx context.Context, tenant, id string) (*models.ForwardingRule, error) {
+	rule, err := s.store.ForwardingRuleGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrForwardingRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+func (s *service) CreateForwardingRule(ctx context.Context, tenant string, req requests.ForwardingRuleCreate) (*models.ForwardingRule, error) {
+	now := clock.Now()
+	rule := &models.ForwardingRule{
+		TenantID:             tenant,
+		ForwardingRuleFields: forwardingRuleFieldsFromRequest(req.ForwardingRuleFields),
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	if err := s.store.ForwardingRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) UpdateForwardingRule(ctx context.Context, tenant, id string, req requests.ForwardingRuleUpdate) (*models.ForwardingRule, error) {
+	fields := forwardingRuleFieldsFromRequest(req.ForwardingRuleFields)
+
+	rule, err := s.store.ForwardingRuleUpdate(ctx, tenant, id, &fields)
+	if err != nil {
+		return nil, NewErrForwardingRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+func (s *service) DeleteForwardingRule(ctx context.Context, tenant, id string) error {
+	if err := s.store.ForwardingRuleDelete(ctx, tenant, id); err != nil {
+		return NewErrForwardingRuleNotFound(id, err)
+	}
+
+	return nil
+}
+
+func (s *service) EvaluateForwarding(ctx context.Context, req requests.ForwardingEvaluate) (bool, error) {
+	rules, _, err := s.store.ForwardingRuleList(ctx, req.TenantID, query.Paginator{Page: -1, PerPage: -1})
+	if err != nil {
+		return false, err
+	}
+
+	for _, rule := range rules {
+		if rule.Active && matchForwardingRule(&rule, req) {
+			return rule.Action == models.ForwardingRuleActionAllow, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *service) EvaluateReverseForwarding(ctx context.Context, uid models.UID) (bool, error) {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return false, NewErrDeviceNotFound(uid, err)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil {
+		return false, NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	return namespace.Settings != nil && namespace.Settings.ReversePortForwarding, nil
+}
+
+// matchForwardingRule checks if a forwarding rule matches the forwarding's destination and the connection requesting
+// it.
+func matchForwardingRule(rule *models.ForwardingRule, req requests.ForwardingEvaluate) bool {
+	if req.Port < rule.Ports.Start || req.Port > rule.Ports.End {
+		return false
+	}
+
+	_, network, err := net.ParseCIDR(rule.Host)
+	if err != nil {
+		return false
+	}
+
+	host := req.Host
+	if host == "localhost" {
+		host = "127.0.0.1"
+	}
+
+	// The device resolves the hostnames, so a hostname is allowed only by a network containing every address, but it's
+	// denied by any network, as it may resolve to an address inside it.
+	if ip := net.ParseIP(host); ip != nil {
+		if !network.Contains(ip) {
+			return false
+		}
+	} else if ones, _ := network.Mask.Size(); ones != 0 && rule.Action != models.ForwardingRuleActionDeny {
+		return false
+	}
+
+	if rule.Filter.Username != "" {
+		if ok, err := regexp.MatchString("^(?:"+rule.Filter.Username+")$", req.Username); err != nil || !ok {
+			return false
+		}
+	}
+
+	if rule.Filter.Fingerprint != "" && rule.Filter.Fingerprint != req.Fingerprint {
+		return false
+	}
+
+	return true
+}
+
+func forwardingRuleFieldsFromRequest(fields requests.ForwardingRuleFields) models.ForwardingRuleFields {
+	return models.ForwardingRuleFields{
+		Priority: fields.Priority,
+		Action:   models.ForwardingRuleAction(fields.Action),
+		Active:   fields.Active,
+		Host:     fields.Host,
+		Ports: models.ForwardingPortRange{
+			Start: fields.Ports.Start,
+			End:   fields.Ports.End,
+		},
+		Filter: models.ForwardingFilter{
+			Username:    fields.Filter.Username,
+			Fingerprint: fields.Filter.Fingerprint,
+		},
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchForwardingRule(t *testing.T) {
+	req := requests.ForwardingEvaluate{
+		TenantID:    "tenant",
+		Username:    "root",
+		Fingerprint: "fingerprint",
+		Host:        "10.0.0.1",
+		Port:        5432,
+	}
+
+	cases := []struct {
+		description string
+		rule        models.ForwardingRuleFields
+		host        string
+		expected    bool
+	}{
+		{
+			description: "matches when the destination is in the CIDR and in the port range",
+			rule: models.ForwardingRuleFields{
+				Host:  "10.0.0.0/8",
+				Ports: models.ForwardingPortRange{Start: 5000, End: 6000},
+			},
+			host:     "10.0.0.1",
+			expected: true,
+		},
+		{
+			description: "does not match when the destination is out of the CIDR",
+			rule: models.ForwardingRuleFields{
+				Host:  "192.168.0.0/16",
+				Ports: models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "10.0.0.1",
+			expected: false,
+		},
+		{
+			description: "does not match when the port is out of the range",
+			rule: models.ForwardingRuleFields{
+				Host:  "10.0.0.0/8",
+				Ports: models.ForwardingPortRange{Start: 22, End: 22},
+			},
+			host:     "10.0.0.1",
+			expected: false,
+		},
+		{
+			description: "matches localhost as the loopback address",
+			rule: models.ForwardingRuleFields{
+				Host:  "127.0.0.0/8",
+				Ports: models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "localhost",
+			expected: true,
+		},
+		{
+			description: "does not match a hostname when the CIDR does not contain every address",
+			rule: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionAllow,
+				Host:   "10.0.0.0/8",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "db.internal",
+			expected: false,
+		},
+		{
+			description: "matches a hostname when the rule denies any CIDR",
+			rule: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionDeny,
+				Host:   "10.0.0.0/8",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "intranet.corp",
+			expected: true,
+		},
+		{
+			description: "matches a loopback hostname when the rule denies the loopback CIDR",
+			rule: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionDeny,
+				Host:   "127.0.0.0/8",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "localhost.localdomain",
+			expected: true,
+		},
+		{
+			description: "matches a hostname when the CIDR contains every address",
+			rule: models.ForwardingRuleFields{
+				Host:  "0.0.0.0/0",
+				Ports: models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+			host:     "db.internal",
+			expected: true,
+		},
+		{
+			description: "does not match when the username does not match the regexp",
+			rule: models.ForwardingRuleFields{
+				Host:   "0.0.0.0/0",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+				Filter: models.ForwardingFilter{Username: "^admin$"},
+			},
+			host:     "10.0.0.1",
+			expected: false,
+		},
+		{
+			description: "does not match when the regexp matches only part of the username",
+			rule: models.ForwardingRuleFields{
+				Host:   "0.0.0.0/0",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+				Filter: models.ForwardingFilter{Username: "ro"},
+			},
+			host:     "10.0.0.1",
+			expected: false,
+		},
+		{
+			description: "does not match when the public key differs",
+			rule: models.ForwardingRuleFields{
+				Host:   "0.0.0.0/0",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+				Filter: models.ForwardingFilter{Fingerprint: "other"},
+			},
+			host:     "10.0.0.1",
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req.Host = tc.host
+			assert.Equal(t, tc.expected, matchForwardingRule(&models.ForwardingRule{ForwardingRuleFields: tc.rule}, req))
+		})
+	}
+}
+
+func TestEvaluateForwarding(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	rules := []models.ForwardingRule{
+		{
+			ID: "inactive",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionDeny,
+				Active: false,
+				Host:   "0.0.0.0/0",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+		},
+		{
+			ID: "allow",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionAllow,
+				Active: true,
+				Host:   "10.0.0.0/8",
+				Ports:  models.ForwardingPortRange{Start: 443, End: 443},
+			},
+		},
+		{
+			ID: "deny",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Action: models.ForwardingRuleActionDeny,
+				Active: true,
+				Host:   "10.0.0.0/8",
+				Ports:  models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		host          string
+		port          uint32
+		requiredMocks func()
+		expected      bool
+	}{
+		{
+			description: "allows when the first matching rule allows it",
+			host:        "10.0.0.1",
+			port:        443,
+			requiredMocks: func() {
+				mock.On("ForwardingRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(rules, 3, nil).Once()
+			},
+			expected: true,
+		},
+		{
+			description: "denies when the first matching rule denies it",
+			host:        "10.0.0.1",
+			port:        22,
+			requiredMocks: func() {
+				mock.On("ForwardingRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(rules, 3, nil).Once()
+			},
+			expected: false,
+		},
+		{
+			description: "denies a hostname when a rule denies any CIDR",
+			host:        "intranet.corp",
+			port:        22,
+			requiredMocks: func() {
+				mock.On("ForwardingRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(rules, 3, nil).Once()
+			},
+			expected: false,
+		},
+		{
+			description: "allows when no active rule matches",
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func() {
+				mock.On("ForwardingRuleList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).Return(rules, 3, nil).Once()
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			ok, err := service.EvaluateForwarding(ctx, requests.ForwardingEvaluate{
+				TenantID: "tenant",
+				Username: "root",
+				Host:     tc.host,
+				Port:     tc.port,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateReverseForwarding(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      bool
+		expectedErr   error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, errors.New("error")).Once()
+			},
+			expected:    false,
+			expectedErr: NewErrDeviceNotFound(models.UID("uid"), errors.New("error")),
+		},
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, errors.New("error")).Once()
+			},
+			expected:    false,
+			expectedErr: NewErrNamespaceNotFound("tenant", errors.New("error")),
+		},
+		{
+			description: "denies when the namespace doesn't allow the reverse port forwarding",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Settings: &models.NamespaceSettings{ReversePortForwarding: false},
+				}, nil).Once()
+			},
+			expected: false,
+		},
+		{
+			description: "allows when the namespace allows the reverse port forwarding",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Settings: &models.NamespaceSettings{ReversePortForwarding: true},
+				}, nil).Once()
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			ok, err := service.EvaluateReverseForwarding(ctx, models.UID("uid"))
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
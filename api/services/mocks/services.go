@@ -286,9 +286,9 @@ func (_m *Service) AuthUncacheToken(ctx context.Context, tenant string, id strin
 	return r0
 }
 
-// AuthUser provides a mock function with given fields: ctx, model
-func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*models.UserAuthResponse, error) {
-	ret := _m.Called(ctx, model)
+// AuthUser provides a mock function with given fields: ctx, req
+func (_m *Service) AuthUser(ctx context.Context, req *requests.UserAuth) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthUser")
@@ -297,10 +297,10 @@ func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*mod
 	var r0 *models.UserAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAuth) (*models.UserAuthResponse, error)); ok {
-		return rf(ctx, model)
+		return rf(ctx, req)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAuth) *models.UserAuthResponse); ok {
-		r0 = rf(ctx, model)
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserAuthResponse)
@@ -308,7 +308,7 @@ func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*mod
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserAuth) error); ok {
-		r1 = rf(ctx, model)
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -392,6 +392,24 @@ func (_m *Service) BillingReport(_a0 internalclient.Client, _a1 string, _a2 stri
 	return r0
 }
 
+// CloseSession provides a mock function with given fields: ctx, uid, user
+func (_m *Service) CloseSession(ctx context.Context, uid models.UID, user string) error {
+	ret := _m.Called(ctx, uid, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateAPIKey provides a mock function with given fields: ctx, userID, tenant, key, req
 func (_m *Service) CreateAPIKey(ctx context.Context, userID string, tenant string, key string, req *requests.CreateAPIKey) (string, error) {
 	ret := _m.Called(ctx, userID, tenant, key, req)
@@ -420,6 +438,96 @@ func (_m *Service) CreateAPIKey(ctx context.Context, userID string, tenant strin
 	return r0, r1
 }
 
+// CreateAutoAcceptRule provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateAutoAcceptRule(ctx context.Context, tenant string, req requests.AutoAcceptRuleCreate) (*models.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAutoAcceptRule")
+	}
+
+	var r0 *models.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.AutoAcceptRuleCreate) (*models.AutoAcceptRule, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.AutoAcceptRuleCreate) *models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.AutoAcceptRuleCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCertificateAuthority provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateCertificateAuthority(ctx context.Context, tenant string, req requests.CertificateAuthorityCreate) (*models.CertificateAuthority, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCertificateAuthority")
+	}
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.CertificateAuthorityCreate) (*models.CertificateAuthority, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.CertificateAuthorityCreate) *models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.CertificateAuthorityCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeviceGroup provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceGroupCreate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceGroupCreate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.DeviceGroupCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -438,6 +546,96 @@ func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
+// CreateDeviceTarget provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateDeviceTarget(ctx context.Context, tenant string, req requests.DeviceTargetCreate) (*models.Device, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceTarget")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceTargetCreate) (*models.Device, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceTargetCreate) *models.Device); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.DeviceTargetCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFirewallRule provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFirewallRule")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.FirewallRuleCreate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.FirewallRuleCreate) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.FirewallRuleCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateForwardingRule provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateForwardingRule(ctx context.Context, tenant string, req requests.ForwardingRuleCreate) (*models.ForwardingRule, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateForwardingRule")
+	}
+
+	var r0 *models.ForwardingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.ForwardingRuleCreate) (*models.ForwardingRule, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.ForwardingRuleCreate) *models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ForwardingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.ForwardingRuleCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNamespace provides a mock function with given fields: ctx, namespace, userID
 func (_m *Service) CreateNamespace(ctx context.Context, namespace requests.NamespaceCreate, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, namespace, userID)
@@ -558,6 +756,54 @@ func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCr
 	return r0, r1
 }
 
+// CreateSessionEvent provides a mock function with given fields: ctx, req
+func (_m *Service) CreateSessionEvent(ctx context.Context, req requests.SessionEventCreate) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSessionEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionEventCreate) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.WebhookCreate) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.WebhookCreate) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.WebhookCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateSession provides a mock function with given fields: ctx, uid
 func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -594,6 +840,42 @@ func (_m *Service) DeleteAPIKey(ctx context.Context, id string, tenantID string)
 	return r0
 }
 
+// DeleteAutoAcceptRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteAutoAcceptRule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAutoAcceptRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCertificateAuthority provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Service) DeleteCertificateAuthority(ctx context.Context, tenant string, fingerprint string) error {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCertificateAuthority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, uid, tenant
 func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
 	ret := _m.Called(ctx, uid, tenant)
@@ -612,6 +894,60 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
+// DeleteDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteDeviceGroup(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeviceGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFirewallRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteFirewallRule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFirewallRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteForwardingRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteForwardingRule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForwardingRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	ret := _m.Called(ctx, tenantID)
@@ -648,6 +984,24 @@ func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tena
 	return r0
 }
 
+// DeleteRecordedSession provides a mock function with given fields: ctx, uid
+func (_m *Service) DeleteRecordedSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecordedSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTag provides a mock function with given fields: ctx, tenant, tag
 func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
 	ret := _m.Called(ctx, tenant, tag)
@@ -666,17 +1020,35 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
-// DeviceHeartbeat provides a mock function with given fields: ctx, uid
-func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// DeleteWebhook provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteWebhook(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeviceHeartbeat")
+		panic("no return value specified for DeleteWebhook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceHeartbeat provides a mock function with given fields: ctx, uid
+func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -780,6 +1152,62 @@ func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bo
 	return r0
 }
 
+// EvaluateFirewall provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateFirewall(ctx context.Context, req requests.FirewallEvaluate) (bool, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateFirewall")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.FirewallEvaluate) (bool, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.FirewallEvaluate) bool); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.FirewallEvaluate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateForwarding provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateForwarding(ctx context.Context, req requests.ForwardingEvaluate) (bool, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateForwarding")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.ForwardingEvaluate) (bool, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.ForwardingEvaluate) bool); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.ForwardingEvaluate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
 func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	ret := _m.Called(ctx, key, dev)
@@ -836,6 +1264,34 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
+// EvaluateReverseForwarding provides a mock function with given fields: ctx, uid
+func (_m *Service) EvaluateReverseForwarding(ctx context.Context, uid models.UID) (bool, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateReverseForwarding")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (bool, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) bool); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAPIKeyByUID provides a mock function with given fields: ctx, id
 func (_m *Service) GetAPIKeyByUID(ctx context.Context, id string) (*models.APIKey, error) {
 	ret := _m.Called(ctx, id)
@@ -866,6 +1322,36 @@ func (_m *Service) GetAPIKeyByUID(ctx context.Context, id string) (*models.APIKe
 	return r0, r1
 }
 
+// GetAutoAcceptRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetAutoAcceptRule(ctx context.Context, tenant string, id string) (*models.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAutoAcceptRule")
+	}
+
+	var r0 *models.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AutoAcceptRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, uid
 func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
 	ret := _m.Called(ctx, uid)
@@ -926,6 +1412,126 @@ func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address stri
 	return r0, r1
 }
 
+// GetDeviceCredentials provides a mock function with given fields: ctx, uid
+func (_m *Service) GetDeviceCredentials(ctx context.Context, uid models.UID) (*models.DeviceCredentials, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceCredentials")
+	}
+
+	var r0 *models.DeviceCredentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.DeviceCredentials, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.DeviceCredentials); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceCredentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetDeviceGroup(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirewallRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetFirewallRule(ctx context.Context, tenant string, id string) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirewallRule")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForwardingRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetForwardingRule(ctx context.Context, tenant string, id string) (*models.ForwardingRule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForwardingRule")
+	}
+
+	var r0 *models.ForwardingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ForwardingRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ForwardingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -1044,6 +1650,36 @@ func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool,
 	return r0, r1
 }
 
+// GetSessionRecordFrames provides a mock function with given fields: ctx, uid
+func (_m *Service) GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionRecordFrames")
+	}
+
+	var r0 []models.RecordedSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.RecordedSession, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.RecordedSession); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecordedSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStats provides a mock function with given fields: ctx
 func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
 	ret := _m.Called(ctx)
@@ -1111,6 +1747,36 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 	return r0, r1, r2
 }
 
+// GetWebhook provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetWebhook(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // KeepAliveSession provides a mock function with given fields: ctx, uid
 func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -1166,9 +1832,187 @@ func (_m *Service) ListAPIKeys(ctx context.Context, req *requests.APIKeyList) ([
 	return r0, r1, r2
 }
 
-// ListDevices provides a mock function with given fields: ctx, tenant, status, paginator, filter, sorter
-func (_m *Service) ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, sorter query.Sorter) ([]models.Device, int, error) {
-	ret := _m.Called(ctx, tenant, status, paginator, filter, sorter)
+// ListAuditLogs provides a mock function with given fields: ctx, req
+func (_m *Service) ListAuditLogs(ctx context.Context, req requests.AuditList) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditLogs")
+	}
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.AuditList) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.AuditList) []models.AuditLog); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.AuditList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, requests.AuditList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAutoAcceptRules provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListAutoAcceptRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.AutoAcceptRule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAutoAcceptRules")
+	}
+
+	var r0 []models.AutoAcceptRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.AutoAcceptRule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCertificateAuthorities provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListCertificateAuthorities(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCertificateAuthorities")
+	}
+
+	var r0 []models.CertificateAuthority
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.CertificateAuthority, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroups provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListDeviceGroups(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceGroups")
+	}
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceTargets provides a mock function with given fields: ctx, gateway
+func (_m *Service) ListDeviceTargets(ctx context.Context, gateway models.UID) ([]models.DeviceTarget, error) {
+	ret := _m.Called(ctx, gateway)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceTargets")
+	}
+
+	var r0 []models.DeviceTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.DeviceTarget, error)); ok {
+		return rf(ctx, gateway)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.DeviceTarget); ok {
+		r0 = rf(ctx, gateway)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, gateway)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDevices provides a mock function with given fields: ctx, tenant, status, paginator, filter, group, sorter
+func (_m *Service) ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, group string, sorter query.Sorter) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, tenant, status, paginator, filter, group, sorter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDevices")
@@ -1177,25 +2021,99 @@ func (_m *Service) ListDevices(ctx context.Context, tenant string, status models
 	var r0 []models.Device
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter) ([]models.Device, int, error)); ok {
-		return rf(ctx, tenant, status, paginator, filter, sorter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, string, query.Sorter) ([]models.Device, int, error)); ok {
+		return rf(ctx, tenant, status, paginator, filter, group, sorter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter) []models.Device); ok {
-		r0 = rf(ctx, tenant, status, paginator, filter, sorter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, string, query.Sorter) []models.Device); ok {
+		r0 = rf(ctx, tenant, status, paginator, filter, group, sorter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Device)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter) int); ok {
-		r1 = rf(ctx, tenant, status, paginator, filter, sorter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, string, query.Sorter) int); ok {
+		r1 = rf(ctx, tenant, status, paginator, filter, group, sorter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, string, query.Sorter) error); ok {
+		r2 = rf(ctx, tenant, status, paginator, filter, group, sorter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListFirewallRules provides a mock function with given fields: ctx, paginator
+func (_m *Service) ListFirewallRules(ctx context.Context, paginator query.Paginator) ([]models.FirewallRule, int, error) {
+	ret := _m.Called(ctx, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFirewallRules")
+	}
+
+	var r0 []models.FirewallRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.FirewallRule, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.FirewallRule); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListForwardingRules provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListForwardingRules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForwardingRules")
+	}
+
+	var r0 []models.ForwardingRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.ForwardingRule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ForwardingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter) error); ok {
-		r2 = rf(ctx, tenant, status, paginator, filter, sorter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1245,31 +2163,142 @@ func (_m *Service) ListPublicKeys(ctx context.Context, paginator query.Paginator
 	ret := _m.Called(ctx, paginator)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPublicKeys")
+		panic("no return value specified for ListPublicKeys")
+	}
+
+	var r0 []models.PublicKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.PublicKey, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.PublicKey); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessionEvents provides a mock function with given fields: ctx, tenant, uid, paginator
+func (_m *Service) ListSessionEvents(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error) {
+	ret := _m.Called(ctx, tenant, uid, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessionEvents")
+	}
+
+	var r0 []models.SessionEvent
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.SessionEvent, int, error)); ok {
+		return rf(ctx, tenant, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.SessionEvent); ok {
+		r0 = rf(ctx, tenant, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, uid, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessions provides a mock function with given fields: ctx, paginator
+func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, tenant, id, paginator
+func (_m *Service) ListWebhookDeliveries(ctx context.Context, tenant string, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, id, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
 	}
 
-	var r0 []models.PublicKey
+	var r0 []models.WebhookDelivery
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.PublicKey, int, error)); ok {
-		return rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, id, paginator)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.PublicKey); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, paginator)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.WebhookDelivery)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, id, paginator)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, id, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1277,36 +2306,36 @@ func (_m *Service) ListPublicKeys(ctx context.Context, paginator query.Paginator
 	return r0, r1, r2
 }
 
-// ListSessions provides a mock function with given fields: ctx, paginator
-func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, paginator)
+// ListWebhooks provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListWebhooks(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSessions")
+		panic("no return value specified for ListWebhooks")
 	}
 
-	var r0 []models.Session
+	var r0 []models.Webhook
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
-		return rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, paginator)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, paginator)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).([]models.Webhook)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1362,6 +2391,24 @@ func (_m *Service) OffineDevice(ctx context.Context, uid models.UID, online bool
 	return r0
 }
 
+// ProbeDeviceTargets provides a mock function with given fields: ctx, gateway, probes
+func (_m *Service) ProbeDeviceTargets(ctx context.Context, gateway models.UID, probes []models.DeviceTargetProbe) error {
+	ret := _m.Called(ctx, gateway, probes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProbeDeviceTargets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []models.DeviceTargetProbe) error); ok {
+		r0 = rf(ctx, gateway, probes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PublicKey provides a mock function with given fields:
 func (_m *Service) PublicKey() *rsa.PublicKey {
 	ret := _m.Called()
@@ -1484,6 +2531,72 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
+// ReorderFirewallRules provides a mock function with given fields: ctx, tenant, ids
+func (_m *Service) ReorderFirewallRules(ctx context.Context, tenant string, ids []string) error {
+	ret := _m.Called(ctx, tenant, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderFirewallRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, tenant, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SelectDevices provides a mock function with given fields: ctx, tenant, uids, filters
+func (_m *Service) SelectDevices(ctx context.Context, tenant string, uids []string, filters query.Filters) ([]models.Device, error) {
+	ret := _m.Called(ctx, tenant, uids, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectDevices")
+	}
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, query.Filters) ([]models.Device, error)); ok {
+		return rf(ctx, tenant, uids, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, query.Filters) []models.Device); ok {
+		r0 = rf(ctx, tenant, uids, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, query.Filters) error); ok {
+		r1 = rf(ctx, tenant, uids, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDeviceAttributes provides a mock function with given fields: ctx, tenant, uid, attributes
+func (_m *Service) SetDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, tenant, uid, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, tenant, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDevicePosition provides a mock function with given fields: ctx, uid, ip
 func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip string) error {
 	ret := _m.Called(ctx, uid, ip)
@@ -1502,6 +2615,24 @@ func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip str
 	return r0
 }
 
+// SetDeviceVia provides a mock function with given fields: ctx, tenant, uid, via
+func (_m *Service) SetDeviceVia(ctx context.Context, tenant string, uid models.UID, via models.DeviceVia) error {
+	ret := _m.Called(ctx, tenant, uid, via)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceVia")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceVia) error); ok {
+		r0 = rf(ctx, tenant, uid, via)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetSessionAuthenticated provides a mock function with given fields: ctx, uid, authenticated
 func (_m *Service) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
 	ret := _m.Called(ctx, uid, authenticated)
@@ -1520,6 +2651,42 @@ func (_m *Service) SetSessionAuthenticated(ctx context.Context, uid models.UID,
 	return r0
 }
 
+// SetSessionCloseReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Service) SetSessionCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSessionCloseReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.SessionCloseReason) error); ok {
+		r0 = rf(ctx, uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSessionShareInput provides a mock function with given fields: ctx, req
+func (_m *Service) SetSessionShareInput(ctx context.Context, req requests.SessionShareInput) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSessionShareInput")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionShareInput) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields: ctx, req
 func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
 	ret := _m.Called(ctx, req)
@@ -1538,6 +2705,36 @@ func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
 	return r0
 }
 
+// ShareSession provides a mock function with given fields: ctx, req
+func (_m *Service) ShareSession(ctx context.Context, req requests.SessionShare) (*responses.SessionShare, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareSession")
+	}
+
+	var r0 *responses.SessionShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionShare) (*responses.SessionShare, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionShare) *responses.SessionShare); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.SessionShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.SessionShare) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SystemDownloadInstallScript provides a mock function with given fields: ctx, req
 func (_m *Service) SystemDownloadInstallScript(ctx context.Context, req requests.SystemInstallScript) (*template.Template, map[string]interface{}, error) {
 	ret := _m.Called(ctx, req)
@@ -1607,6 +2804,72 @@ func (_m *Service) SystemGetInfo(ctx context.Context, req requests.SystemGetInfo
 	return r0, r1
 }
 
+// UnsetDeviceAttribute provides a mock function with given fields: ctx, tenant, uid, key
+func (_m *Service) UnsetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error {
+	ret := _m.Called(ctx, tenant, uid, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsetDeviceAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsetDeviceVia provides a mock function with given fields: ctx, tenant, uid
+func (_m *Service) UnsetDeviceVia(ctx context.Context, tenant string, uid models.UID) error {
+	ret := _m.Called(ctx, tenant, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsetDeviceVia")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) error); ok {
+		r0 = rf(ctx, tenant, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAutoAcceptRule provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateAutoAcceptRule(ctx context.Context, tenant string, id string, req requests.AutoAcceptRuleUpdate) (*models.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAutoAcceptRule")
+	}
+
+	var r0 *models.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.AutoAcceptRuleUpdate) (*models.AutoAcceptRule, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.AutoAcceptRuleUpdate) *models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.AutoAcceptRuleUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDataUser provides a mock function with given fields: ctx, id, userData
 func (_m *Service) UpdateDataUser(ctx context.Context, id string, userData models.UserData) ([]string, error) {
 	ret := _m.Called(ctx, id, userData)
@@ -1655,6 +2918,36 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceGroup provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateDeviceGroup(ctx context.Context, tenant string, id string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceGroupUpdate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
 	ret := _m.Called(ctx, tenant, uid, status)
@@ -1691,6 +2984,66 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
+// UpdateFirewallRule provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateFirewallRule(ctx context.Context, tenant string, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFirewallRule")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.FirewallRuleUpdate) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.FirewallRuleUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateForwardingRule provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateForwardingRule(ctx context.Context, tenant string, id string, req requests.ForwardingRuleUpdate) (*models.ForwardingRule, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateForwardingRule")
+	}
+
+	var r0 *models.ForwardingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.ForwardingRuleUpdate) (*models.ForwardingRule, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.ForwardingRuleUpdate) *models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ForwardingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.ForwardingRuleUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
 func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
 	ret := _m.Called(ctx, id, currentPassword, newPassword)
@@ -1757,6 +3110,36 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// UpdateWebhook provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateWebhook(ctx context.Context, tenant string, id string, req requests.WebhookUpdate) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhook")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.WebhookUpdate) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.WebhookUpdate) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.WebhookUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
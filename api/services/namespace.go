@@ -193,6 +193,16 @@ func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit
 		Name:                   strings.ToLower(req.Name),
 		SessionRecord:          req.Settings.SessionRecord,
 		ConnectionAnnouncement: req.Settings.ConnectionAnnouncement,
+		RecordRetentionDays:    req.Settings.RecordRetentionDays.Days,
+		ReversePortForwarding:  req.Settings.ReversePortForwarding,
+		AgentForwarding:        req.Settings.AgentForwarding,
+		IdleTimeout:            req.Settings.IdleTimeout,
+		MaxSessionDuration:     req.Settings.MaxSessionDuration,
+		ShadowNotice:           req.Settings.ShadowNotice,
+		RecordExecOutput:       req.Settings.RecordExecOutput,
+		RecordInput:            req.Settings.RecordInput,
+		// NOTICE: Zero keeps the records forever, while null falls back to the instance's default retention.
+		UnsetRecordRetentionDays: req.Settings.RecordRetentionDays.Unset(),
 	}
 
 	if err := s.store.NamespaceEdit(ctx, req.Tenant, changes); err != nil {
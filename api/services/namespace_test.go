@@ -770,12 +770,15 @@ func TestEditNamespace(t *testing.T) {
 		err       error
 	}
 
+	retention := 30
+
 	cases := []struct {
-		description   string
-		requiredMocks func()
-		tenantID      string
-		namespaceName string
-		expected      Expected
+		description         string
+		requiredMocks       func()
+		tenantID            string
+		namespaceName       string
+		recordRetentionDays requests.RecordRetentionDays
+		expected            Expected
 	}{
 		{
 			description:   "fails when namespace does not exist",
@@ -831,6 +834,64 @@ func TestEditNamespace(t *testing.T) {
 				nil,
 			},
 		},
+		{
+			description:         "succeeds changing the record retention",
+			namespaceName:       "newname",
+			tenantID:            "xxxxx",
+			recordRetentionDays: requests.RecordRetentionDays{Days: &retention, Set: true},
+			requiredMocks: func() {
+				mock.On("NamespaceEdit", ctx, "xxxxx", &models.NamespaceChanges{Name: "newname", RecordRetentionDays: &retention}).
+					Return(nil).
+					Once()
+
+				namespace := &models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{RecordRetentionDays: &retention},
+				}
+
+				mock.On("NamespaceGet", ctx, "xxxxx").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{
+				&models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{RecordRetentionDays: &retention},
+				},
+				nil,
+			},
+		},
+		{
+			description:         "succeeds unsetting the record retention",
+			namespaceName:       "newname",
+			tenantID:            "xxxxx",
+			recordRetentionDays: requests.RecordRetentionDays{Set: true},
+			requiredMocks: func() {
+				mock.On("NamespaceEdit", ctx, "xxxxx", &models.NamespaceChanges{Name: "newname", UnsetRecordRetentionDays: true}).
+					Return(nil).
+					Once()
+
+				namespace := &models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{},
+				}
+
+				mock.On("NamespaceGet", ctx, "xxxxx").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{
+				&models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{},
+				},
+				nil,
+			},
+		},
 		{
 			description:   "succeeds",
 			namespaceName: "newname",
@@ -868,6 +929,8 @@ func TestEditNamespace(t *testing.T) {
 				TenantParam: requests.TenantParam{Tenant: tc.tenantID},
 				Name:        tc.namespaceName,
 			}
+			req.Settings.RecordRetentionDays = tc.recordRetentionDays
+
 			namespace, err := service.EditNamespace(ctx, req)
 
 			assert.Equal(t, tc.expected, Expected{namespace, err})
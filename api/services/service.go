@@ -31,6 +31,9 @@ type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceAttributes
+	DeviceVia
+	DeviceTargets
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
@@ -41,6 +44,13 @@ type Service interface {
 	SetupService
 	SystemService
 	APIKeyService
+	FirewallService
+	AuditService
+	WebhookService
+	AutoAcceptRuleService
+	ForwardingRuleService
+	DeviceGroupService
+	CertificateAuthorityService
 }
 
 func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
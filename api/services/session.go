@@ -7,7 +7,10 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
 )
 
 type SessionService interface {
@@ -15,8 +18,28 @@ type SessionService interface {
 	GetSession(ctx context.Context, uid models.UID) (*models.Session, error)
 	CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error)
 	DeactivateSession(ctx context.Context, uid models.UID) error
+	// SetSessionCloseReason records the reason why ShellHub closed the session.
+	SetSessionCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error
+	// CloseSession marks an active session as closed by the user. The SSH server holding the session is signaled to
+	// close its connection by the service returned from [WithSessionSignals]. Closing a session already closed does
+	// nothing.
+	CloseSession(ctx context.Context, uid models.UID, user string) error
+	// ShareSession invites a guest to attach to an active session, returning the invite's token. The SSH server holding
+	// the session is signaled about the invite by the service returned from [WithSessionSignals].
+	ShareSession(ctx context.Context, req requests.SessionShare) (*responses.SessionShare, error)
+	// SetSessionShareInput grants or revokes the input control of a guest attached to an active session. The SSH
+	// server holding the session is signaled about it by the service returned from [WithSessionSignals].
+	SetSessionShareInput(ctx context.Context, req requests.SessionShareInput) error
 	KeepAliveSession(ctx context.Context, uid models.UID) error
 	SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
+	// GetSessionRecordFrames returns the recorded frames of a session.
+	GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error)
+	// DeleteRecordedSession deletes the recorded frames of a session, marking it as not recorded.
+	DeleteRecordedSession(ctx context.Context, uid models.UID) error
+	// CreateSessionEvent records something done in the session besides its terminal, as reported by the SSH server.
+	CreateSessionEvent(ctx context.Context, req requests.SessionEventCreate) error
+	// ListSessionEvents lists the events of the tenant's session, from the oldest to the newest.
+	ListSessionEvents(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error)
 }
 
 func (s *service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
@@ -35,6 +58,14 @@ func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Sessi
 func (s *service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
 	position, _ := s.locator.GetPosition(net.ParseIP(session.IPAddress))
 
+	var certificate *models.SessionCertificate
+	if session.Certificate != nil {
+		certificate = &models.SessionCertificate{
+			Serial: session.Certificate.Serial,
+			KeyID:  session.Certificate.KeyID,
+		}
+	}
+
 	return s.store.SessionCreate(ctx, models.Session{
 		UID:       session.UID,
 		DeviceUID: models.UID(session.DeviceUID),
@@ -46,6 +77,8 @@ func (s *service) CreateSession(ctx context.Context, session requests.SessionCre
 			Longitude: position.Longitude,
 			Latitude:  position.Latitude,
 		},
+		Certificate: certificate,
+		Hops:        session.Hops,
 	})
 }
 
@@ -58,6 +91,63 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	return err
 }
 
+func (s *service) SetSessionCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error {
+	if err := s.store.SessionSetCloseReason(ctx, uid, reason); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return nil
+}
+
+func (s *service) CloseSession(ctx context.Context, uid models.UID, user string) error {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	if session.Closed {
+		return nil
+	}
+
+	if err := s.store.SessionSetClosedBy(ctx, uid, user); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return nil
+}
+
+// activeSession gets the session, failing when it is already closed.
+func (s *service) activeSession(ctx context.Context, uid models.UID) (*models.Session, error) {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrSessionNotFound(uid, err)
+	}
+
+	if session.Closed || !session.Active {
+		return nil, NewErrSessionClosed(uid, nil)
+	}
+
+	return session, nil
+}
+
+func (s *service) ShareSession(ctx context.Context, req requests.SessionShare) (*responses.SessionShare, error) {
+	if _, err := s.activeSession(ctx, models.UID(req.UID)); err != nil {
+		return nil, err
+	}
+
+	return &responses.SessionShare{
+		Token: uuid.Generate(),
+		Guest: req.Guest,
+		Input: req.Input,
+	}, nil
+}
+
+func (s *service) SetSessionShareInput(ctx context.Context, req requests.SessionShareInput) error {
+	_, err := s.activeSession(ctx, models.UID(req.UID))
+
+	return err
+}
+
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	return s.store.SessionSetLastSeen(ctx, uid)
 }
@@ -65,3 +155,53 @@ func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 func (s *service) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
 	return s.store.SessionSetAuthenticated(ctx, uid, authenticated)
 }
+
+func (s *service) GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	frames, _, err := s.store.SessionGetRecordFrame(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return frames, nil
+}
+
+func (s *service) DeleteRecordedSession(ctx context.Context, uid models.UID) error {
+	if _, err := s.store.SessionGet(ctx, uid); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	if err := s.store.SessionDeleteRecordFrame(ctx, uid); err != nil && err != store.ErrNoDocuments {
+		return err
+	}
+
+	return s.store.SessionSetRecorded(ctx, uid, false)
+}
+
+func (s *service) CreateSessionEvent(ctx context.Context, req requests.SessionEventCreate) error {
+	session, err := s.store.SessionGet(ctx, models.UID(req.UID))
+	if err != nil {
+		return NewErrSessionNotFound(models.UID(req.UID), err)
+	}
+
+	timestamp := req.Timestamp
+	if timestamp.IsZero() {
+		timestamp = clock.Now()
+	}
+
+	return s.store.SessionEventCreate(ctx, &models.SessionEvent{
+		Session:   session.UID,
+		TenantID:  session.TenantID,
+		Type:      models.SessionEventType(req.Type),
+		Timestamp: timestamp,
+		Data:      req.Data,
+	})
+}
+
+func (s *service) ListSessionEvents(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error) {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil || session.TenantID != tenant {
+		return nil, 0, NewErrSessionNotFound(uid, err)
+	}
+
+	return s.store.SessionEventList(ctx, tenant, uid, paginator)
+}
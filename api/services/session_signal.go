@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/pubsub"
+)
+
+// Publisher publishes the messages broadcasted to the other ShellHub's services.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, message interface{}) error
+}
+
+// signaled is a Service that signals the SSH servers about the sessions changed through the API. Each SSH server
+// subscribes to the signals and acts on the sessions it holds.
+type signaled struct {
+	Service
+	publisher Publisher
+}
+
+// WithSessionSignals wraps service to signal, through publisher, the SSH servers to close the sessions closed by the
+// namespaces' members and to apply the sharing of the sessions.
+func WithSessionSignals(service Service, publisher Publisher) Service {
+	return &signaled{Service: service, publisher: publisher}
+}
+
+func (s *signaled) CloseSession(ctx context.Context, uid models.UID, user string) error {
+	if err := s.Service.CloseSession(ctx, uid, user); err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, pubsub.SessionCloseChannel, &pubsub.SessionClose{UID: string(uid)})
+}
+
+func (s *signaled) ShareSession(ctx context.Context, req requests.SessionShare) (*responses.SessionShare, error) {
+	share, err := s.Service.ShareSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.publisher.Publish(ctx, pubsub.SessionShareChannel, &pubsub.SessionShare{
+		UID:   req.UID,
+		Token: share.Token,
+		Guest: share.Guest,
+		Input: share.Input,
+	}); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (s *signaled) SetSessionShareInput(ctx context.Context, req requests.SessionShareInput) error {
+	if err := s.Service.SetSessionShareInput(ctx, req); err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, pubsub.SessionShareChannel, &pubsub.SessionShare{
+		UID:   req.UID,
+		Guest: req.Guest,
+		Input: req.Input,
+	})
+}
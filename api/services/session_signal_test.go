@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// publisherMock records the messages published.
+type publisherMock struct {
+	messages map[string][]interface{}
+}
+
+func (p *publisherMock) Publish(_ context.Context, channel string, message interface{}) error {
+	p.messages[channel] = append(p.messages[channel], message)
+
+	return nil
+}
+
+func TestSignaledCloseSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		requiredMocks func()
+		expected      []interface{}
+	}{
+		{
+			name: "does not signal when the session is not found",
+			uid:  models.UID("_uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("_uid")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "signals the SSH servers to close the session",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(&models.Session{UID: "uid"}, nil).Once()
+				mock.On("SessionSetClosedBy", ctx, models.UID("uid"), "user").Return(nil).Once()
+			},
+			expected: []interface{}{&pubsub.SessionClose{UID: "uid"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			publisher := &publisherMock{messages: make(map[string][]interface{})}
+
+			service := WithSessionSignals(NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil), publisher)
+			service.CloseSession(ctx, tc.uid, "user") //nolint:errcheck
+
+			assert.Equal(t, tc.expected, publisher.messages[pubsub.SessionCloseChannel])
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSignaledSetSessionShareInput(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		req           requests.SessionShareInput
+		requiredMocks func()
+		expected      []interface{}
+	}{
+		{
+			name: "does not signal when the session is closed",
+			req:  requests.SessionShareInput{SessionIDParam: requests.SessionIDParam{UID: "closed"}, Guest: "guest"},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("closed")).Return(&models.Session{UID: "closed", Closed: true}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "signals the SSH servers to grant the guest's input",
+			req:  requests.SessionShareInput{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Guest: "guest", Input: true},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(&models.Session{UID: "uid", Active: true}, nil).Once()
+			},
+			expected: []interface{}{&pubsub.SessionShare{UID: "uid", Guest: "guest", Input: true}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			publisher := &publisherMock{messages: make(map[string][]interface{})}
+
+			service := WithSessionSignals(NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil), publisher)
+			service.SetSessionShareInput(ctx, tc.req) //nolint:errcheck
+
+			assert.Equal(t, tc.expected, publisher.messages[pubsub.SessionShareChannel])
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
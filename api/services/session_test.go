@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	goerrors "errors"
 
@@ -11,10 +12,13 @@ import (
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -269,6 +273,124 @@ func TestDeactivateSession(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestCloseSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when session is not found",
+			uid:  models.UID("_uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("_uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrSessionNotFound("_uid", store.ErrNoDocuments),
+		},
+		{
+			name: "succeeds without changes when the session is already closed",
+			uid:  models.UID("closed"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("closed")).
+					Return(&models.Session{UID: "closed", Closed: true}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "succeeds",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid"}, nil).Once()
+				mock.On("SessionSetClosedBy", ctx, models.UID("uid"), "user").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.CloseSession(ctx, tc.uid, "user")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestShareSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	type Expected struct {
+		share *responses.SessionShare
+		err   error
+	}
+
+	cases := []struct {
+		name          string
+		req           requests.SessionShare
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when session is not found",
+			req:  requests.SessionShare{SessionIDParam: requests.SessionIDParam{UID: "_uid"}, Guest: "guest"},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("_uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("_uid", store.ErrNoDocuments)},
+		},
+		{
+			name: "fails when session is closed",
+			req:  requests.SessionShare{SessionIDParam: requests.SessionIDParam{UID: "closed"}, Guest: "guest"},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("closed")).
+					Return(&models.Session{UID: "closed", Closed: true}, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionClosed("closed", nil)},
+		},
+		{
+			name: "succeeds",
+			req:  requests.SessionShare{SessionIDParam: requests.SessionIDParam{UID: "uid"}, Guest: "guest", Input: true},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Active: true}, nil).Once()
+				uuidMock.On("Generate").Return("token").Once()
+			},
+			expected: Expected{&responses.SessionShare{Token: "token", Guest: "guest", Input: true}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			share, err := service.ShareSession(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{share, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
 func TestSetSessionAuthenticated(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -312,3 +434,276 @@ func TestSetSessionAuthenticated(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestGetSessionRecordFrames(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		frames []models.RecordedSession
+		err    error
+	}
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).
+					Return(nil, 0, goerrors.New("error")).Once()
+			},
+			expected: Expected{nil, goerrors.New("error")},
+		},
+		{
+			name: "succeeds",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).
+					Return([]models.RecordedSession{{UID: "uid", Message: "message"}}, 1, nil).Once()
+			},
+			expected: Expected{[]models.RecordedSession{{UID: "uid", Message: "message"}}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			frames, err := service.GetSessionRecordFrames(ctx, tc.uid)
+			assert.Equal(t, tc.expected, Expected{frames, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteRecordedSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when the session is not found",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrSessionNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			name: "fails when the recorded frames cannot be deleted",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Recorded: true}, nil).Once()
+				mock.On("SessionDeleteRecordFrame", ctx, models.UID("uid")).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			name: "succeeds when the session has no recorded frames",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid"}, nil).Once()
+				mock.On("SessionDeleteRecordFrame", ctx, models.UID("uid")).
+					Return(store.ErrNoDocuments).Once()
+				mock.On("SessionSetRecorded", ctx, models.UID("uid"), false).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "succeeds",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Recorded: true}, nil).Once()
+				mock.On("SessionDeleteRecordFrame", ctx, models.UID("uid")).
+					Return(nil).Once()
+				mock.On("SessionSetRecorded", ctx, models.UID("uid"), false).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.DeleteRecordedSession(ctx, tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateSessionEvent(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	timestamp := now.Add(-time.Minute)
+
+	cases := []struct {
+		name          string
+		req           requests.SessionEventCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when the session is not found",
+			req: requests.SessionEventCreate{
+				SessionIDParam: requests.SessionIDParam{UID: "uid"},
+				Type:           "exec",
+			},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrSessionNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			name: "succeeds with the event's timestamp",
+			req: requests.SessionEventCreate{
+				SessionIDParam: requests.SessionIDParam{UID: "uid"},
+				Type:           "sftp",
+				Timestamp:      timestamp,
+				Data:           map[string]interface{}{"operation": "remove", "path": "/tmp/file"},
+			},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+				mock.On("SessionEventCreate", ctx, &models.SessionEvent{
+					Session:   "uid",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Type:      models.SessionEventTypeSFTP,
+					Timestamp: timestamp,
+					Data:      map[string]interface{}{"operation": "remove", "path": "/tmp/file"},
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "succeeds with the current time when the event has no timestamp",
+			req: requests.SessionEventCreate{
+				SessionIDParam: requests.SessionIDParam{UID: "uid"},
+				Type:           "exec",
+				Data:           map[string]interface{}{"command": "uptime"},
+			},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionEventCreate", ctx, &models.SessionEvent{
+					Session:   "uid",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Type:      models.SessionEventTypeExec,
+					Timestamp: now,
+					Data:      map[string]interface{}{"command": "uptime"},
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.CreateSessionEvent(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListSessionEvents(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	paginator := query.Paginator{Page: 1, PerPage: 10}
+
+	cases := []struct {
+		name          string
+		tenant        string
+		uid           models.UID
+		requiredMocks func()
+		expected      []models.SessionEvent
+		count         int
+		err           error
+	}{
+		{
+			name:   "fails when the session is not found",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			uid:    models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrSessionNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			name:   "fails when the session belongs to another tenant",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			uid:    models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "00000000-0000-4001-0000-000000000000"}, nil).Once()
+			},
+			err: NewErrSessionNotFound(models.UID("uid"), nil),
+		},
+		{
+			name:   "succeeds",
+			tenant: "00000000-0000-4000-0000-000000000000",
+			uid:    models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+				mock.On("SessionEventList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), paginator).
+					Return([]models.SessionEvent{
+						{Session: "uid", Type: models.SessionEventTypeShell, Timestamp: now},
+					}, 1, nil).Once()
+			},
+			expected: []models.SessionEvent{
+				{Session: "uid", Type: models.SessionEventTypeShell, Timestamp: now},
+			},
+			count: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			events, count, err := service.ListSessionEvents(ctx, tc.tenant, tc.uid, paginator)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, events)
+			assert.Equal(t, tc.count, count)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -32,7 +32,7 @@ type Request struct {
 	Namespace string
 }
 
-func (s *service) EvaluateKeyFilter(_ context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
+func (s *service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	if key.Filter.Hostname != "" {
 		ok, err := regexp.MatchString(key.Filter.Hostname, dev.Name)
 		if err != nil {
@@ -48,6 +48,10 @@ func (s *service) EvaluateKeyFilter(_ context.Context, key *models.PublicKey, de
 		}
 
 		return false, nil
+	} else if len(key.Filter.Attributes) > 0 {
+		return hasAttributes(&dev, key.Filter.Attributes), nil
+	} else if key.Filter.Group != "" {
+		return s.isDeviceGroupMember(ctx, key.TenantID, key.Filter.Group, &dev)
 	}
 
 	return true, nil
@@ -91,6 +95,12 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		}
 	}
 
+	if req.Filter.Group != "" {
+		if _, err := s.GetDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+			return nil, err
+		}
+	}
+
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(req.Data) //nolint:dogsled
 	if err != nil {
 		return nil, NewErrPublicKeyDataInvalid(req.Data, nil)
@@ -116,9 +126,13 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 			Name:     req.Name,
 			Username: req.Username,
 			Filter: models.PublicKeyFilter{
-				Hostname: req.Filter.Hostname,
-				Tags:     req.Filter.Tags,
+				Hostname:   req.Filter.Hostname,
+				Tags:       req.Filter.Tags,
+				Attributes: req.Filter.Attributes,
+				Group:      req.Filter.Group,
 			},
+			ForceCommand:    req.ForceCommand,
+			AllowedCommands: req.AllowedCommands,
 		},
 	}
 
@@ -128,12 +142,14 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 	}
 
 	return &responses.PublicKeyCreate{
-		Data:        model.Data,
-		Filter:      responses.PublicKeyFilter(model.Filter),
-		Name:        model.Name,
-		Username:    model.Username,
-		TenantID:    model.TenantID,
-		Fingerprint: model.Fingerprint,
+		Data:            model.Data,
+		Filter:          responses.PublicKeyFilter(model.Filter),
+		Name:            model.Name,
+		Username:        model.Username,
+		TenantID:        model.TenantID,
+		Fingerprint:     model.Fingerprint,
+		ForceCommand:    model.ForceCommand,
+		AllowedCommands: model.AllowedCommands,
 	}, nil
 }
 
@@ -157,14 +173,24 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 		}
 	}
 
+	if key.Filter.Group != "" {
+		if _, err := s.GetDeviceGroup(ctx, tenant, key.Filter.Group); err != nil {
+			return nil, err
+		}
+	}
+
 	model := models.PublicKeyUpdate{
 		PublicKeyFields: models.PublicKeyFields{
 			Name:     key.Name,
 			Username: key.Username,
 			Filter: models.PublicKeyFilter{
-				Hostname: key.Filter.Hostname,
-				Tags:     key.Filter.Tags,
+				Hostname:   key.Filter.Hostname,
+				Tags:       key.Filter.Tags,
+				Attributes: key.Filter.Attributes,
+				Group:      key.Filter.Group,
 			},
+			ForceCommand:    key.ForceCommand,
+			AllowedCommands: key.AllowedCommands,
 		},
 	}
 
@@ -28,7 +28,7 @@ func (s *service) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || len(key.Filter.Attributes) > 0 || key.Filter.Group != "" {
 		return NewErrPublicKeyFilter(nil)
 	}
 
@@ -73,7 +73,7 @@ func (s *service) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, t
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || len(key.Filter.Attributes) > 0 || key.Filter.Group != "" {
 		return NewErrPublicKeyFilter(nil)
 	}
 
@@ -124,7 +124,7 @@ func (s *service) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint s
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || len(key.Filter.Attributes) > 0 || key.Filter.Group != "" {
 		return NewErrPublicKeyNotFound(fingerprint, nil)
 	}
 
@@ -106,6 +106,60 @@ func TestEvaluateKeyFilter(t *testing.T) {
 			},
 			expected: Expected{true, nil},
 		},
+		{
+			description: "fail to evaluate filter attributes when an attribute differs",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Attributes: map[string]string{"site": "lab", "env": "prod"},
+					},
+				},
+			},
+			device: models.Device{
+				Attributes: map[string]string{"site": "lab", "env": "dev"},
+			},
+			requiredMocks: func() {
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "success to evaluate filter attributes",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Attributes: map[string]string{"site": "lab"},
+					},
+				},
+			},
+			device: models.Device{
+				Attributes: map[string]string{"site": "lab", "env": "dev"},
+			},
+			requiredMocks: func() {
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "success to evaluate filter group when the device is a member of a child group",
+			key: &models.PublicKey{
+				TenantID: "tenant",
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Group: "europe",
+					},
+				},
+			},
+			device: models.Device{
+				UID: "uid",
+			},
+			requiredMocks: func() {
+				mock.On("DeviceGroupList", ctx, "tenant", query.Paginator{Page: -1, PerPage: -1}).
+					Return([]models.DeviceGroup{
+						{ID: "europe"},
+						{ID: "lisbon", DeviceGroupFields: models.DeviceGroupFields{Parent: "europe", Devices: []string{"uid"}}},
+					}, 2, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
 		{
 			description: "success to evaluate when key has no filter",
 			key: &models.PublicKey{
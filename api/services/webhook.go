@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/workers"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookService interface {
+	ListWebhooks(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error)
+	GetWebhook(ctx context.Context, tenant, id string) (*models.Webhook, error)
+	// CreateWebhook creates a webhook subscribed to the request's events. The returned webhook is the only one
+	// carrying its secret.
+	CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, tenant, id string, req requests.WebhookUpdate) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, tenant, id string) error
+	// ListWebhookDeliveries lists the webhook's delivery log, from the newest to the oldest attempt.
+	ListWebhookDeliveries(ctx context.Context, tenant, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error)
+}
+
+func (s *service) ListWebhooks(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error) {
+	webhooks, count, err := s.store.WebhookList(ctx, tenant, paginator)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *service) GetWebhook(ctx context.Context, tenant, id string) (*models.Webhook, error) {
+	webhook, err := s.store.WebhookGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(id, err)
+	}
+
+	webhook.Secret = ""
+
+	return webhook, nil
+}
+
+func (s *service) CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*models.Webhook, error) {
+	secret := req.Secret
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+
+		secret = hex.EncodeToString(key)
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	now := clock.Now()
+	webhook := &models.Webhook{
+		TenantID:  tenant,
+		URL:       req.URL,
+		Events:    webhookEvents(req.Events),
+		Secret:    secret,
+		Active:    active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.store.WebhookCreate(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, tenant, id string, req requests.WebhookUpdate) (*models.Webhook, error) {
+	changes := &models.WebhookChanges{
+		URL:       req.URL,
+		Active:    req.Active,
+		UpdatedAt: clock.Now(),
+	}
+
+	if len(req.Events) > 0 {
+		changes.Events = webhookEvents(req.Events)
+	}
+
+	webhook, err := s.store.WebhookUpdate(ctx, tenant, id, changes)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(id, err)
+	}
+
+	webhook.Secret = ""
+
+	return webhook, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, tenant, id string) error {
+	if err := s.store.WebhookDelete(ctx, tenant, id); err != nil {
+		return NewErrWebhookNotFound(id, err)
+	}
+
+	return nil
+}
+
+func (s *service) ListWebhookDeliveries(ctx context.Context, tenant, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error) {
+	if _, err := s.store.WebhookGet(ctx, tenant, id); err != nil {
+		return nil, 0, NewErrWebhookNotFound(id, err)
+	}
+
+	return s.store.WebhookDeliveryList(ctx, tenant, id, paginator)
+}
+
+func webhookEvents(events []string) []models.WebhookEvent {
+	converted := make([]models.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		converted = append(converted, models.WebhookEvent(event))
+	}
+
+	return converted
+}
+
+// TaskEnqueuer enqueues the tasks processed by the API's workers.
+type TaskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// webhooked is a Service that emits the device and session lifecycle events to the namespaces' webhooks. The events
+// are enqueued to the workers, which deliver them to the webhooks subscribed to each event.
+type webhooked struct {
+	Service
+	store store.Store
+	tasks TaskEnqueuer
+}
+
+// WithWebhooks wraps service to emit, through tasks, the device and session lifecycle events to the namespaces'
+// webhooks. Mutations that fail don't emit events. The service must already apply the auto-accept rules, so a
+// registering device emits the status it's left with.
+func WithWebhooks(service Service, store store.Store, tasks TaskEnqueuer) Service {
+	return &webhooked{Service: service, store: store, tasks: tasks}
+}
+
+// emit enqueues the event about data. As the mutation was already done, a failure to enqueue it is only reported.
+func (w *webhooked) emit(tenant string, event models.WebhookEvent, data interface{}) {
+	logger := logrus.WithFields(logrus.Fields{"tenant_id": tenant, "event": event})
+
+	task, err := workers.NewWebhookEventTask(tenant, event, data)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create the webhook event")
+
+		return
+	}
+
+	if _, err := w.tasks.Enqueue(task); err != nil {
+		logger.WithError(err).Error("Failed to enqueue the webhook event")
+	}
+}
+
+func (w *webhooked) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
+	uid := models.UID(deviceUID(req))
+
+	// Only the device's first authentication registers it as pending.
+	_, err := w.store.DeviceGet(ctx, uid)
+	registered := err == nil
+
+	res, err := w.Service.AuthDevice(ctx, req, remoteAddr)
+	if err != nil || registered {
+		return res, err
+	}
+
+	device, err := w.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return res, nil
+	}
+
+	// The auto-accept rules are evaluated by the decorated service, so the device is only still pending when no rule
+	// applied to it.
+	switch device.Status {
+	case models.DeviceStatusPending:
+		w.emit(device.TenantID, models.WebhookEventDevicePending, device)
+	case models.DeviceStatusAccepted:
+		w.emit(device.TenantID, models.WebhookEventDeviceAccepted, device)
+	}
+
+	return res, nil
+}
+
+func (w *webhooked) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
+	if err := w.Service.UpdateDeviceStatus(ctx, tenant, uid, status); err != nil {
+		return err
+	}
+
+	var event models.WebhookEvent
+	switch status {
+	case models.DeviceStatusPending:
+		event = models.WebhookEventDevicePending
+	case models.DeviceStatusAccepted:
+		event = models.WebhookEventDeviceAccepted
+	default:
+		return nil
+	}
+
+	if device, err := w.store.DeviceGetByUID(ctx, uid, tenant); err == nil {
+		w.emit(tenant, event, device)
+	}
+
+	return nil
+}
+
+func (w *webhooked) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
+	// The device is read before the mutation to know whether it was online.
+	device, _ := w.store.DeviceGet(ctx, uid)
+
+	if err := w.Service.OffineDevice(ctx, uid, online); err != nil {
+		return err
+	}
+
+	if device != nil && device.Online && !online {
+		device.Online = false
+		w.emit(device.TenantID, models.WebhookEventDeviceOffline, device)
+	}
+
+	return nil
+}
+
+func (w *webhooked) CreateSession(ctx context.Context, req requests.SessionCreate) (*models.Session, error) {
+	session, err := w.Service.CreateSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	w.emit(session.TenantID, models.WebhookEventSessionStarted, session)
+
+	return session, nil
+}
+
+func (w *webhooked) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
+	if err := w.Service.SetSessionAuthenticated(ctx, uid, authenticated); err != nil {
+		return err
+	}
+
+	if !authenticated {
+		return nil
+	}
+
+	if session, err := w.store.SessionGet(ctx, uid); err == nil {
+		w.emit(session.TenantID, models.WebhookEventSessionAuthenticated, session)
+	}
+
+	return nil
+}
+
+func (w *webhooked) DeactivateSession(ctx context.Context, uid models.UID) error {
+	if err := w.Service.DeactivateSession(ctx, uid); err != nil {
+		return err
+	}
+
+	if session, err := w.store.SessionGet(ctx, uid); err == nil {
+		w.emit(session.TenantID, models.WebhookEventSessionFinished, session)
+	}
+
+	return nil
+}
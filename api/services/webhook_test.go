@@ -0,0 +1,522 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	mocksService "github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/api/workers"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/geoip"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+// tasksRecorder is a TaskEnqueuer that records the enqueued tasks.
+type tasksRecorder struct {
+	tasks []*asynq.Task
+}
+
+func (r *tasksRecorder) Enqueue(task *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.tasks = append(r.tasks, task)
+
+	return &asynq.TaskInfo{}, nil
+}
+
+// events returns the events of the recorded tasks.
+func (r *tasksRecorder) events() []models.WebhookEvent {
+	events := make([]models.WebhookEvent, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		var payload models.WebhookPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			continue
+		}
+
+		events = append(events, payload.Event)
+	}
+
+	return events
+}
+
+func TestCreateWebhook(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	active := false
+
+	cases := []struct {
+		description   string
+		req           requests.WebhookCreate
+		requiredMocks func()
+		expected      func(t *testing.T, webhook *models.Webhook, err error)
+	}{
+		{
+			description: "fails when the store fails",
+			req: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, gomock.Anything).Return(errors.New("error")).Once()
+			},
+			expected: func(t *testing.T, webhook *models.Webhook, err error) {
+				assert.Nil(t, webhook)
+				assert.Equal(t, errors.New("error"), err)
+			},
+		},
+		{
+			description: "succeeds generating a secret",
+			req: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online", "session.started"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, gomock.Anything).Return(nil).Once()
+			},
+			expected: func(t *testing.T, webhook *models.Webhook, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "tenant", webhook.TenantID)
+				assert.Equal(t, []models.WebhookEvent{models.WebhookEventDeviceOnline, models.WebhookEventSessionStarted}, webhook.Events)
+				assert.Len(t, webhook.Secret, 64)
+				assert.True(t, webhook.Active)
+				assert.Equal(t, now, webhook.CreatedAt)
+			},
+		},
+		{
+			description: "succeeds with the request's secret",
+			req: requests.WebhookCreate{
+				URL:    "https://example.com",
+				Events: []string{"device.online"},
+				Secret: "0123456789abcdef",
+				Active: &active,
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, gomock.Anything).Return(nil).Once()
+			},
+			expected: func(t *testing.T, webhook *models.Webhook, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "0123456789abcdef", webhook.Secret)
+				assert.False(t, webhook.Active)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			webhook, err := service.CreateWebhook(ctx, "tenant", tc.req)
+			tc.expected(t, webhook, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetWebhook(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		webhook *models.Webhook
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the webhook is not found",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrWebhookNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds hiding the secret",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(&models.Webhook{ID: "id", Secret: "secret"}, nil).Once()
+			},
+			expected: Expected{&models.Webhook{ID: "id"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			webhook, err := service.GetWebhook(ctx, "tenant", "id")
+			assert.Equal(t, tc.expected, Expected{webhook, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	active := false
+
+	type Expected struct {
+		webhook *models.Webhook
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.WebhookUpdate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the webhook is not found",
+			req:         requests.WebhookUpdate{Active: &active},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookUpdate", ctx, "tenant", "id", &models.WebhookChanges{Active: &active, UpdatedAt: now}).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrWebhookNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req:         requests.WebhookUpdate{Events: []string{"session.finished"}},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookUpdate", ctx, "tenant", "id", &models.WebhookChanges{Events: []models.WebhookEvent{models.WebhookEventSessionFinished}, UpdatedAt: now}).
+					Return(&models.Webhook{ID: "id", Events: []models.WebhookEvent{models.WebhookEventSessionFinished}, Secret: "secret"}, nil).Once()
+			},
+			expected: Expected{&models.Webhook{ID: "id", Events: []models.WebhookEvent{models.WebhookEventSessionFinished}}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			webhook, err := service.UpdateWebhook(ctx, "tenant", "id", tc.req)
+			assert.Equal(t, tc.expected, Expected{webhook, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the webhook is not found",
+			requiredMocks: func() {
+				mock.On("WebhookDelete", ctx, "tenant", "id").Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrWebhookNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("WebhookDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			assert.Equal(t, tc.expected, service.DeleteWebhook(ctx, "tenant", "id"))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	paginator := query.Paginator{Page: 1, PerPage: 10}
+
+	type Expected struct {
+		deliveries []models.WebhookDelivery
+		count      int
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the webhook is not found",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, 0, NewErrWebhookNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(&models.Webhook{ID: "id"}, nil).Once()
+				mock.On("WebhookDeliveryList", ctx, "tenant", "id", paginator).
+					Return([]models.WebhookDelivery{{WebhookID: "id", Attempt: 1}}, 1, nil).Once()
+			},
+			expected: Expected{[]models.WebhookDelivery{{WebhookID: "id", Attempt: 1}}, 1, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			deliveries, count, err := service.ListWebhookDeliveries(ctx, "tenant", "id", paginator)
+			assert.Equal(t, tc.expected, Expected{deliveries, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWithWebhooks(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	locator := &mocksGeoIp.Locator{}
+
+	type Expected struct {
+		events []models.WebhookEvent
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		run           func(service Service) error
+		expected      Expected
+	}{
+		{
+			description: "does not emit when the mutation fails",
+			requiredMocks: func() {
+				mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(store.ErrNoDocuments).Once()
+			},
+			run: func(service Service) error {
+				return service.DeactivateSession(ctx, "uid")
+			},
+			expected: Expected{[]models.WebhookEvent{}, NewErrSessionNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "emits when a session is started",
+			requiredMocks: func() {
+				locator.On("GetPosition", gomock.Anything).Return(geoip.Position{}, nil).Once()
+				mock.On("SessionCreate", ctx, gomock.Anything).Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			run: func(service Service) error {
+				_, err := service.CreateSession(ctx, requests.SessionCreate{UID: "uid"})
+
+				return err
+			},
+			expected: Expected{[]models.WebhookEvent{models.WebhookEventSessionStarted}, nil},
+		},
+		{
+			description: "emits when a session is authenticated",
+			requiredMocks: func() {
+				mock.On("SessionSetAuthenticated", ctx, models.UID("uid"), true).Return(nil).Once()
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			run: func(service Service) error {
+				return service.SetSessionAuthenticated(ctx, "uid", true)
+			},
+			expected: Expected{[]models.WebhookEvent{models.WebhookEventSessionAuthenticated}, nil},
+		},
+		{
+			description: "emits when a session is finished",
+			requiredMocks: func() {
+				mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(nil).Once()
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			run: func(service Service) error {
+				return service.DeactivateSession(ctx, "uid")
+			},
+			expected: Expected{[]models.WebhookEvent{models.WebhookEventSessionFinished}, nil},
+		},
+		{
+			description: "emits when a device is accepted",
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "uid",
+					Name:     "edge-01",
+					TenantID: "tenant",
+					Identity: &models.DeviceIdentity{MAC: "aa:bb:cc:00:11:22"},
+					Status:   models.DeviceStatusPending,
+				}
+
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", MaxDevices: -1}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Twice()
+				mock.On("DeviceGetByMac", ctx, "aa:bb:cc:00:11:22", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByName", ctx, "edge-01", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+				mock.On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatusAccepted).Return(nil).Once()
+			},
+			run: func(service Service) error {
+				return service.UpdateDeviceStatus(ctx, "tenant", "uid", models.DeviceStatusAccepted)
+			},
+			expected: Expected{[]models.WebhookEvent{models.WebhookEventDeviceAccepted}, nil},
+		},
+		{
+			description: "emits when an online device goes offline",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant", Online: true}, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, false).Return(true, nil).Once()
+			},
+			run: func(service Service) error {
+				return service.OffineDevice(ctx, "uid", false)
+			},
+			expected: Expected{[]models.WebhookEvent{models.WebhookEventDeviceOffline}, nil},
+		},
+		{
+			description: "does not emit when an offline device goes offline",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant", Online: false}, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, false).Return(false, nil).Once()
+			},
+			run: func(service Service) error {
+				return service.OffineDevice(ctx, "uid", false)
+			},
+			expected: Expected{[]models.WebhookEvent{}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			clockMock.On("Now").Return(now).Maybe()
+
+			tasks := new(tasksRecorder)
+			service := WithWebhooks(NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, locator), mock, tasks)
+
+			err := tc.run(service)
+			assert.Equal(t, tc.expected, Expected{tasks.events(), err})
+
+			for _, task := range tasks.tasks {
+				assert.Equal(t, workers.TaskWebhookEvent, task.Type())
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWebhookedAuthDevice(t *testing.T) {
+	mock := new(mocks.Store)
+	serviceMock := new(mocksService.Service)
+
+	ctx := context.TODO()
+
+	req := requests.DeviceAuth{Hostname: "edge-01", TenantID: "tenant"}
+	uid := models.UID(deviceUID(req))
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      []models.WebhookEvent
+	}{
+		{
+			description: "does not emit for a registered device",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: string(uid), TenantID: "tenant"}, nil).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+			},
+			expected: []models.WebhookEvent{},
+		},
+		{
+			description: "emits pending when the registered device is still pending",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).
+					Return(&models.Device{UID: string(uid), TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: []models.WebhookEvent{models.WebhookEventDevicePending},
+		},
+		{
+			description: "emits only accepted when an auto-accept rule accepted the registered device",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).
+					Return(&models.Device{UID: string(uid), TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+			},
+			expected: []models.WebhookEvent{models.WebhookEventDeviceAccepted},
+		},
+		{
+			description: "does not emit when an auto-accept rule rejected the registered device",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, store.ErrNoDocuments).Once()
+				serviceMock.On("AuthDevice", ctx, req, "10.0.0.1").Return(&models.DeviceAuthResponse{UID: string(uid)}, nil).Once()
+				mock.On("DeviceGet", ctx, uid).
+					Return(&models.Device{UID: string(uid), TenantID: "tenant", Status: models.DeviceStatusRejected}, nil).Once()
+			},
+			expected: []models.WebhookEvent{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			tasks := new(tasksRecorder)
+			service := WithWebhooks(serviceMock, mock, tasks)
+
+			_, err := service.AuthDevice(ctx, req, "10.0.0.1")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tasks.events())
+		})
+	}
+
+	mock.AssertExpectations(t)
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AuditStore interface {
+	// AuditCreate saves an audit log, setting its ID.
+	AuditCreate(ctx context.Context, log *models.AuditLog) error
+
+	// AuditList lists the tenant's audit logs, from the newest to the oldest, matching the filters.
+	AuditList(ctx context.Context, tenant string, paginator query.Paginator, filters query.Filters) ([]models.AuditLog, int, error)
+}
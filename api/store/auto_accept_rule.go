@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AutoAcceptRuleStore interface {
+	// AutoAcceptRuleList lists the namespace's auto-accept rules in evaluation order.
+	AutoAcceptRuleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.AutoAcceptRule, int, error)
+	AutoAcceptRuleCreate(ctx context.Context, rule *models.AutoAcceptRule) error
+	AutoAcceptRuleGet(ctx context.Context, tenant, id string) (*models.AutoAcceptRule, error)
+	AutoAcceptRuleUpdate(ctx context.Context, tenant, id string, fields *models.AutoAcceptRuleFields) (*models.AutoAcceptRule, error)
+	AutoAcceptRuleDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type CertificateAuthorityStore interface {
+	CertificateAuthorityList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error)
+	CertificateAuthorityGet(ctx context.Context, tenant, fingerprint string) (*models.CertificateAuthority, error)
+	// CertificateAuthorityCreate creates a certificate authority. It returns [ErrDuplicate] when the namespace already
+	// trusts a certificate authority with the same fingerprint.
+	CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error
+	CertificateAuthorityDelete(ctx context.Context, tenant, fingerprint string) error
+}
@@ -28,7 +28,11 @@ type DeviceStore interface {
 	DeviceCreate(ctx context.Context, d models.Device, hostname string) error
 	DeviceRename(ctx context.Context, uid models.UID, hostname string) error
 	DeviceLookup(ctx context.Context, namespace, hostname string) (*models.Device, error)
-	DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error
+	// DeviceSetOnline sets the device's online status, reporting whether it changed. An online device is refreshed
+	// with timestamp as its last seen.
+	DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) (bool, error)
+	// DeviceExpireOnline sets offline the online devices last seen at, or before, lte, returning their UIDs.
+	DeviceExpireOnline(ctx context.Context, lte time.Time) ([]models.UID, error)
 	DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error
 	DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error
 	DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error
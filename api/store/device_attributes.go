@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceAttributesStore interface {
+	// DeviceSetAttributes sets the attributes on the device with the specified UID, keeping the attributes already set
+	// and not present on attributes.
+	// Returns ErrNoDocuments when no device matches the UID.
+	DeviceSetAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error
+
+	// DeviceUnsetAttribute removes the attribute with the specified key from the device with the specified UID.
+	// Returns ErrNoDocuments when no device matches the UID.
+	DeviceUnsetAttribute(ctx context.Context, uid models.UID, key string) error
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupStore interface {
+	DeviceGroupList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error)
+	DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error
+	DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	DeviceGroupUpdate(ctx context.Context, tenant, id string, fields *models.DeviceGroupFields) (*models.DeviceGroup, error)
+	DeviceGroupDelete(ctx context.Context, tenant, id string) error
+	// DeviceGroupMembers returns the UIDs of the namespace's devices that are direct members of any of the groups.
+	DeviceGroupMembers(ctx context.Context, tenant string, groups []models.DeviceGroup) ([]models.UID, error)
+}
@@ -0,0 +1,12 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceTargetStore interface {
+	// DeviceTargetList lists the accepted agentless targets reached through the device with the specified UID.
+	DeviceTargetList(ctx context.Context, gateway models.UID) ([]models.Device, error)
+}
@@ -0,0 +1,14 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceViaStore interface {
+	// DeviceSetVia sets the device which the connections jump from to reach the device with the specified UID. When
+	// via is nil, it is unset.
+	// Returns ErrNoDocuments when no device matches the UID.
+	DeviceSetVia(ctx context.Context, uid models.UID, via *models.DeviceVia) error
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type ForwardingRuleStore interface {
+	// ForwardingRuleList lists the namespace's forwarding rules in evaluation order.
+	ForwardingRuleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error)
+	ForwardingRuleCreate(ctx context.Context, rule *models.ForwardingRule) error
+	ForwardingRuleGet(ctx context.Context, tenant, id string) (*models.ForwardingRule, error)
+	ForwardingRuleUpdate(ctx context.Context, tenant, id string, fields *models.ForwardingRuleFields) (*models.ForwardingRule, error)
+	ForwardingRuleDelete(ctx context.Context, tenant, id string) error
+}
@@ -348,6 +348,297 @@ func (_m *Store) AnnouncementUpdate(ctx context.Context, announcement *models.An
 	return r0
 }
 
+// AuditCreate provides a mock function with given fields: ctx, log
+func (_m *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditList provides a mock function with given fields: ctx, tenant, paginator, filters
+func (_m *Store) AuditList(ctx context.Context, tenant string, paginator query.Paginator, filters query.Filters) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, tenant, paginator, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditList")
+	}
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, tenant, paginator, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters) []models.AuditLog); ok {
+		r0 = rf(ctx, tenant, paginator, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator, query.Filters) int); ok {
+		r1 = rf(ctx, tenant, paginator, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator, query.Filters) error); ok {
+		r2 = rf(ctx, tenant, paginator, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AutoAcceptRuleCreate provides a mock function with given fields: ctx, rule
+func (_m *Store) AutoAcceptRuleCreate(ctx context.Context, rule *models.AutoAcceptRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAcceptRuleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AutoAcceptRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AutoAcceptRuleDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) AutoAcceptRuleDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAcceptRuleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AutoAcceptRuleGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) AutoAcceptRuleGet(ctx context.Context, tenant string, id string) (*models.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAcceptRuleGet")
+	}
+
+	var r0 *models.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AutoAcceptRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AutoAcceptRuleList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) AutoAcceptRuleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.AutoAcceptRule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAcceptRuleList")
+	}
+
+	var r0 []models.AutoAcceptRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.AutoAcceptRule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AutoAcceptRuleUpdate provides a mock function with given fields: ctx, tenant, id, fields
+func (_m *Store) AutoAcceptRuleUpdate(ctx context.Context, tenant string, id string, fields *models.AutoAcceptRuleFields) (*models.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAcceptRuleUpdate")
+	}
+
+	var r0 *models.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.AutoAcceptRuleFields) (*models.AutoAcceptRule, error)); ok {
+		return rf(ctx, tenant, id, fields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.AutoAcceptRuleFields) *models.AutoAcceptRule); ok {
+		r0 = rf(ctx, tenant, id, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.AutoAcceptRuleFields) error); ok {
+		r1 = rf(ctx, tenant, id, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateAuthorityCreate provides a mock function with given fields: ctx, ca
+func (_m *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	ret := _m.Called(ctx, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertificateAuthorityCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CertificateAuthority) error); ok {
+		r0 = rf(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateAuthorityDelete provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Store) CertificateAuthorityDelete(ctx context.Context, tenant string, fingerprint string) error {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertificateAuthorityDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateAuthorityGet provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Store) CertificateAuthorityGet(ctx context.Context, tenant string, fingerprint string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertificateAuthorityGet")
+	}
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.CertificateAuthority, error)); ok {
+		return rf(ctx, tenant, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateAuthorityList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) CertificateAuthorityList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertificateAuthorityList")
+	}
+
+	var r0 []models.CertificateAuthority
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.CertificateAuthority, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteCodes provides a mock function with given fields: ctx, username
 func (_m *Store) DeleteCodes(ctx context.Context, username string) error {
 	ret := _m.Called(ctx, username)
@@ -512,6 +803,36 @@ func (_m *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// DeviceExpireOnline provides a mock function with given fields: ctx, lte
+func (_m *Store) DeviceExpireOnline(ctx context.Context, lte time.Time) ([]models.UID, error) {
+	ret := _m.Called(ctx, lte)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceExpireOnline")
+	}
+
+	var r0 []models.UID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.UID, error)); ok {
+		return rf(ctx, lte)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.UID); ok {
+		r0 = rf(ctx, lte)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lte)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceGet provides a mock function with given fields: ctx, uid
 func (_m *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
 	ret := _m.Called(ctx, uid)
@@ -699,6 +1020,169 @@ func (_m *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, in
 	return r0, r1, r2
 }
 
+// DeviceGroupCreate provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	ret := _m.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupGet")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) DeviceGroupList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupList")
+	}
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceGroupMembers provides a mock function with given fields: ctx, tenant, groups
+func (_m *Store) DeviceGroupMembers(ctx context.Context, tenant string, groups []models.DeviceGroup) ([]models.UID, error) {
+	ret := _m.Called(ctx, tenant, groups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupMembers")
+	}
+
+	var r0 []models.UID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.DeviceGroup) ([]models.UID, error)); ok {
+		return rf(ctx, tenant, groups)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.DeviceGroup) []models.UID); ok {
+		r0 = rf(ctx, tenant, groups)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []models.DeviceGroup) error); ok {
+		r1 = rf(ctx, tenant, groups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupUpdate provides a mock function with given fields: ctx, tenant, id, fields
+func (_m *Store) DeviceGroupUpdate(ctx context.Context, tenant string, id string, fields *models.DeviceGroupFields) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGroupUpdate")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupFields) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id, fields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupFields) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.DeviceGroupFields) error); ok {
+		r1 = rf(ctx, tenant, id, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceList provides a mock function with given fields: ctx, status, pagination, filters, sorter, acceptable
 func (_m *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagination query.Paginator, filters query.Filters, sorter query.Sorter, acceptable store.DeviceAcceptable) ([]models.Device, int, error) {
 	ret := _m.Called(ctx, status, pagination, filters, sorter, acceptable)
@@ -981,22 +1465,50 @@ func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname stri
 	return r0
 }
 
+// DeviceSetAttributes provides a mock function with given fields: ctx, uid, attributes
+func (_m *Store) DeviceSetAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, uid, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, timestamp, online
-func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error {
+func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) (bool, error) {
 	ret := _m.Called(ctx, uid, timestamp, online)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeviceSetOnline")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, bool) error); ok {
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, bool) (bool, error)); ok {
+		return rf(ctx, uid, timestamp, online)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, bool) bool); ok {
 		r0 = rf(ctx, uid, timestamp, online)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, time.Time, bool) error); ok {
+		r1 = rf(ctx, uid, timestamp, online)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeviceSetPosition provides a mock function with given fields: ctx, uid, position
@@ -1052,6 +1564,72 @@ func (_m *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []strin
 	return r0, r1, r2
 }
 
+// DeviceSetVia provides a mock function with given fields: ctx, uid, via
+func (_m *Store) DeviceSetVia(ctx context.Context, uid models.UID, via *models.DeviceVia) error {
+	ret := _m.Called(ctx, uid, via)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetVia")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceVia) error); ok {
+		r0 = rf(ctx, uid, via)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceTargetList provides a mock function with given fields: ctx, gateway
+func (_m *Store) DeviceTargetList(ctx context.Context, gateway models.UID) ([]models.Device, error) {
+	ret := _m.Called(ctx, gateway)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceTargetList")
+	}
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.Device, error)); ok {
+		return rf(ctx, gateway)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.Device); ok {
+		r0 = rf(ctx, gateway)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, gateway)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceUnsetAttribute provides a mock function with given fields: ctx, uid, key
+func (_m *Store) DeviceUnsetAttribute(ctx context.Context, uid models.UID, key string) error {
+	ret := _m.Called(ctx, uid, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceUnsetAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceUpdate provides a mock function with given fields: ctx, tenant, uid, name, publicURL
 func (_m *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
 	ret := _m.Called(ctx, tenant, uid, name, publicURL)
@@ -1338,65 +1916,198 @@ func (_m *Store) FirewallRulePullTag(ctx context.Context, id string, tag string)
 	return r0
 }
 
-// FirewallRulePushTag provides a mock function with given fields: ctx, id, tag
-func (_m *Store) FirewallRulePushTag(ctx context.Context, id string, tag string) error {
-	ret := _m.Called(ctx, id, tag)
+// FirewallRulePushTag provides a mock function with given fields: ctx, id, tag
+func (_m *Store) FirewallRulePushTag(ctx context.Context, id string, tag string) error {
+	ret := _m.Called(ctx, id, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRulePushTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleSetTags provides a mock function with given fields: ctx, id, tags
+func (_m *Store) FirewallRuleSetTags(ctx context.Context, id string, tags []string) error {
+	ret := _m.Called(ctx, id, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleSetTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, id, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleUpdate provides a mock function with given fields: ctx, id, rule
+func (_m *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, id, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleUpdate")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, id, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) *models.FirewallRule); ok {
+		r0 = rf(ctx, id, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FirewallRuleUpdate) error); ok {
+		r1 = rf(ctx, id, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForwardingRuleCreate provides a mock function with given fields: ctx, rule
+func (_m *Store) ForwardingRuleCreate(ctx context.Context, rule *models.ForwardingRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForwardingRuleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ForwardingRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForwardingRuleDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) ForwardingRuleDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForwardingRuleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForwardingRuleGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) ForwardingRuleGet(ctx context.Context, tenant string, id string) (*models.ForwardingRule, error) {
+	ret := _m.Called(ctx, tenant, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRulePushTag")
+		panic("no return value specified for ForwardingRuleGet")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, id, tag)
+	var r0 *models.ForwardingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ForwardingRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ForwardingRule)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FirewallRuleSetTags provides a mock function with given fields: ctx, id, tags
-func (_m *Store) FirewallRuleSetTags(ctx context.Context, id string, tags []string) error {
-	ret := _m.Called(ctx, id, tags)
+// ForwardingRuleList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) ForwardingRuleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleSetTags")
+		panic("no return value specified for ForwardingRuleList")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
-		r0 = rf(ctx, id, tags)
+	var r0 []models.ForwardingRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.ForwardingRule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, paginator)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ForwardingRule)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// FirewallRuleUpdate provides a mock function with given fields: ctx, id, rule
-func (_m *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
-	ret := _m.Called(ctx, id, rule)
+// ForwardingRuleUpdate provides a mock function with given fields: ctx, tenant, id, fields
+func (_m *Store) ForwardingRuleUpdate(ctx context.Context, tenant string, id string, fields *models.ForwardingRuleFields) (*models.ForwardingRule, error) {
+	ret := _m.Called(ctx, tenant, id, fields)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleUpdate")
+		panic("no return value specified for ForwardingRuleUpdate")
 	}
 
-	var r0 *models.FirewallRule
+	var r0 *models.ForwardingRule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
-		return rf(ctx, id, rule)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ForwardingRuleFields) (*models.ForwardingRule, error)); ok {
+		return rf(ctx, tenant, id, fields)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) *models.FirewallRule); ok {
-		r0 = rf(ctx, id, rule)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ForwardingRuleFields) *models.ForwardingRule); ok {
+		r0 = rf(ctx, tenant, id, fields)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.FirewallRule)
+			r0 = ret.Get(0).(*models.ForwardingRule)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, models.FirewallRuleUpdate) error); ok {
-		r1 = rf(ctx, id, rule)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.ForwardingRuleFields) error); ok {
+		r1 = rf(ctx, tenant, id, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2332,9 +3043,9 @@ func (_m *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) e
 	return r0
 }
 
-// SessionDeleteRecordFrameByDate provides a mock function with given fields: ctx, lte
-func (_m *Store) SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Time) (int64, int64, error) {
-	ret := _m.Called(ctx, lte)
+// SessionDeleteRecordFrameByDate provides a mock function with given fields: ctx, tenant, lte
+func (_m *Store) SessionDeleteRecordFrameByDate(ctx context.Context, tenant string, lte time.Time) (int64, int64, error) {
+	ret := _m.Called(ctx, tenant, lte)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SessionDeleteRecordFrameByDate")
@@ -2343,23 +3054,78 @@ func (_m *Store) SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Ti
 	var r0 int64
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, int64, error)); ok {
-		return rf(ctx, lte)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, int64, error)); ok {
+		return rf(ctx, tenant, lte)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
-		r0 = rf(ctx, lte)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, tenant, lte)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time) int64); ok {
-		r1 = rf(ctx, lte)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) int64); ok {
+		r1 = rf(ctx, tenant, lte)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
-		r2 = rf(ctx, lte)
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, tenant, lte)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SessionEventCreate provides a mock function with given fields: ctx, event
+func (_m *Store) SessionEventCreate(ctx context.Context, event *models.SessionEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SessionEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionEventList provides a mock function with given fields: ctx, tenant, uid, paginator
+func (_m *Store) SessionEventList(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error) {
+	ret := _m.Called(ctx, tenant, uid, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEventList")
+	}
+
+	var r0 []models.SessionEvent
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.SessionEvent, int, error)); ok {
+		return rf(ctx, tenant, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.SessionEvent); ok {
+		r0 = rf(ctx, tenant, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, uid, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -2489,6 +3255,42 @@ func (_m *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, au
 	return r0
 }
 
+// SessionSetCloseReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Store) SessionSetCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionSetCloseReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.SessionCloseReason) error); ok {
+		r0 = rf(ctx, uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionSetClosedBy provides a mock function with given fields: ctx, uid, user
+func (_m *Store) SessionSetClosedBy(ctx context.Context, uid models.UID, user string) error {
+	ret := _m.Called(ctx, uid, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionSetClosedBy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SessionSetLastSeen provides a mock function with given fields: ctx, uid
 func (_m *Store) SessionSetLastSeen(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -2992,6 +3794,224 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 	return r0
 }
 
+// WebhookCreate provides a mock function with given fields: ctx, webhook
+func (_m *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryCreate provides a mock function with given fields: ctx, delivery
+func (_m *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryList provides a mock function with given fields: ctx, tenant, id, paginator
+func (_m *Store) WebhookDeliveryList(ctx context.Context, tenant string, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, id, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryList")
+	}
+
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, id, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, id, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, id, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookGet")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) WebhookList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookList")
+	}
+
+	var r0 []models.Webhook
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookListByEvent provides a mock function with given fields: ctx, tenant, event
+func (_m *Store) WebhookListByEvent(ctx context.Context, tenant string, event models.WebhookEvent) ([]models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookListByEvent")
+	}
+
+	var r0 []models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.WebhookEvent) ([]models.Webhook, error)); ok {
+		return rf(ctx, tenant, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.WebhookEvent) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.WebhookEvent) error); ok {
+		r1 = rf(ctx, tenant, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUpdate provides a mock function with given fields: ctx, tenant, id, changes
+func (_m *Store) WebhookUpdate(ctx context.Context, tenant string, id string, changes *models.WebhookChanges) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookUpdate")
+	}
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.WebhookChanges) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id, changes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.WebhookChanges) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.WebhookChanges) error); ok {
+		r1 = rf(ctx, tenant, id, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	res, err := s.db.Collection("audit_logs").InsertOne(ctx, log)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		log.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) AuditList(ctx context.Context, tenant string, paginator query.Paginator, filters query.Filters) ([]models.AuditLog, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	queryMatch, err := queries.FromFilters(&filters)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queryMatch...)
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("audit_logs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("audit_logs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]models.AuditLog, 0)
+	for cursor.Next(ctx) {
+		log := new(models.AuditLog)
+		if err := cursor.Decode(log); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return logs, count, nil
+}
@@ -0,0 +1,121 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditList(t *testing.T) {
+	type Expected struct {
+		actions []models.AuditAction
+		count   int
+		err     error
+	}
+
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	logs := []models.AuditLog{
+		{
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			Action:    models.AuditActionDeviceUpdateStatus,
+			Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+			CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			Action:    models.AuditActionDeviceDelete,
+			Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+			CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			Action:    models.AuditActionNamespaceUpdate,
+			Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "00000000-0000-4000-0000-000000000000"},
+			CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			TenantID:  "00000000-0000-4001-0000-000000000000",
+			Action:    models.AuditActionNamespaceUpdate,
+			Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "00000000-0000-4001-0000-000000000000"},
+			CreatedAt: time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		paginator   query.Paginator
+		filters     query.Filters
+		expected    Expected
+	}{
+		{
+			description: "succeeds listing the tenant's audit logs from the newest",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			paginator:   query.Paginator{Page: 1, PerPage: 10},
+			filters:     query.Filters{},
+			expected: Expected{
+				actions: []models.AuditAction{
+					models.AuditActionNamespaceUpdate,
+					models.AuditActionDeviceDelete,
+					models.AuditActionDeviceUpdateStatus,
+				},
+				count: 3,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the tenant's audit logs matching the filters",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			paginator:   query.Paginator{Page: 1, PerPage: 1},
+			filters: query.Filters{
+				Data: []query.Filter{
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "target.type",
+							Operator: "eq",
+							Value:    "device",
+						},
+					},
+				},
+			},
+			expected: Expected{
+				actions: []models.AuditAction{models.AuditActionDeviceDelete},
+				count:   2,
+				err:     nil,
+			},
+		},
+	}
+
+	for i := range logs {
+		assert.NoError(t, mongostore.AuditCreate(ctx, &logs[i]))
+		assert.NotEmpty(t, logs[i].ID)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			list, count, err := mongostore.AuditList(ctx, tc.tenant, tc.paginator, tc.filters)
+
+			actions := make([]models.AuditAction, 0, len(list))
+			for _, log := range list {
+				actions = append(actions, log.Action)
+			}
+
+			assert.Equal(t, tc.expected, Expected{actions, count, err})
+		})
+	}
+}
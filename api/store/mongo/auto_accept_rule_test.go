@@ -0,0 +1,87 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoAcceptRuleList(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	rules := []models.AutoAcceptRule{
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+				Priority: 2,
+				Action:   models.AutoAcceptRuleActionReject,
+				Active:   true,
+			},
+		},
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+				Priority: 1,
+				Action:   models.AutoAcceptRuleActionAccept,
+				Active:   true,
+				Filter:   models.AutoAcceptFilter{SourceIP: "10.0.0.0/8"},
+			},
+		},
+		{
+			TenantID: "00000000-0000-4001-0000-000000000000",
+			AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+				Priority: 1,
+				Action:   models.AutoAcceptRuleActionAccept,
+				Active:   true,
+			},
+		},
+	}
+
+	for i := range rules {
+		assert.NoError(t, mongostore.AutoAcceptRuleCreate(ctx, &rules[i]))
+		assert.NotEmpty(t, rules[i].ID)
+	}
+
+	list, count, err := mongostore.AutoAcceptRuleList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, rules[1].ID, list[0].ID)
+	assert.Equal(t, rules[0].ID, list[1].ID)
+}
+
+func TestAutoAcceptRuleDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	rule := &models.AutoAcceptRule{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		AutoAcceptRuleFields: models.AutoAcceptRuleFields{
+			Action: models.AutoAcceptRuleActionAccept,
+		},
+	}
+	assert.NoError(t, mongostore.AutoAcceptRuleCreate(ctx, rule))
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.AutoAcceptRuleDelete(ctx, "00000000-0000-4001-0000-000000000000", rule.ID))
+	assert.NoError(t, mongostore.AutoAcceptRuleDelete(ctx, "00000000-0000-4000-0000-000000000000", rule.ID))
+
+	_, err := mongostore.AutoAcceptRuleGet(ctx, "00000000-0000-4000-0000-000000000000", rule.ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
@@ -0,0 +1,80 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) CertificateAuthorityList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.CertificateAuthority, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("certificate_authorities"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": 1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("certificate_authorities").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	cas := make([]models.CertificateAuthority, 0)
+	for cursor.Next(ctx) {
+		ca := new(models.CertificateAuthority)
+		if err := cursor.Decode(ca); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		cas = append(cas, *ca)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return cas, count, nil
+}
+
+func (s *Store) CertificateAuthorityGet(ctx context.Context, tenant, fingerprint string) (*models.CertificateAuthority, error) {
+	ca := new(models.CertificateAuthority)
+	if err := s.db.Collection("certificate_authorities").FindOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint}).Decode(ca); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return ca, nil
+}
+
+func (s *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	if _, err := s.db.Collection("certificate_authorities").InsertOne(ctx, ca); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) CertificateAuthorityDelete(ctx context.Context, tenant, fingerprint string) error {
+	res, err := s.db.Collection("certificate_authorities").DeleteOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateAuthorityList(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	cas := []models.CertificateAuthority{
+		{TenantID: "00000000-0000-4000-0000-000000000000", Fingerprint: "fingerprint-1", Name: "ca-1"},
+		{TenantID: "00000000-0000-4000-0000-000000000000", Fingerprint: "fingerprint-2", Name: "ca-2"},
+		{TenantID: "00000000-0000-4001-0000-000000000000", Fingerprint: "fingerprint-1", Name: "ca-1"},
+	}
+
+	for i := range cas {
+		assert.NoError(t, mongostore.CertificateAuthorityCreate(ctx, &cas[i]))
+	}
+
+	list, count, err := mongostore.CertificateAuthorityList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, list, 2)
+}
+
+func TestCertificateAuthorityDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	ca := &models.CertificateAuthority{TenantID: "00000000-0000-4000-0000-000000000000", Fingerprint: "fingerprint", Name: "ca"}
+	assert.NoError(t, mongostore.CertificateAuthorityCreate(ctx, ca))
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.CertificateAuthorityDelete(ctx, "00000000-0000-4001-0000-000000000000", "fingerprint"))
+	assert.NoError(t, mongostore.CertificateAuthorityDelete(ctx, "00000000-0000-4000-0000-000000000000", "fingerprint"))
+
+	_, err := mongostore.CertificateAuthorityGet(ctx, "00000000-0000-4000-0000-000000000000", "fingerprint")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
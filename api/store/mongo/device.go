@@ -325,11 +325,14 @@ func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*
 	return device, nil
 }
 
-func (s *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error {
+func (s *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) (bool, error) {
 	if !online {
-		_, err := s.db.Collection("connected_devices").DeleteMany(ctx, bson.M{"uid": uid})
+		res, err := s.db.Collection("connected_devices").DeleteMany(ctx, bson.M{"uid": uid})
+		if err != nil {
+			return false, FromMongoError(err)
+		}
 
-		return FromMongoError(err)
+		return res.DeletedCount > 0, nil
 	}
 
 	collOptions := writeconcern.W1()
@@ -343,12 +346,12 @@ func (s *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp t
 				},
 			}, updateOptions)
 	if result.Err() != nil {
-		return FromMongoError(result.Err())
+		return false, FromMongoError(result.Err())
 	}
 
 	device := new(models.Device)
 	if err := result.Decode(&device); err != nil {
-		return FromMongoError(err)
+		return false, FromMongoError(err)
 	}
 
 	cd := &models.ConnectedDevice{
@@ -361,14 +364,49 @@ func (s *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp t
 	updated := cd.LastSeen.Before(timestamp)
 	if updated {
 		replaceOptions := options.Replace().SetUpsert(true)
-		_, err := s.db.Collection("connected_devices", options.Collection().SetWriteConcern(collOptions)).
+		res, err := s.db.Collection("connected_devices", options.Collection().SetWriteConcern(collOptions)).
 			ReplaceOne(ctx, bson.M{"uid": uid}, &cd, replaceOptions)
 		if err != nil {
-			return FromMongoError(err)
+			return false, FromMongoError(err)
 		}
+
+		// The device was offline when it has no connected device document to replace.
+		return res.UpsertedCount > 0, nil
 	}
 
-	return nil
+	return false, nil
+}
+
+func (s *Store) DeviceExpireOnline(ctx context.Context, lte time.Time) ([]models.UID, error) {
+	cursor, err := s.db.Collection("connected_devices").Find(ctx, bson.M{"last_seen": bson.M{"$lte": lte}})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	uids := make([]models.UID, 0)
+	for cursor.Next(ctx) {
+		cd := new(models.ConnectedDevice)
+		if err := cursor.Decode(cd); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		// NOTICE: The last seen is matched again, so a device refreshed after it was found is kept online.
+		res, err := s.db.Collection("connected_devices").DeleteOne(ctx, bson.M{"uid": cd.UID, "last_seen": cd.LastSeen})
+		if err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		if res.DeletedCount > 0 {
+			uids = append(uids, models.UID(cd.UID))
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return uids, nil
 }
 
 func (s *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error {
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceSetAttributes(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		attributes  map[string]string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device doesn't exist",
+			uid:         models.UID("nonexistent"),
+			attributes:  map[string]string{"site": "lisbon"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device exists",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			attributes:  map[string]string{"site": "lisbon", "rack": "r1"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetAttributes(context.TODO(), tc.uid, tc.attributes)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGet(context.TODO(), tc.uid)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.attributes, device.Attributes)
+			}
+		})
+	}
+}
+
+func TestDeviceUnsetAttribute(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		key         string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device doesn't exist",
+			uid:         models.UID("nonexistent"),
+			key:         "site",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device exists",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			key:         "site",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceUnsetAttribute(context.TODO(), tc.uid, tc.key)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
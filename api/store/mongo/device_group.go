@@ -0,0 +1,180 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceGroupList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.DeviceGroup, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_groups"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"name": 1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_groups").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := make([]models.DeviceGroup, 0)
+	for cursor.Next(ctx) {
+		group := new(models.DeviceGroup)
+		if err := cursor.Decode(group); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		groups = append(groups, *group)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return groups, count, nil
+}
+
+func (s *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	res, err := s.db.Collection("device_groups").InsertOne(ctx, group)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		group.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	group := new(models.DeviceGroup)
+	if err := s.db.Collection("device_groups").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(group); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupUpdate(ctx context.Context, tenant, id string, fields *models.DeviceGroupFields) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":        fields.Name,
+			"description": fields.Description,
+			"parent":      fields.Parent,
+			"devices":     fields.Devices,
+			"filter":      fields.Filter,
+			"updated_at":  clock.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := s.db.Collection("device_groups").FindOneAndUpdate(ctx, bson.M{"_id": objID, "tenant_id": tenant}, update, opts)
+	if result.Err() != nil {
+		return nil, FromMongoError(result.Err())
+	}
+
+	group := new(models.DeviceGroup)
+	if err := result.Decode(group); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	res, err := s.db.Collection("device_groups").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupMembers(ctx context.Context, tenant string, groups []models.DeviceGroup) ([]models.UID, error) {
+	criteria := make([]bson.M, 0)
+	for _, group := range groups {
+		if len(group.Devices) > 0 {
+			criteria = append(criteria, bson.M{"uid": bson.M{"$in": group.Devices}})
+		}
+
+		if group.Filter.IsEmpty() {
+			continue
+		}
+
+		filter := bson.M{}
+		if len(group.Filter.Tags) > 0 {
+			filter["tags"] = bson.M{"$in": group.Filter.Tags}
+		}
+
+		for key, value := range group.Filter.Attributes {
+			filter["attributes."+key] = value
+		}
+
+		criteria = append(criteria, filter)
+	}
+
+	uids := make([]models.UID, 0)
+	if len(criteria) == 0 {
+		return uids, nil
+	}
+
+	opts := options.Find().SetProjection(bson.M{"uid": 1})
+	cursor, err := s.db.Collection("devices").Find(ctx, bson.M{"tenant_id": tenant, "$or": criteria}, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		device := new(models.Device)
+		if err := cursor.Decode(device); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		uids = append(uids, models.UID(device.UID))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return uids, nil
+}
@@ -0,0 +1,127 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceGroupList(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	groups := []models.DeviceGroup{
+		{
+			TenantID:          "00000000-0000-4000-0000-000000000000",
+			DeviceGroupFields: models.DeviceGroupFields{Name: "lisbon"},
+		},
+		{
+			TenantID:          "00000000-0000-4000-0000-000000000000",
+			DeviceGroupFields: models.DeviceGroupFields{Name: "europe"},
+		},
+		{
+			TenantID:          "00000000-0000-4001-0000-000000000000",
+			DeviceGroupFields: models.DeviceGroupFields{Name: "europe"},
+		},
+	}
+
+	for i := range groups {
+		assert.NoError(t, mongostore.DeviceGroupCreate(ctx, &groups[i]))
+		assert.NotEmpty(t, groups[i].ID)
+	}
+
+	list, count, err := mongostore.DeviceGroupList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, groups[1].ID, list[0].ID)
+	assert.Equal(t, groups[0].ID, list[1].ID)
+}
+
+func TestDeviceGroupDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	group := &models.DeviceGroup{
+		TenantID:          "00000000-0000-4000-0000-000000000000",
+		DeviceGroupFields: models.DeviceGroupFields{Name: "lisbon"},
+	}
+	assert.NoError(t, mongostore.DeviceGroupCreate(ctx, group))
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceGroupDelete(ctx, "00000000-0000-4001-0000-000000000000", group.ID))
+	assert.NoError(t, mongostore.DeviceGroupDelete(ctx, "00000000-0000-4000-0000-000000000000", group.ID))
+
+	_, err := mongostore.DeviceGroupGet(ctx, "00000000-0000-4000-0000-000000000000", group.ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestDeviceGroupMembers(t *testing.T) {
+	cases := []struct {
+		description string
+		groups      []models.DeviceGroup
+		expected    []models.UID
+	}{
+		{
+			description: "returns no device when the groups have no members",
+			groups: []models.DeviceGroup{
+				{DeviceGroupFields: models.DeviceGroupFields{Name: "empty"}},
+			},
+			expected: []models.UID{},
+		},
+		{
+			description: "returns the static and dynamic members",
+			groups: []models.DeviceGroup{
+				{
+					DeviceGroupFields: models.DeviceGroupFields{
+						Name:    "static",
+						Devices: []string{"4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"},
+					},
+				},
+				{
+					DeviceGroupFields: models.DeviceGroupFields{
+						Name:   "dynamic",
+						Filter: models.DeviceGroupFilter{Tags: []string{"tag-1"}},
+					},
+				},
+			},
+			expected: []models.UID{
+				"5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+				"4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e",
+				"2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			uids, err := mongostore.DeviceGroupMembers(context.TODO(), "00000000-0000-4000-0000-000000000000", tc.groups)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, uids)
+		})
+	}
+}
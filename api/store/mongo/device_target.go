@@ -0,0 +1,25 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceTargetList(ctx context.Context, gateway models.UID) ([]models.Device, error) {
+	filter := bson.M{"agentless": true, "via.device": gateway, "status": models.DeviceStatusAccepted}
+
+	cursor, err := s.db.Collection("devices").Find(ctx, filter)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	targets := make([]models.Device, 0)
+	if err := cursor.All(ctx, &targets); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return targets, nil
+}
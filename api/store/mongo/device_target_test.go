@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceTargetList(t *testing.T) {
+	cases := []struct {
+		description string
+		gateway     models.UID
+		expected    []string
+	}{
+		{
+			description: "succeeds without targets when the device is not a gateway",
+			gateway:     models.UID("nonexistent"),
+			expected:    []string{},
+		},
+		{
+			description: "succeeds with the accepted targets reached through the gateway",
+			gateway:     models.UID("gateway"),
+			expected:    []string{"switch"},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			defer fixtures.Teardown() // nolint: errcheck
+
+			_, err := db.Client().Database("test").Collection("devices").InsertMany(context.TODO(), []interface{}{
+				models.Device{UID: "switch", Status: models.DeviceStatusAccepted, Agentless: true, Via: &models.DeviceVia{Device: "gateway", Host: "192.168.0.2", Port: 22}},
+				models.Device{UID: "plc", Status: models.DeviceStatusPending, Agentless: true, Via: &models.DeviceVia{Device: "gateway", Host: "192.168.0.3", Port: 22}},
+				models.Device{UID: "router", Status: models.DeviceStatusAccepted, Via: &models.DeviceVia{Device: "gateway", Host: "192.168.0.1", Port: 22}},
+			})
+			assert.NoError(t, err)
+
+			targets, err := mongostore.DeviceTargetList(context.TODO(), tc.gateway)
+			assert.NoError(t, err)
+
+			uids := []string{}
+			for _, target := range targets {
+				uids = append(uids, target.UID)
+			}
+
+			assert.Equal(t, tc.expected, uids)
+		})
+	}
+}
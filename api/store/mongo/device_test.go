@@ -13,6 +13,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestDeviceList(t *testing.T) {
@@ -1248,26 +1249,31 @@ func TestDeviceUpdateLastSeen(t *testing.T) {
 }
 
 func TestDeviceSetOnline(t *testing.T) {
+	type Expected struct {
+		changed bool
+		err     error
+	}
+
 	cases := []struct {
 		description string
 		uid         models.UID
 		online      bool
 		fixtures    []string
-		expected    error
+		expected    Expected
 	}{
 		{
 			description: "succeeds when UID is valid and online is true",
 			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
 			online:      true,
 			fixtures:    []string{fixtures.FixtureDevices},
-			expected:    nil,
+			expected:    Expected{changed: true, err: nil},
 		},
 		{
 			description: "succeeds when UID is valid and online is false",
 			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
 			online:      false,
 			fixtures:    []string{fixtures.FixtureDevices},
-			expected:    nil,
+			expected:    Expected{changed: false, err: nil},
 		},
 	}
 
@@ -1282,8 +1288,58 @@ func TestDeviceSetOnline(t *testing.T) {
 			assert.NoError(t, fixtures.Apply(tc.fixtures...))
 			defer fixtures.Teardown() // nolint: errcheck
 
-			err := mongostore.DeviceSetOnline(context.TODO(), tc.uid, time.Now(), tc.online)
-			assert.Equal(t, tc.expected, err)
+			changed, err := mongostore.DeviceSetOnline(context.TODO(), tc.uid, time.Now(), tc.online)
+			assert.Equal(t, tc.expected, Expected{changed, err})
+		})
+	}
+}
+
+func TestDeviceExpireOnline(t *testing.T) {
+	type Expected struct {
+		uids []models.UID
+		err  error
+	}
+
+	cases := []struct {
+		description string
+		lte         time.Time
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds to keep online the devices last seen after lte",
+			lte:         time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDevices, fixtures.FixtureConnectedDevices},
+			expected:    Expected{uids: []models.UID{}, err: nil},
+		},
+		{
+			description: "succeeds to set offline the devices last seen before lte",
+			lte:         time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDevices, fixtures.FixtureConnectedDevices},
+			expected: Expected{
+				uids: []models.UID{"2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"},
+				err:  nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			uids, err := mongostore.DeviceExpireOnline(context.TODO(), tc.lte)
+			assert.Equal(t, tc.expected, Expected{uids, err})
+
+			count, err := db.Client().Database("test").Collection("connected_devices").CountDocuments(context.TODO(), bson.M{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1-len(tc.expected.uids)), count)
 		})
 	}
 }
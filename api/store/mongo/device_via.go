@@ -0,0 +1,33 @@
+package mongo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceSetVia(ctx context.Context, uid models.UID, via *models.DeviceVia) error {
+	update := bson.M{"$set": bson.M{"via": via}}
+	if via == nil {
+		update = bson.M{"$unset": bson.M{"via": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
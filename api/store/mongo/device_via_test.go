@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceSetVia(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		via         *models.DeviceVia
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device doesn't exist",
+			uid:         models.UID("nonexistent"),
+			via:         &models.DeviceVia{Device: "gateway", Host: "192.168.0.10", Port: 22},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device exists",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			via:         &models.DeviceVia{Device: "gateway", Host: "192.168.0.10", Port: 22},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the via is unset",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			via:         nil,
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetVia(context.TODO(), tc.uid, tc.via)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGet(context.TODO(), tc.uid)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.via, device.Via)
+			}
+		})
+	}
+}
@@ -65,10 +65,15 @@ func (s *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRul
 		return FromMongoError(err)
 	}
 
-	if _, err := s.db.Collection("firewall_rules").InsertOne(ctx, &rule); err != nil {
+	res, err := s.db.Collection("firewall_rules").InsertOne(ctx, &rule)
+	if err != nil {
 		return FromMongoError(err)
 	}
 
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		rule.ID = id.Hex()
+	}
+
 	return nil
 }
 
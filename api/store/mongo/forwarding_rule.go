@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) ForwardingRuleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.ForwardingRule, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("forwarding_rules"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.D{{Key: "priority", Value: 1}, {Key: "created_at", Value: 1}}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("forwarding_rules").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := make([]models.ForwardingRule, 0)
+	for cursor.Next(ctx) {
+		rule := new(models.ForwardingRule)
+		if err := cursor.Decode(rule); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return rules, count, nil
+}
+
+func (s *Store) ForwardingRuleCreate(ctx context.Context, rule *models.ForwardingRule) error {
+	res, err := s.db.Collection("forwarding_rules").InsertOne(ctx, rule)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		rule.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) ForwardingRuleGet(ctx context.Context, tenant, id string) (*models.ForwardingRule, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	rule := new(models.ForwardingRule)
+	if err := s.db.Collection("forwarding_rules").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(rule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) ForwardingRuleUpdate(ctx context.Context, tenant, id string, fields *models.ForwardingRuleFields) (*models.ForwardingRule, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"priority":   fields.Priority,
+			"action":     fields.Action,
+			"active":     fields.Active,
+			"host":       fields.Host,
+			"ports":      fields.Ports,
+			"filter":     fields.Filter,
+			"updated_at": clock.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := s.db.Collection("forwarding_rules").FindOneAndUpdate(ctx, bson.M{"_id": objID, "tenant_id": tenant}, update, opts)
+	if result.Err() != nil {
+		return nil, FromMongoError(result.Err())
+	}
+
+	rule := new(models.ForwardingRule)
+	if err := result.Decode(rule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) ForwardingRuleDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	res, err := s.db.Collection("forwarding_rules").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardingRuleList(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	rules := []models.ForwardingRule{
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Priority: 2,
+				Action:   models.ForwardingRuleActionDeny,
+				Active:   true,
+				Host:     "0.0.0.0/0",
+				Ports:    models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+		},
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Priority: 1,
+				Action:   models.ForwardingRuleActionAllow,
+				Active:   true,
+				Host:     "10.0.0.0/8",
+				Ports:    models.ForwardingPortRange{Start: 443, End: 443},
+			},
+		},
+		{
+			TenantID: "00000000-0000-4001-0000-000000000000",
+			ForwardingRuleFields: models.ForwardingRuleFields{
+				Priority: 1,
+				Action:   models.ForwardingRuleActionDeny,
+				Active:   true,
+				Host:     "0.0.0.0/0",
+				Ports:    models.ForwardingPortRange{Start: 1, End: 65535},
+			},
+		},
+	}
+
+	for i := range rules {
+		assert.NoError(t, mongostore.ForwardingRuleCreate(ctx, &rules[i]))
+		assert.NotEmpty(t, rules[i].ID)
+	}
+
+	list, count, err := mongostore.ForwardingRuleList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, rules[1].ID, list[0].ID)
+	assert.Equal(t, rules[0].ID, list[1].ID)
+}
+
+func TestForwardingRuleUpdate(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	rule := &models.ForwardingRule{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		ForwardingRuleFields: models.ForwardingRuleFields{
+			Action: models.ForwardingRuleActionAllow,
+			Host:   "10.0.0.0/8",
+			Ports:  models.ForwardingPortRange{Start: 22, End: 22},
+		},
+	}
+	assert.NoError(t, mongostore.ForwardingRuleCreate(ctx, rule))
+
+	fields := models.ForwardingRuleFields{
+		Action: models.ForwardingRuleActionDeny,
+		Active: true,
+		Host:   "192.168.0.0/16",
+		Ports:  models.ForwardingPortRange{Start: 80, End: 8080},
+		Filter: models.ForwardingFilter{Username: "^root$"},
+	}
+
+	_, err := mongostore.ForwardingRuleUpdate(ctx, "00000000-0000-4001-0000-000000000000", rule.ID, &fields)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	updated, err := mongostore.ForwardingRuleUpdate(ctx, "00000000-0000-4000-0000-000000000000", rule.ID, &fields)
+	assert.NoError(t, err)
+	assert.Equal(t, fields, updated.ForwardingRuleFields)
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration65 = migrate.Migration{
+	Version:     65,
+	Description: "Create the index on 'tenant_id' and 'created_at' for the audit_logs collection.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		mod := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("tenant_id_created_at"),
+		}
+
+		_, err := db.Collection("audit_logs").Indexes().CreateOne(ctx, mod)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("audit_logs").Indexes().DropOne(ctx, "tenant_id_created_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration65(t *testing.T) {
+	logrus.Info("Testing Migration 65")
+
+	ctx := context.Background()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	hasIndex := func() (bool, error) {
+		cursor, err := db.Client().Database("test").Collection("audit_logs").Indexes().List(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		var indexes []bson.M
+		if err := cursor.All(ctx, &indexes); err != nil {
+			return false, err
+		}
+
+		for _, index := range indexes {
+			if index["name"] == "tenant_id_created_at" {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[64:65]...)
+
+	assert.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	found, err := hasIndex()
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	assert.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+
+	found, err = hasIndex()
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration66 = migrate.Migration{
+	Version:     66,
+	Description: "Create the indexes for the webhooks and webhook_deliveries collections.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		webhooks := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "events", Value: 1}},
+			Options: options.Index().SetName("tenant_id_events"),
+		}
+
+		if _, err := db.Collection("webhooks").Indexes().CreateOne(ctx, webhooks); err != nil {
+			return err
+		}
+
+		deliveries := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("webhook_id_created_at"),
+			},
+			{
+				// The delivery log is kept for 30 days.
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("ttl").SetExpireAfterSeconds(30 * 24 * 60 * 60),
+			},
+		}
+
+		_, err := db.Collection("webhook_deliveries").Indexes().CreateMany(ctx, deliveries)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("webhooks").Indexes().DropOne(ctx, "tenant_id_events"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("webhook_deliveries").Indexes().DropOne(ctx, "webhook_id_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("webhook_deliveries").Indexes().DropOne(ctx, "ttl")
+
+		return err
+	}),
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration66(t *testing.T) {
+	logrus.Info("Testing Migration 66")
+
+	ctx := context.Background()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	hasIndex := func(collection, name string) (bool, error) {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		var indexes []bson.M
+		if err := cursor.All(ctx, &indexes); err != nil {
+			return false, err
+		}
+
+		for _, index := range indexes {
+			if index["name"] == name {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	indexes := []struct {
+		collection string
+		name       string
+	}{
+		{"webhooks", "tenant_id_events"},
+		{"webhook_deliveries", "webhook_id_created_at"},
+		{"webhook_deliveries", "ttl"},
+	}
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[65:66]...)
+
+	assert.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	for _, index := range indexes {
+		found, err := hasIndex(index.collection, index.name)
+		assert.NoError(t, err)
+		assert.True(t, found)
+	}
+
+	assert.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+
+	for _, index := range indexes {
+		found, err := hasIndex(index.collection, index.name)
+		assert.NoError(t, err)
+		assert.False(t, found)
+	}
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration68 = migrate.Migration{
+	Version:     68,
+	Description: "Create the unique index on the device groups' name within a namespace.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("tenant_id_name").SetUnique(true),
+		}
+
+		_, err := db.Collection("device_groups").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("device_groups").Indexes().DropOne(ctx, "tenant_id_name")
+
+		return err
+	}),
+}
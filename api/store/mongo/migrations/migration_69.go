@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration69 = migrate.Migration{
+	Version:     69,
+	Description: "Create the index for the forwarding_rules collection.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   69,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "priority", Value: 1}},
+			Options: options.Index().SetName("tenant_id_priority"),
+		}
+
+		_, err := db.Collection("forwarding_rules").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   69,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("forwarding_rules").Indexes().DropOne(ctx, "tenant_id_priority")
+
+		return err
+	}),
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration70 = migrate.Migration{
+	Version:     70,
+	Description: "Create the unique index on the certificate authorities' fingerprint within a namespace.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "fingerprint", Value: 1}},
+			Options: options.Index().SetName("tenant_id_fingerprint").SetUnique(true),
+		}
+
+		_, err := db.Collection("certificate_authorities").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("certificate_authorities").Indexes().DropOne(ctx, "tenant_id_fingerprint")
+
+		return err
+	}),
+}
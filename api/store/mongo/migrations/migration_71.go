@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration71 = migrate.Migration{
+	Version:     71,
+	Description: "Create the index to list the sessions' events by when they happened.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "session", Value: 1}, {Key: "timestamp", Value: 1}},
+			Options: options.Index().SetName("session_timestamp"),
+		}
+
+		_, err := db.Collection("sessions_events").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("sessions_events").Indexes().DropOne(ctx, "session_timestamp")
+
+		return err
+	}),
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration72 = migrate.Migration{
+	Version:     72,
+	Description: "Keep the connected devices until the workers expire them, leaving the TTL index as a fallback.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("connected_devices").Indexes().DropOne(ctx, "last_seen"); err != nil {
+			return err
+		}
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "last_seen", Value: 1}},
+			Options: options.Index().SetName("last_seen").SetExpireAfterSeconds(600),
+		}
+
+		_, err := db.Collection("connected_devices").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("connected_devices").Indexes().DropOne(ctx, "last_seen"); err != nil {
+			return err
+		}
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "last_seen", Value: 1}},
+			Options: options.Index().SetName("last_seen").SetExpireAfterSeconds(120),
+		}
+
+		_, err := db.Collection("connected_devices").Indexes().CreateOne(ctx, index)
+
+		return err
+	}),
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigration72(t *testing.T) {
+	logrus.Info("Testing Migration 72")
+
+	ctx := context.Background()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	expireAfter := func() (int32, error) {
+		list, err := db.Client().Database("test").Collection("connected_devices").Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, index := range list {
+			if index.Name == "last_seen" && index.ExpireAfterSeconds != nil {
+				return *index.ExpireAfterSeconds, nil
+			}
+		}
+
+		return 0, nil
+	}
+
+	_, err := db.Client().Database("test").Collection("connected_devices").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "last_seen", Value: 1}},
+		Options: options.Index().SetName("last_seen").SetExpireAfterSeconds(120),
+	})
+	assert.NoError(t, err)
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[71:72]...)
+
+	assert.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	seconds, err := expireAfter()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(600), seconds)
+
+	assert.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+
+	seconds, err = expireAfter()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(120), seconds)
+}
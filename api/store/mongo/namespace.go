@@ -212,7 +212,7 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 			logrus.Error(err)
 		}
 
-		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions"}
+		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "sessions_events"}
 		for _, collection := range collections {
 			if _, err := s.db.Collection(collection).DeleteMany(sessCtx, bson.M{"tenant_id": tenantID}); err != nil {
 				return nil, FromMongoError(err)
@@ -237,9 +237,19 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 }
 
 func (s *Store) NamespaceEdit(ctx context.Context, tenant string, changes *models.NamespaceChanges) error {
+	update := bson.M{"$set": changes}
+	if changes.UnsetRecordRetentionDays {
+		// NOTICE: MongoDB refuses an empty $set, as when the retention is the only change.
+		if *changes == (models.NamespaceChanges{UnsetRecordRetentionDays: true}) {
+			delete(update, "$set")
+		}
+
+		update["$unset"] = bson.M{"settings.record_retention_days": ""}
+	}
+
 	res, err := s.db.
 		Collection("namespaces").
-		UpdateOne(ctx, bson.M{"tenant_id": tenant}, bson.M{"$set": changes})
+		UpdateOne(ctx, bson.M{"tenant_id": tenant}, update)
 	if err != nil {
 		return FromMongoError(err)
 	}
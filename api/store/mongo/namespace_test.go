@@ -426,6 +426,15 @@ func TestNamespaceEdit(t *testing.T) {
 			fixtures: []string{fixtures.FixtureNamespaces},
 			expected: nil,
 		},
+		{
+			description: "succeeds when the record retention is the only change unset",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			changes: &models.NamespaceChanges{
+				UnsetRecordRetentionDays: true,
+			},
+			fixtures: []string{fixtures.FixtureNamespaces},
+			expected: nil,
+		},
 	}
 
 	db := dbtest.DBServer{}
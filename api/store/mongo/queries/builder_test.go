@@ -181,6 +181,45 @@ func TestFromFilters(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when filtering by device attributes and UIDs",
+			filters: &query.Filters{
+				Data: []query.Filter{
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "attributes.site",
+							Operator: "eq",
+							Value:    "lab",
+						},
+					},
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "attributes.rack",
+							Operator: "exists",
+							Value:    true,
+						},
+					},
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "uid",
+							Operator: "in",
+							Value:    []interface{}{"uid"},
+						},
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{
+					{"attributes.site": bson.M{"$eq": "lab"}},
+					{"attributes.rack": bson.M{"$exists": true}},
+					{"uid": bson.M{"$in": []interface{}{"uid"}}},
+				}}}},
+				err: nil,
+			},
+		},
 		{
 			description: "Fail when operator in operator is invalid",
 			filters: &query.Filters{
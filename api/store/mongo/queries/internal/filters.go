@@ -42,6 +42,12 @@ func ParseFilterProperty(fp *query.FilterProperty) (bson.M, bool, error) {
 	case "gt":
 		res, err = fromGt(fp.Value)
 		ok = true
+	case "exists":
+		res, err = fromExists(fp.Value)
+		ok = true
+	case "in":
+		res, err = fromIn(fp.Value)
+		ok = true
 	default:
 		return nil, false, nil
 	}
@@ -97,3 +103,29 @@ func fromGt(value interface{}) (bson.M, error) {
 
 	return bson.M{"$gt": value}, nil
 }
+
+// fromExists converts an "exists" JSON expression to a Bson expression using "$exists".
+func fromExists(value interface{}) (bson.M, error) {
+	switch v := value.(type) {
+	case bool:
+		return bson.M{"$exists": v}, nil
+	case string:
+		exists, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return bson.M{"$exists": exists}, nil
+	}
+
+	return nil, errors.New("invalid value type for fromExists")
+}
+
+// fromIn converts an "in" JSON expression to a Bson expression using "$in".
+func fromIn(value interface{}) (bson.M, error) {
+	if _, ok := value.([]interface{}); !ok {
+		return nil, errors.New("invalid value type for fromIn")
+	}
+
+	return bson.M{"$in": value}, nil
+}
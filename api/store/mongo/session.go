@@ -176,6 +176,34 @@ func (s *Store) SessionSetRecorded(ctx context.Context, uid models.UID, recorded
 	return nil
 }
 
+func (s *Store) SessionSetCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error {
+	session, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"close_reason": reason}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if session.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionSetClosedBy(ctx context.Context, uid models.UID, user string) error {
+	update := bson.M{"$set": bson.M{"close_reason": models.SessionCloseReasonUser, "closed_by": user}}
+
+	session, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if session.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
 	session.StartedAt = clock.Now()
 	session.LastSeen = session.StartedAt
@@ -301,16 +329,17 @@ func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) er
 	return nil
 }
 
-// SessionDeleteRecordFrameByDate deletes recorded sessions and updates session records
+// SessionDeleteRecordFrameByDate deletes recorded sessions and updates session records of a tenant
 // before the specified date.
 //
-// It takes a time 'lte', representing the maximum date. The method deletes all recorded sessions
-// with a 'time' field less than or equal to 'lte' It also updates 'sessions' records by setting
-// the 'recorded' field to false for sessions that started before 'lte' and are marked as recorded.
+// It takes a time 'lte', representing the maximum date. The method deletes all recorded sessions of
+// the tenant with a 'time' field less than or equal to 'lte' It also updates the tenant's 'sessions'
+// records by setting the 'recorded' field to false for sessions that started before 'lte' and are
+// marked as recorded.
 //
 // The method returns the count of deleted sessions, the count of updated session records,
 // and any encountered error during the operation.
-func (s *Store) SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Time) (deletedCount int64, updatedCount int64, err error) {
+func (s *Store) SessionDeleteRecordFrameByDate(ctx context.Context, tenant string, lte time.Time) (deletedCount int64, updatedCount int64, err error) {
 	mongoSession, err := s.db.Client().StartSession()
 	if err != nil {
 		return deletedCount, updatedCount, FromMongoError(err)
@@ -321,6 +350,7 @@ func (s *Store) SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Tim
 		d, err := s.db.Collection("recorded_sessions").DeleteMany(
 			ctx,
 			bson.M{
+				"tenant_id": tenant,
 				"time": bson.D{
 					{Key: "$lte", Value: lte},
 				},
@@ -333,6 +363,7 @@ func (s *Store) SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Tim
 		u, err := s.db.Collection("sessions").UpdateMany(
 			ctx,
 			bson.M{
+				"tenant_id": tenant,
 				"started_at": bson.D{
 					{Key: "$lte", Value: lte},
 				},
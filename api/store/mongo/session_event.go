@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) SessionEventCreate(ctx context.Context, event *models.SessionEvent) error {
+	if _, err := s.db.Collection("sessions_events").InsertOne(ctx, event); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionEventList(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant, "session": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("sessions_events"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"timestamp": 1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("sessions_events").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	events := make([]models.SessionEvent, 0)
+	for cursor.Next(ctx) {
+		event := new(models.SessionEvent)
+		if err := cursor.Decode(event); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		// NOTICE: The event's data is decoded as an ordered document, which isn't encoded as an object to JSON.
+		if data, ok := event.Data.(bson.D); ok {
+			fields := make(bson.M, len(data))
+			for _, field := range data {
+				fields[field.Key] = field.Value
+			}
+
+			event.Data = fields
+		}
+
+		events = append(events, *event)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return events, count, nil
+}
@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSessionEventCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+	defer fixtures.Teardown() // nolint: errcheck
+
+	event := &models.SessionEvent{
+		Session:   "a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Type:      models.SessionEventTypeSFTP,
+		Timestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		Data:      models.SessionEventSFTP{Operation: models.SessionEventSFTPOperationRemove, Path: "/tmp/file"},
+	}
+
+	assert.NoError(t, mongostore.SessionEventCreate(context.TODO(), event))
+
+	var stored struct {
+		TenantID string                  `bson:"tenant_id"`
+		Type     models.SessionEventType `bson:"type"`
+		Data     models.SessionEventSFTP `bson:"data"`
+	}
+	err := db.Client().Database("test").Collection("sessions_events").FindOne(context.TODO(), bson.M{"session": event.Session}).Decode(&stored)
+	assert.NoError(t, err)
+	assert.Equal(t, event.TenantID, stored.TenantID)
+	assert.Equal(t, event.Type, stored.Type)
+	assert.Equal(t, event.Data, stored.Data)
+}
+
+func TestSessionEventList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+	defer fixtures.Teardown() // nolint: errcheck
+
+	const (
+		uid    = "a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68"
+		tenant = "00000000-0000-4000-0000-000000000000"
+	)
+
+	started := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// NOTICE: The events are created out of order, as the SSH server sends them in background.
+	events := []*models.SessionEvent{
+		{Session: uid, TenantID: tenant, Type: models.SessionEventTypeShell, Timestamp: started.Add(time.Second)},
+		{Session: uid, TenantID: tenant, Type: models.SessionEventTypePty, Timestamp: started, Data: models.SessionEventPty{Term: "xterm", Columns: 80, Rows: 24}},
+		{Session: uid, TenantID: "00000000-0000-4001-0000-000000000000", Type: models.SessionEventTypeShell, Timestamp: started},
+		{Session: "other", TenantID: tenant, Type: models.SessionEventTypeShell, Timestamp: started},
+	}
+
+	for _, event := range events {
+		assert.NoError(t, mongostore.SessionEventCreate(context.TODO(), event))
+	}
+
+	list, count, err := mongostore.SessionEventList(context.TODO(), tenant, models.UID(uid), query.Paginator{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.SessionEvent{
+		{Session: uid, TenantID: tenant, Type: models.SessionEventTypePty, Timestamp: started, Data: bson.M{"term": "xterm", "columns": int64(80), "rows": int64(24)}},
+		{Session: uid, TenantID: tenant, Type: models.SessionEventTypeShell, Timestamp: started.Add(time.Second)},
+	}, list)
+}
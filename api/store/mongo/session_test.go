@@ -706,12 +706,14 @@ func TestSessionDeleteRecordFrameByDate(t *testing.T) {
 
 	cases := []struct {
 		description string
+		tenant      string
 		lte         time.Time
 		fixtures    []string
 		expected    Expected
 	}{
 		{
 			description: "succeeds when there are no sessions to update or delete",
+			tenant:      "00000000-0000-4000-0000-000000000000",
 			lte:         time.Date(2023, time.January, 30, 12, 00, 0, 0, time.UTC),
 			fixtures:    []string{},
 			expected: Expected{
@@ -720,8 +722,23 @@ func TestSessionDeleteRecordFrameByDate(t *testing.T) {
 				err:          nil,
 			},
 		},
+		{
+			description: "succeeds when the tenant has no sessions to update or delete",
+			tenant:      "nonexistent",
+			lte:         time.Date(2023, time.January, 30, 12, 00, 0, 0, time.UTC),
+			fixtures: []string{
+				fixtures.FixtureSessions,
+				fixtures.FixtureRecordedSessions,
+			},
+			expected: Expected{
+				deletedCount: 0,
+				updatedCount: 0,
+				err:          nil,
+			},
+		},
 		{
 			description: "succeeds to delete and update recorded sessions before specified date",
+			tenant:      "00000000-0000-4000-0000-000000000000",
 			lte:         time.Date(2023, time.January, 30, 12, 00, 0, 0, time.UTC),
 			fixtures: []string{
 				fixtures.FixtureSessions,
@@ -746,7 +763,7 @@ func TestSessionDeleteRecordFrameByDate(t *testing.T) {
 			assert.NoError(t, fixtures.Apply(tc.fixtures...))
 			defer fixtures.Teardown() // nolint:errcheck
 
-			deletedCount, updatedCount, err := mongostore.SessionDeleteRecordFrameByDate(context.TODO(), tc.lte)
+			deletedCount, updatedCount, err := mongostore.SessionDeleteRecordFrameByDate(context.TODO(), tc.tenant, tc.lte)
 			assert.Equal(t, tc.expected, Expected{deletedCount, updatedCount, err})
 		})
 	}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) WebhookList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhooks"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": 1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("webhooks").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	for cursor.Next(ctx) {
+		webhook := new(models.Webhook)
+		if err := cursor.Decode(webhook); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *Store) WebhookListByEvent(ctx context.Context, tenant string, event models.WebhookEvent) ([]models.Webhook, error) {
+	cursor, err := s.db.Collection("webhooks").Find(ctx, bson.M{"tenant_id": tenant, "events": event, "active": true})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	res, err := s.db.Collection("webhooks").InsertOne(ctx, webhook)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	webhook := new(models.Webhook)
+	if err := s.db.Collection("webhooks").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(webhook); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookUpdate(ctx context.Context, tenant, id string, changes *models.WebhookChanges) (*models.Webhook, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := s.db.Collection("webhooks").FindOneAndUpdate(ctx, bson.M{"_id": objID, "tenant_id": tenant}, bson.M{"$set": changes}, opts)
+	if result.Err() != nil {
+		return nil, FromMongoError(result.Err())
+	}
+
+	webhook := new(models.Webhook)
+	if err := result.Decode(webhook); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	res, err := s.db.Collection("webhooks").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if _, err := s.db.Collection("webhook_deliveries").DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	res, err := s.db.Collection("webhook_deliveries").InsertOne(ctx, delivery)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := res.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryList(ctx context.Context, tenant, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant, "webhook_id": id},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhook_deliveries"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("webhook_deliveries").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for cursor.Next(ctx) {
+		delivery := new(models.WebhookDelivery)
+		if err := cursor.Decode(delivery); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return deliveries, count, nil
+}
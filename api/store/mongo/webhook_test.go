@@ -0,0 +1,133 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookListByEvent(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	webhooks := []models.Webhook{
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			URL:      "https://example.com/online",
+			Events:   []models.WebhookEvent{models.WebhookEventDeviceOnline},
+			Active:   true,
+		},
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			URL:      "https://example.com/inactive",
+			Events:   []models.WebhookEvent{models.WebhookEventDeviceOnline},
+			Active:   false,
+		},
+		{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			URL:      "https://example.com/sessions",
+			Events:   []models.WebhookEvent{models.WebhookEventSessionStarted},
+			Active:   true,
+		},
+		{
+			TenantID: "00000000-0000-4001-0000-000000000000",
+			URL:      "https://example.com/other",
+			Events:   []models.WebhookEvent{models.WebhookEventDeviceOnline},
+			Active:   true,
+		},
+	}
+
+	for i := range webhooks {
+		assert.NoError(t, mongostore.WebhookCreate(ctx, &webhooks[i]))
+		assert.NotEmpty(t, webhooks[i].ID)
+	}
+
+	list, err := mongostore.WebhookListByEvent(ctx, "00000000-0000-4000-0000-000000000000", models.WebhookEventDeviceOnline)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "https://example.com/online", list[0].URL)
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	webhook := &models.Webhook{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		URL:      "https://example.com",
+		Events:   []models.WebhookEvent{models.WebhookEventDeviceOnline},
+		Secret:   "secret",
+		Active:   true,
+	}
+	assert.NoError(t, mongostore.WebhookCreate(ctx, webhook))
+
+	active := false
+	updated, err := mongostore.WebhookUpdate(ctx, webhook.TenantID, webhook.ID, &models.WebhookChanges{Active: &active})
+	assert.NoError(t, err)
+	assert.False(t, updated.Active)
+	assert.Equal(t, webhook.URL, updated.URL)
+	assert.Equal(t, webhook.Events, updated.Events)
+	assert.Equal(t, webhook.Secret, updated.Secret)
+
+	_, err = mongostore.WebhookUpdate(ctx, "00000000-0000-4001-0000-000000000000", webhook.ID, &models.WebhookChanges{Active: &active})
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestWebhookDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	webhook := &models.Webhook{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		URL:      "https://example.com",
+		Events:   []models.WebhookEvent{models.WebhookEventDeviceOnline},
+		Active:   true,
+	}
+	assert.NoError(t, mongostore.WebhookCreate(ctx, webhook))
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, mongostore.WebhookDeliveryCreate(ctx, &models.WebhookDelivery{
+			TenantID:  webhook.TenantID,
+			WebhookID: webhook.ID,
+			Event:     models.WebhookEventDeviceOnline,
+			Attempt:   i,
+			CreatedAt: time.Date(2023, 1, i, 12, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	deliveries, count, err := mongostore.WebhookDeliveryList(ctx, webhook.TenantID, webhook.ID, query.Paginator{Page: 1, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.WebhookDelete(ctx, "00000000-0000-4001-0000-000000000000", webhook.ID))
+	assert.NoError(t, mongostore.WebhookDelete(ctx, webhook.TenantID, webhook.ID))
+
+	_, count, err = mongostore.WebhookDeliveryList(ctx, webhook.TenantID, webhook.ID, query.Paginator{Page: 1, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,23 @@
+package store
+
+import "context"
+
+// RecordingStore is an object storage used to keep session recordings outside of the database. Recordings are saved
+// as compressed chunks of frames, each one identified by a slash-separated key.
+//
+// Unlike the other stores, it isn't part of [Store], as it is an optional backend configured apart from the database.
+type RecordingStore interface {
+	// RecordingPut saves the data under the key, replacing any existing object.
+	RecordingPut(ctx context.Context, key string, data []byte) error
+
+	// RecordingGet retrieves the data saved under the key.
+	// It returns ErrNoDocuments when the key does not exist.
+	RecordingGet(ctx context.Context, key string) ([]byte, error)
+
+	// RecordingList lists, in lexical order, the keys starting with the prefix.
+	RecordingList(ctx context.Context, prefix string) ([]string, error)
+
+	// RecordingDelete deletes the object saved under the key.
+	// It returns ErrNoDocuments when the key does not exist.
+	RecordingDelete(ctx context.Context, key string) error
+}
@@ -0,0 +1,150 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// Filesystem is a [store.RecordingStore] that saves each object as a file inside a root directory. The object's key is
+// used as the file's path relative to the root.
+type Filesystem struct {
+	root string
+}
+
+var _ store.RecordingStore = (*Filesystem)(nil)
+
+// NewFilesystem creates a [Filesystem] rooted at the directory, creating it when it does not exist.
+func NewFilesystem(root string) (*Filesystem, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &Filesystem{root: root}, nil
+}
+
+// path converts a key to a path inside the root directory. Keys trying to escape from the root are rejected.
+func (f *Filesystem) path(key string) (string, error) {
+	if key == "" || !fs.ValidPath(key) {
+		return "", ErrKeyInvalid
+	}
+
+	return filepath.Join(f.root, filepath.FromSlash(key)), nil
+}
+
+func (f *Filesystem) RecordingPut(_ context.Context, key string, data []byte) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// The data is written to a temporary file and renamed to avoid readers seeing a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *Filesystem) RecordingGet(_ context.Context, key string) ([]byte, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, store.ErrNoDocuments
+	}
+
+	return data, err
+}
+
+func (f *Filesystem) RecordingList(_ context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	// Only the directory containing the prefix needs to be walked.
+	start := f.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		if !fs.ValidPath(prefix[:i]) {
+			return nil, ErrKeyInvalid
+		}
+
+		start = filepath.Join(f.root, filepath.FromSlash(prefix[:i]))
+	}
+
+	err := filepath.WalkDir(start, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(f.root, path)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (f *Filesystem) RecordingDelete(_ context.Context, key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return store.ErrNoDocuments
+		}
+
+		return err
+	}
+
+	// Empty directories left behind are removed up to the root. Errors are ignored because a directory that still
+	// has entries cannot be removed.
+	for dir := filepath.Dir(path); dir != f.root && strings.HasPrefix(dir, f.root); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package recording
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystem(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+
+	fs, err := NewFilesystem(root)
+	require.NoError(t, err)
+
+	cases := []struct {
+		description string
+		test        func(t *testing.T)
+	}{
+		{
+			description: "fails when the key is invalid",
+			test: func(t *testing.T) {
+				assert.ErrorIs(t, fs.RecordingPut(ctx, "", []byte("data")), ErrKeyInvalid)
+				assert.ErrorIs(t, fs.RecordingPut(ctx, "../escape", []byte("data")), ErrKeyInvalid)
+				assert.ErrorIs(t, fs.RecordingPut(ctx, "/absolute", []byte("data")), ErrKeyInvalid)
+
+				_, err := fs.RecordingList(ctx, "../escape/")
+				assert.ErrorIs(t, err, ErrKeyInvalid)
+			},
+		},
+		{
+			description: "fails when the key does not exist",
+			test: func(t *testing.T) {
+				_, err := fs.RecordingGet(ctx, "tenant/missing")
+				assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+				assert.ErrorIs(t, fs.RecordingDelete(ctx, "tenant/missing"), store.ErrNoDocuments)
+			},
+		},
+		{
+			description: "succeeds to put, list, get and delete objects",
+			test: func(t *testing.T) {
+				require.NoError(t, fs.RecordingPut(ctx, "tenant/uid/2", []byte("second")))
+				require.NoError(t, fs.RecordingPut(ctx, "tenant/uid/1", []byte("first")))
+				require.NoError(t, fs.RecordingPut(ctx, "tenant/other/1", []byte("other")))
+
+				keys, err := fs.RecordingList(ctx, "tenant/uid/")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"tenant/uid/1", "tenant/uid/2"}, keys)
+
+				keys, err = fs.RecordingList(ctx, "")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"tenant/other/1", "tenant/uid/1", "tenant/uid/2"}, keys)
+
+				data, err := fs.RecordingGet(ctx, "tenant/uid/1")
+				require.NoError(t, err)
+				assert.Equal(t, []byte("first"), data)
+
+				require.NoError(t, fs.RecordingDelete(ctx, "tenant/uid/1"))
+				require.NoError(t, fs.RecordingDelete(ctx, "tenant/uid/2"))
+
+				keys, err = fs.RecordingList(ctx, "tenant/uid/")
+				require.NoError(t, err)
+				assert.Empty(t, keys)
+
+				// The directory of a session without objects is removed.
+				_, err = os.Stat(filepath.Join(root, "tenant", "uid"))
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, tc.test)
+	}
+}
@@ -0,0 +1,98 @@
+// Package recording keeps session recordings in an object storage instead of the database.
+//
+// Recorded frames are buffered in memory and flushed as gzip-compressed chunks, each one a JSON array of frames, to a
+// [store.RecordingStore]. The chunks are saved under the key "{tenant}/{session}/{first}-{last}.json.gz", where first
+// and last are the Unix time, in nanoseconds, of the chunk's first and last frames. This layout allows listing the
+// chunks of a session, or of a namespace, and deleting chunks by date without reading them.
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// ErrKeyInvalid is returned when an object's key is empty or isn't a valid slash-separated path.
+var ErrKeyInvalid = errors.New("invalid recording key")
+
+const chunkExtension = ".json.gz"
+
+// sessionPrefix returns the prefix shared by all chunks of a session.
+func sessionPrefix(tenant string, uid models.UID) string {
+	return tenant + "/" + string(uid) + "/"
+}
+
+// chunkKey returns the key of a chunk from the time of its first and last frames.
+func chunkKey(tenant string, uid models.UID, first, last time.Time) string {
+	return fmt.Sprintf("%s%020d-%020d%s", sessionPrefix(tenant, uid), first.UnixNano(), last.UnixNano(), chunkExtension)
+}
+
+// parseChunkKey returns the time of the first and last frames of a chunk from its key.
+func parseChunkKey(key string) (time.Time, time.Time, error) {
+	name := key[strings.LastIndex(key, "/")+1:]
+	if !strings.HasSuffix(name, chunkExtension) {
+		return time.Time{}, time.Time{}, ErrKeyInvalid
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSuffix(name, chunkExtension), "-")
+	if !ok {
+		return time.Time{}, time.Time{}, ErrKeyInvalid
+	}
+
+	f, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrKeyInvalid
+	}
+
+	l, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrKeyInvalid
+	}
+
+	return time.Unix(0, f), time.Unix(0, l), nil
+}
+
+// encodeChunk encodes the frames as a gzip-compressed JSON array.
+func encodeChunk(frames []models.RecordedSession) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+
+	writer := gzip.NewWriter(buffer)
+	if err := json.NewEncoder(writer).Encode(frames); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decodeChunk decodes the frames encoded by [encodeChunk].
+func decodeChunk(data []byte) ([]models.RecordedSession, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]models.RecordedSession, 0)
+	if err := json.Unmarshal(content, &frames); err != nil {
+		return nil, err
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,342 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+)
+
+// S3Options contains the parameters to access an S3-compatible bucket.
+type S3Options struct {
+	// Endpoint is the base URL of the object storage, like "https://s3.us-east-1.amazonaws.com" or
+	// "http://minio:9000".
+	Endpoint string
+	// Region is the region used to sign the requests.
+	Region string
+	// Bucket is the name of the bucket where the objects are saved. It must already exist.
+	Bucket string
+	// AccessKey is the access key ID used to sign the requests.
+	AccessKey string
+	// SecretKey is the secret access key used to sign the requests.
+	SecretKey string
+}
+
+// S3 is a [store.RecordingStore] that saves each object in an S3-compatible bucket, like AWS S3 or MinIO.
+//
+// It uses path-style requests, "{endpoint}/{bucket}/{key}", signed with AWS Signature Version 4.
+type S3 struct {
+	http     *http.Client
+	endpoint *url.URL
+	opts     S3Options
+}
+
+var _ store.RecordingStore = (*S3)(nil)
+
+// NewS3 creates a [S3] to the bucket described by opts.
+func NewS3(opts S3Options) (*S3, error) {
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", opts.Endpoint)
+	}
+
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+
+	return &S3{http: http.DefaultClient, endpoint: endpoint, opts: opts}, nil
+}
+
+func (s *S3) url(key string, query url.Values) *url.URL {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opts.Bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+
+	return &u
+}
+
+func (s *S3) do(ctx context.Context, method string, u *url.URL, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = int64(len(body))
+
+	sum := sha256.Sum256(body)
+	signV4(req, hex.EncodeToString(sum[:]), s.opts.Region, s.opts.AccessKey, s.opts.SecretKey, clock.Now())
+
+	return s.http.Do(req)
+}
+
+// s3Error reads the error returned by the object storage.
+func s3Error(res *http.Response) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err := xml.Unmarshal(data, &body); err != nil || body.Code == "" {
+		return fmt.Errorf("s3: unexpected status %d", res.StatusCode)
+	}
+
+	return fmt.Errorf("s3: %s: %s", body.Code, body.Message)
+}
+
+func (s *S3) RecordingPut(ctx context.Context, key string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, s.url(key, nil), data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return s3Error(res)
+	}
+
+	return nil
+}
+
+func (s *S3) RecordingGet(ctx context.Context, key string) ([]byte, error) {
+	res, err := s.do(ctx, http.MethodGet, s.url(key, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, store.ErrNoDocuments
+	default:
+		return nil, s3Error(res)
+	}
+}
+
+func (s *S3) RecordingList(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		res, err := s.do(ctx, http.MethodGet, s.url("", query), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			err := s3Error(res)
+			res.Body.Close()
+
+			return nil, err
+		}
+
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			keys = append(keys, content.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+
+		token = result.NextContinuationToken
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *S3) RecordingDelete(ctx context.Context, key string) error {
+	// S3 doesn't report when the deleted object doesn't exist, so its existence is checked first.
+	res, err := s.do(ctx, http.MethodHead, s.url(key, nil), nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return store.ErrNoDocuments
+	default:
+		return fmt.Errorf("s3: unexpected status %d", res.StatusCode)
+	}
+
+	res, err = s.do(ctx, http.MethodDelete, s.url(key, nil), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return s3Error(res)
+	}
+
+	return nil
+}
+
+// signV4 signs the request with AWS Signature Version 4, setting the "X-Amz-Date", "X-Amz-Content-Sha256" and
+// "Authorization" headers.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func signV4(req *http.Request, payloadHash, region, accessKey, secretKey string, now time.Time) {
+	const (
+		algorithm = "AWS4-HMAC-SHA256"
+		service   = "s3"
+	)
+
+	now = now.UTC()
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "range" || name == "content-type" || name == "content-md5" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	canonicalHeaders := new(strings.Builder)
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{algorithm, now.Format("20060102T150405Z"), scope, hex.EncodeToString(hash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// escape encodes a string as required by the AWS signature, where only unreserved characters are kept.
+func escape(value string, keepSlash bool) string {
+	builder := new(strings.Builder)
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			builder.WriteByte(b)
+		case b == '/' && keepSlash:
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(builder, "%%%02X", b)
+		}
+	}
+
+	return builder.String()
+}
+
+func escapePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return escape(path, true)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+
+		for _, value := range values {
+			pairs = append(pairs, escape(key, false)+"="+escape(value, false))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,381 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Options configures when the buffered frames are flushed to the object storage.
+type Options struct {
+	// MaxFrames is the number of frames that triggers a flush.
+	MaxFrames int
+	// MaxSize is the sum of the frames' message length, in bytes, that triggers a flush.
+	MaxSize int
+	// FlushInterval is the time a buffer can stay without new frames before being flushed by [Store.Start].
+	FlushInterval time.Duration
+	// SessionIdle is the time a session being recorded can stay without new frames before it's forgotten by
+	// [Store.Start], and so looked up again on its next frame. It bounds the sessions kept of the ones never
+	// deactivated, as when the SSH server stops abruptly.
+	SessionIdle time.Duration
+}
+
+// DefaultOptions are the options used when a zero value is set.
+var DefaultOptions = Options{
+	MaxFrames:     1000,
+	MaxSize:       1 << 20,
+	FlushInterval: 30 * time.Second,
+	SessionIdle:   time.Hour,
+}
+
+type buffer struct {
+	tenant  string
+	frames  []models.RecordedSession
+	size    int
+	updated time.Time
+}
+
+// recorded is a session being recorded, kept from its first frame until it's deactivated, so its tenant is known and
+// it's marked as recorded only once, even when its buffer is flushed.
+type recorded struct {
+	tenant  string
+	updated time.Time
+}
+
+// Store is a [store.Store] that saves the session recordings in a [store.RecordingStore]. Every other method is
+// handled by the wrapped store.
+//
+// The frames are buffered in memory and saved in chunks when the buffer reaches the maximum number of frames or size,
+// when the session is deactivated, or after the flush interval without new frames. Until then, the frames are only
+// read by the API instance that buffered them, and they are lost if it stops abruptly.
+//
+// Frames recorded before the object storage was configured are still read and deleted from the wrapped store.
+type Store struct {
+	store.Store
+
+	backend store.RecordingStore
+	opts    Options
+
+	mu       sync.Mutex
+	buffers  map[models.UID]*buffer
+	sessions map[models.UID]*recorded
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore wraps s to save the session recordings in backend.
+func NewStore(s store.Store, backend store.RecordingStore, opts Options) *Store {
+	if opts.MaxFrames <= 0 {
+		opts.MaxFrames = DefaultOptions.MaxFrames
+	}
+
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultOptions.MaxSize
+	}
+
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultOptions.FlushInterval
+	}
+
+	if opts.SessionIdle <= 0 {
+		opts.SessionIdle = DefaultOptions.SessionIdle
+	}
+
+	return &Store{
+		Store:    s,
+		backend:  backend,
+		opts:     opts,
+		buffers:  make(map[models.UID]*buffer),
+		sessions: make(map[models.UID]*recorded),
+	}
+}
+
+// Start flushes, periodically, the buffers without new frames for longer than the flush interval, and forgets the
+// sessions without new frames for longer than the session idle time. When ctx is done, every buffer is flushed and
+// Start returns.
+func (s *Store) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.flushIdle(context.WithoutCancel(ctx), 0)
+
+			return
+		case <-ticker.C:
+			s.flushIdle(ctx, s.opts.FlushInterval)
+			s.forgetIdle(s.opts.SessionIdle)
+		}
+	}
+}
+
+// flushIdle flushes the buffers without new frames for, at least, the idle duration.
+func (s *Store) flushIdle(ctx context.Context, idle time.Duration) {
+	for _, uid := range s.idle(idle) {
+		if err := s.flush(ctx, uid); err != nil {
+			log.WithError(err).WithField("uid", uid).Error("failed to flush the session record")
+		}
+	}
+}
+
+// forgetIdle forgets the sessions without new frames for, at least, the idle duration and without buffered frames.
+func (s *Store) forgetIdle(idle time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uid, sess := range s.sessions {
+		if _, ok := s.buffers[uid]; !ok && clock.Now().Sub(sess.updated) >= idle {
+			delete(s.sessions, uid)
+		}
+	}
+}
+
+func (s *Store) idle(idle time.Duration) []models.UID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uids := make([]models.UID, 0)
+	for uid, buf := range s.buffers {
+		if clock.Now().Sub(buf.updated) >= idle {
+			uids = append(uids, uid)
+		}
+	}
+
+	return uids
+}
+
+// take removes the buffered frames of a session, returning them with the session's tenant.
+func (s *Store) take(uid models.UID) (string, []models.RecordedSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf, ok := s.buffers[uid]
+	if !ok {
+		return "", nil
+	}
+
+	delete(s.buffers, uid)
+
+	return buf.tenant, buf.frames
+}
+
+// flush saves the buffered frames of a session as a new chunk.
+func (s *Store) flush(ctx context.Context, uid models.UID) error {
+	tenant, frames := s.take(uid)
+
+	return s.put(ctx, tenant, uid, frames)
+}
+
+func (s *Store) put(ctx context.Context, tenant string, uid models.UID, frames []models.RecordedSession) error {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	first, last := frames[0].Time, frames[0].Time
+	for _, frame := range frames[1:] {
+		if frame.Time.Before(first) {
+			first = frame.Time
+		}
+
+		if frame.Time.After(last) {
+			last = frame.Time
+		}
+	}
+
+	data, err := encodeChunk(frames)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.RecordingPut(ctx, chunkKey(tenant, uid, first, last), data)
+}
+
+func (s *Store) SessionCreateRecordFrame(ctx context.Context, uid models.UID, recordSession *models.RecordedSession) error {
+	// NOTICE: The tenant is kept from the lookup, as the session may be forgotten before the frame is appended to its
+	// buffer.
+	var tenant string
+
+	s.mu.Lock()
+	sess, ok := s.sessions[uid]
+	if ok {
+		tenant = sess.tenant
+		sess.updated = clock.Now()
+	}
+	s.mu.Unlock()
+
+	// The session is looked up only on its first frame, to get its tenant and mark it as recorded.
+	if !ok {
+		session, err := s.Store.SessionGet(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.SessionSetRecorded(ctx, uid, true); err != nil {
+			return err
+		}
+
+		tenant = session.TenantID
+	}
+
+	s.mu.Lock()
+
+	if !ok {
+		s.sessions[uid] = &recorded{tenant: tenant, updated: clock.Now()}
+	}
+
+	buf, ok := s.buffers[uid]
+	if !ok {
+		buf = &buffer{tenant: tenant, frames: make([]models.RecordedSession, 0), updated: clock.Now()}
+		s.buffers[uid] = buf
+	}
+
+	buf.frames = append(buf.frames, *recordSession)
+	buf.size += len(recordSession.Message)
+	buf.updated = clock.Now()
+
+	if len(buf.frames) < s.opts.MaxFrames && buf.size < s.opts.MaxSize {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	tenant, frames := buf.tenant, buf.frames
+	buf.frames = make([]models.RecordedSession, 0)
+	buf.size = 0
+
+	s.mu.Unlock()
+
+	return s.put(ctx, tenant, uid, frames)
+}
+
+func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	// The session is retrieved from the wrapped store to respect the tenant set in the context.
+	session, err := s.Store.SessionGet(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	frames, _, err := s.Store.SessionGetRecordFrame(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keys, err := s.backend.RecordingList(ctx, sessionPrefix(session.TenantID, uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, key := range keys {
+		data, err := s.backend.RecordingGet(ctx, key)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		chunk, err := decodeChunk(data)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		frames = append(frames, chunk...)
+	}
+
+	s.mu.Lock()
+	if buf, ok := s.buffers[uid]; ok {
+		frames = append(frames, buf.frames...)
+	}
+	s.mu.Unlock()
+
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].Time.Before(frames[j].Time)
+	})
+
+	return frames, len(frames), nil
+}
+
+func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error {
+	deleted := false
+
+	// NOTICE: The session is no longer recorded, so its next frame marks it again.
+	s.take(uid)
+	s.forget(uid)
+
+	if session, err := s.Store.SessionGet(ctx, uid); err == nil {
+		keys, err := s.backend.RecordingList(ctx, sessionPrefix(session.TenantID, uid))
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := s.backend.RecordingDelete(ctx, key); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+				return err
+			}
+
+			deleted = true
+		}
+	}
+
+	if err := s.Store.SessionDeleteRecordFrame(ctx, uid); err != nil {
+		if !errors.Is(err, store.ErrNoDocuments) || !deleted {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SessionDeleteRecordFrameByDate deletes the tenant's chunks whose last frame is before, or at, lte, and then the
+// frames saved in the wrapped store. The deleted count is the sum of deleted chunks and frames.
+func (s *Store) SessionDeleteRecordFrameByDate(ctx context.Context, tenant string, lte time.Time) (int64, int64, error) {
+	keys, err := s.backend.RecordingList(ctx, tenant+"/")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var chunks int64
+	for _, key := range keys {
+		_, last, err := parseChunkKey(key)
+		if err != nil || last.After(lte) {
+			continue
+		}
+
+		if err := s.backend.RecordingDelete(ctx, key); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+			return chunks, 0, err
+		}
+
+		chunks++
+	}
+
+	deletedCount, updatedCount, err := s.Store.SessionDeleteRecordFrameByDate(ctx, tenant, lte)
+
+	return chunks + deletedCount, updatedCount, err
+}
+
+// SessionDeleteActives closes the session on the wrapped store, flushes its buffered frames and forgets it, as no more
+// frames are expected.
+func (s *Store) SessionDeleteActives(ctx context.Context, uid models.UID) error {
+	err := s.Store.SessionDeleteActives(ctx, uid)
+
+	if err := s.flush(ctx, uid); err != nil {
+		log.WithError(err).WithField("uid", uid).Error("failed to flush the session record")
+	}
+
+	s.forget(uid)
+
+	return err
+}
+
+// forget removes the session being recorded.
+func (s *Store) forget(uid models.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, uid)
+}
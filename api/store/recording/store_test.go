@@ -0,0 +1,229 @@
+package recording
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFrames(start time.Time, n int) []models.RecordedSession {
+	frames := make([]models.RecordedSession, n)
+	for i := range frames {
+		frames[i] = models.RecordedSession{
+			UID:     "uid",
+			Message: "frame",
+			Time:    start.Add(time.Duration(i) * time.Second).UTC(),
+			Width:   80,
+			Height:  24,
+		}
+	}
+
+	return frames
+}
+
+func TestStoreRecordFrames(t *testing.T) {
+	ctx := context.Background()
+
+	mock := new(mocks.Store)
+
+	backend, err := NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+
+	s := NewStore(mock, backend, Options{MaxFrames: 2})
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+	frames := newFrames(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil)
+	mock.On("SessionSetRecorded", ctx, models.UID("uid"), true).Return(nil).Once()
+
+	for i := range frames {
+		require.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", &frames[i]))
+	}
+
+	// The first two frames are flushed as a chunk and the last one is kept in memory.
+	keys, err := backend.RecordingList(ctx, "tenant/uid/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{chunkKey("tenant", "uid", frames[0].Time, frames[1].Time)}, keys)
+
+	mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return([]models.RecordedSession{}, 0, nil)
+
+	recorded, count, err := s.SessionGetRecordFrame(ctx, "uid")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, frames, recorded)
+
+	// Closing the session flushes the remaining frames.
+	mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(nil).Once()
+	require.NoError(t, s.SessionDeleteActives(ctx, "uid"))
+
+	keys, err = backend.RecordingList(ctx, "tenant/uid/")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	recorded, count, err = s.SessionGetRecordFrame(ctx, "uid")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, frames, recorded)
+
+	mock.On("SessionDeleteRecordFrame", ctx, models.UID("uid")).Return(store.ErrNoDocuments).Once()
+	require.NoError(t, s.SessionDeleteRecordFrame(ctx, "uid"))
+
+	keys, err = backend.RecordingList(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	mock.AssertExpectations(t)
+}
+
+func TestStoreRecordFramesWhileFlushing(t *testing.T) {
+	ctx := context.Background()
+
+	mock := new(mocks.Store)
+
+	backend, err := NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+
+	s := NewStore(mock, backend, Options{})
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+	frames := newFrames(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 100)
+
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil)
+	mock.On("SessionSetRecorded", ctx, models.UID("uid"), true).Return(nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := range frames {
+			assert.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", &frames[i]))
+		}
+	}()
+
+	// The buffers are flushed, and so removed, while the frames are recorded.
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+			s.flushIdle(ctx, 0)
+		}
+	}
+
+	s.flushIdle(ctx, 0)
+
+	mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return([]models.RecordedSession{}, 0, nil)
+
+	recorded, count, err := s.SessionGetRecordFrame(ctx, "uid")
+	require.NoError(t, err)
+	assert.Equal(t, len(frames), count)
+	assert.Equal(t, frames, recorded)
+}
+
+func TestStoreRecordFramesAfterIdleFlush(t *testing.T) {
+	ctx := context.Background()
+
+	mock := new(mocks.Store)
+
+	backend, err := NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+
+	s := NewStore(mock, backend, Options{})
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+	frames := newFrames(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+
+	// The session is looked up and marked as recorded only once, even when its buffer is flushed between frames.
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+	mock.On("SessionSetRecorded", ctx, models.UID("uid"), true).Return(nil).Once()
+
+	require.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", &frames[0]))
+	s.flushIdle(ctx, 0)
+	require.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", &frames[1]))
+	s.flushIdle(ctx, 0)
+
+	keys, err := backend.RecordingList(ctx, "tenant/uid/")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	// A session forgotten after being idle is looked up again on its next frame.
+	s.forgetIdle(0)
+
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+	mock.On("SessionSetRecorded", ctx, models.UID("uid"), true).Return(nil).Once()
+
+	require.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", &frames[2]))
+
+	// The session's buffered frames are flushed when it's deactivated.
+	mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(nil).Once()
+	require.NoError(t, s.SessionDeleteActives(ctx, "uid"))
+
+	keys, err = backend.RecordingList(ctx, "tenant/uid/")
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+	assert.Empty(t, s.sessions)
+
+	mock.AssertExpectations(t)
+}
+
+func TestStoreSessionDeleteRecordFrameByDate(t *testing.T) {
+	ctx := context.Background()
+
+	mock := new(mocks.Store)
+
+	backend, err := NewFilesystem(t.TempDir())
+	require.NoError(t, err)
+
+	s := NewStore(mock, backend, Options{})
+
+	old := newFrames(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 2)
+	recent := newFrames(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), 2)
+
+	require.NoError(t, s.put(ctx, "tenant", "old", old))
+	require.NoError(t, s.put(ctx, "tenant", "recent", recent))
+	require.NoError(t, s.put(ctx, "other", "old", old))
+
+	lte := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.On("SessionDeleteRecordFrameByDate", ctx, "tenant", lte).Return(int64(1), int64(2), nil).Once()
+
+	deleted, updated, err := s.SessionDeleteRecordFrameByDate(ctx, "tenant", lte)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, int64(2), updated)
+
+	keys, err := backend.RecordingList(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		chunkKey("other", "old", old[0].Time, old[1].Time),
+		chunkKey("tenant", "recent", recent[0].Time, recent[1].Time),
+	}, keys)
+
+	mock.AssertExpectations(t)
+}
+
+func TestChunk(t *testing.T) {
+	frames := newFrames(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+
+	data, err := encodeChunk(frames)
+	require.NoError(t, err)
+
+	decoded, err := decodeChunk(data)
+	require.NoError(t, err)
+	assert.Equal(t, frames, decoded)
+
+	first, last, err := parseChunkKey(chunkKey("tenant", "uid", frames[0].Time, frames[2].Time))
+	require.NoError(t, err)
+	assert.True(t, first.Equal(frames[0].Time))
+	assert.True(t, last.Equal(frames[2].Time))
+
+	_, _, err = parseChunkKey("tenant/uid/invalid.json.gz")
+	assert.ErrorIs(t, err, ErrKeyInvalid)
+}
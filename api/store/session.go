@@ -19,6 +19,10 @@ type SessionStore interface {
 	SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, newUID models.UID) error
 	SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error)
 	SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error
-	SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Time) (deletedCount int64, updatedCount int64, err error)
+	SessionDeleteRecordFrameByDate(ctx context.Context, tenant string, lte time.Time) (deletedCount int64, updatedCount int64, err error)
 	SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error
+	// SessionSetCloseReason sets the reason why ShellHub closed the session.
+	SessionSetCloseReason(ctx context.Context, uid models.UID, reason models.SessionCloseReason) error
+	// SessionSetClosedBy marks the session as closed by the user.
+	SessionSetClosedBy(ctx context.Context, uid models.UID, user string) error
 }
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SessionEventStore interface {
+	// SessionEventCreate records an event of a session.
+	SessionEventCreate(ctx context.Context, event *models.SessionEvent) error
+
+	// SessionEventList lists the tenant's session events, from the oldest to the newest.
+	SessionEventList(ctx context.Context, tenant string, uid models.UID, paginator query.Paginator) ([]models.SessionEvent, int, error)
+}
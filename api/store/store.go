@@ -6,7 +6,12 @@ type Store interface {
 	TagsStore
 	DeviceStore
 	DeviceTagsStore
+	DeviceAttributesStore
+	DeviceViaStore
+	DeviceTargetStore
+	DeviceGroupStore
 	SessionStore
+	SessionEventStore
 	UserStore
 	FirewallStore
 	FirewallTagsStore
@@ -18,4 +23,9 @@ type Store interface {
 	StatsStore
 	MFAStore
 	APIKeyStore
+	AuditStore
+	WebhookStore
+	AutoAcceptRuleStore
+	ForwardingRuleStore
+	CertificateAuthorityStore
 }
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebhookStore interface {
+	// WebhookList lists the namespace's webhooks.
+	WebhookList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Webhook, int, error)
+	// WebhookListByEvent lists the namespace's active webhooks subscribed to event.
+	WebhookListByEvent(ctx context.Context, tenant string, event models.WebhookEvent) ([]models.Webhook, error)
+	WebhookCreate(ctx context.Context, webhook *models.Webhook) error
+	WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error)
+	WebhookUpdate(ctx context.Context, tenant, id string, changes *models.WebhookChanges) (*models.Webhook, error)
+	// WebhookDelete deletes the webhook and its delivery log.
+	WebhookDelete(ctx context.Context, tenant, id string) error
+
+	WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error
+	// WebhookDeliveryList lists the webhook's delivery log, from the newest to the oldest attempt.
+	WebhookDeliveryList(ctx context.Context, tenant, id string, paginator query.Paginator) ([]models.WebhookDelivery, int, error)
+}
@@ -14,5 +14,9 @@
 // The maximum number of devices to wait for before triggering is defined by the `SHELLHUB_ASYNQ_GROUP_MAX_SIZE` (default is 500).
 // Another triggering mechanism involves a timeout defined in the `SHELLHUB_ASYNQ_GROUP_MAX_DELAY` environment variable.
 //
+// The `webhook` workers deliver the device and session lifecycle events to the namespaces' webhooks. Each event is
+// fanned out to the webhooks subscribed to it, and its payload is signed with the webhook's secret. A failed delivery
+// is retried up to `SHELLHUB_WEBHOOK_MAX_RETRY` times, and every attempt is recorded in the webhook's delivery log.
+//
 // The patterns of tasks used by the handlers are available as constants with the "Task" prefix.
 package workers
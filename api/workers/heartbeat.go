@@ -9,10 +9,18 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// deviceOnlineTTL is how long a device is kept online without a heartbeat.
+	deviceOnlineTTL = 2 * time.Minute
+	// deviceOfflineSchedule is how often the devices without a heartbeat are set offline.
+	deviceOfflineSchedule = "@every 30s"
+)
+
 // heartbeat worker manages heartbeat tasks, signaling the online status of devices.
 // It aggregates heartbeat data and updates the online status of devices accordingly.
 // The maximum number of devices to wait for before triggering is defined by the `SHELLHUB_ASYNQ_GROUP_MAX_SIZE` (default is 500).
@@ -49,9 +57,58 @@ func (w *Workers) registerHeartbeat() {
 
 			timestamp := time.Unix(i, 0)
 
-			w.store.DeviceSetOnline(ctx, models.UID(uid), timestamp, true) //nolint:errcheck
+			changed, err := w.store.DeviceSetOnline(ctx, models.UID(uid), timestamp, true)
+			if err == nil && changed {
+				w.emitWebhookEvent(ctx, models.UID(uid), models.WebhookEventDeviceOnline)
+			}
+		}
+
+		return nil
+	})
+}
+
+// registerDeviceOffline worker sets offline the devices without a heartbeat for longer than [deviceOnlineTTL],
+// emitting the device-offline event to the namespaces' webhooks. It runs every 30 seconds.
+//
+// The TTL index of the connected devices is only a fallback for when the workers aren't running, and the devices
+// expired by it don't emit the event.
+func (w *Workers) registerDeviceOffline() {
+	w.mux.HandleFunc(TaskDeviceOffline, func(ctx context.Context, _ *asynq.Task) error {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskDeviceOffline,
+			}).
+			Trace("Executing device offline worker.")
+
+		uids, err := w.store.DeviceExpireOnline(ctx, clock.Now().Add(-deviceOnlineTTL))
+		if err != nil {
+			log.WithFields(
+				log.Fields{
+					"component": "worker",
+					"task":      TaskDeviceOffline,
+				}).
+				WithError(err).
+				Error("Failed to set offline the devices without a heartbeat.")
+
+			return err
+		}
+
+		for _, uid := range uids {
+			w.emitWebhookEvent(ctx, uid, models.WebhookEventDeviceOffline)
 		}
 
 		return nil
 	})
+
+	task := asynq.NewTask(TaskDeviceOffline, nil, asynq.TaskID(TaskDeviceOffline), asynq.Queue("api"))
+	if _, err := w.scheduler.Register(deviceOfflineSchedule, task); err != nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskDeviceOffline,
+			}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
 }
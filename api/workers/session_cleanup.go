@@ -2,16 +2,19 @@ package workers
 
 import (
 	"context"
-	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	log "github.com/sirupsen/logrus"
 )
 
 // registerSessionCleanup worker is designed to delete recorded sessions older than a specified number
-// of days. The retention period is determined by the value of the `SHELLHUB_RECORD_RETENTION` environment
-// variable. To disable this worker, set `SHELLHUB_RECORD_RETENTION` to 0 (default behavior). It uses
-// a cron expression from `SHELLHUB_RECORD_RETENTION` to schedule its periodic execution.
+// of days. The retention period is set per namespace by its `RecordRetentionDays` setting; namespaces
+// without it use the value of the `SHELLHUB_RECORD_RETENTION` environment variable. A retention equal
+// to 0 (default behavior) keeps the records forever. It uses a cron expression from
+// `SHELLHUB_SESSION_RECORD_CLEANUP_SCHEDULE` to schedule its periodic execution.
 func (w *Workers) registerSessionCleanup() {
 	if w.env.SessionRecordCleanupRetention < 1 {
 		log.WithFields(
@@ -19,9 +22,7 @@ func (w *Workers) registerSessionCleanup() {
 				"component": "worker",
 				"task":      TaskSessionCleanup,
 			}).
-			Warnf("Aborting cleanup worker due to SHELLHUB_RECORD_RETENTION equal to %d.", w.env.SessionRecordCleanupRetention)
-
-		return
+			Infof("Cleanup worker only applies to namespaces with a retention due to SHELLHUB_RECORD_RETENTION equal to %d.", w.env.SessionRecordCleanupRetention)
 	}
 
 	w.mux.HandleFunc(TaskSessionCleanup, func(ctx context.Context, _ *asynq.Task) error {
@@ -33,18 +34,31 @@ func (w *Workers) registerSessionCleanup() {
 			}).
 			Trace("Executing cleanup worker.")
 
-		lte := time.Now().UTC().AddDate(0, 0, w.env.SessionRecordCleanupRetention*(-1))
-		deletedCount, updatedCount, err := w.store.SessionDeleteRecordFrameByDate(ctx, lte)
-		if err != nil {
-			log.WithFields(
-				log.Fields{
-					"component": "worker",
-					"task":      TaskSessionCleanup,
-				}).
-				WithError(err).
-				Error("Failed to delete recorded sessions")
-
-			return err
+		paginator := query.Paginator{Page: 1, PerPage: 100}
+		for {
+			namespaces, _, err := w.store.NamespaceList(ctx, paginator, query.Filters{}, false)
+			if err != nil {
+				log.WithFields(
+					log.Fields{
+						"component": "worker",
+						"task":      TaskSessionCleanup,
+					}).
+					WithError(err).
+					Error("Failed to list the namespaces")
+
+				return err
+			}
+
+			// NOTICE: A namespace whose records cannot be deleted doesn't stop the cleanup of the others.
+			for _, namespace := range namespaces {
+				w.cleanupSessionRecords(ctx, &namespace)
+			}
+
+			if len(namespaces) < paginator.PerPage {
+				break
+			}
+
+			paginator.Page++
 		}
 
 		log.WithFields(
@@ -52,9 +66,6 @@ func (w *Workers) registerSessionCleanup() {
 				"component":       "worker",
 				"cron_expression": w.env.SessionRecordCleanupSchedule,
 				"task":            TaskSessionCleanup,
-				"lte":             lte.String(),
-				"deleted_count":   deletedCount,
-				"updated_count":   updatedCount,
 			}).
 			Trace("Finishing cleanup worker.")
 
@@ -72,3 +83,42 @@ func (w *Workers) registerSessionCleanup() {
 			Error("Failed to register the scheduler.")
 	}
 }
+
+// cleanupSessionRecords deletes the namespace's recorded sessions older than its retention period. A failure is only
+// logged.
+func (w *Workers) cleanupSessionRecords(ctx context.Context, namespace *models.Namespace) {
+	retention := w.env.SessionRecordCleanupRetention
+	if namespace.Settings != nil && namespace.Settings.RecordRetentionDays != nil {
+		retention = *namespace.Settings.RecordRetentionDays
+	}
+
+	if retention < 1 {
+		return
+	}
+
+	lte := clock.Now().UTC().AddDate(0, 0, retention*(-1))
+	deletedCount, updatedCount, err := w.store.SessionDeleteRecordFrameByDate(ctx, namespace.TenantID, lte)
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskSessionCleanup,
+				"tenant_id": namespace.TenantID,
+			}).
+			WithError(err).
+			Error("Failed to delete recorded sessions")
+
+		return
+	}
+
+	log.WithFields(
+		log.Fields{
+			"component":     "worker",
+			"task":          TaskSessionCleanup,
+			"tenant_id":     namespace.TenantID,
+			"lte":           lte.String(),
+			"deleted_count": deletedCount,
+			"updated_count": updatedCount,
+		}).
+		Trace("Recorded sessions deleted.")
+}
@@ -3,4 +3,10 @@ package workers
 const (
 	TaskSessionCleanup = "session_record:cleanup"
 	TaskHeartbeat      = "api:heartbeat"
+	// TaskDeviceOffline sets offline the devices without a heartbeat for longer than deviceOnlineTTL.
+	TaskDeviceOffline = "api:device_offline"
+	// TaskWebhookEvent dispatches a lifecycle event to the namespace's webhooks subscribed to it.
+	TaskWebhookEvent = "webhook:event"
+	// TaskWebhookDelivery delivers a lifecycle event to a webhook.
+	TaskWebhookDelivery = "webhook:delivery"
 )
@@ -28,6 +28,12 @@ type Envs struct {
 	//
 	// Check [https://github.com/hibiken/asynq/wiki/Task-aggregation] for more information.
 	AsynqGroupMaxSize int `env:"ASYNQ_GROUP_MAX_SIZE,default=500"`
+	// WebhookMaxRetry is the maximum number of times a webhook delivery is retried before it's given up.
+	WebhookMaxRetry int `env:"WEBHOOK_MAX_RETRY,default=10"`
+	// WebhookTimeout is the maximum duration of a webhook delivery.
+	//
+	// Its time unit is second.
+	WebhookTimeout int `env:"WEBHOOK_TIMEOUT,default=10"`
 }
 
 func getEnvs() (*Envs, error) {
@@ -0,0 +1,290 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookEventHeader is the header with the event of a webhook delivery.
+	WebhookEventHeader = "X-ShellHub-Event"
+	// WebhookDeliveryHeader is the header with the ID of the event of a webhook delivery. It's the same on every retry.
+	WebhookDeliveryHeader = "X-ShellHub-Delivery"
+	// WebhookSignatureHeader is the header with the HMAC-SHA256 signature of a webhook delivery's body, in the
+	// "sha256=<hex>" format.
+	WebhookSignatureHeader = "X-ShellHub-Signature"
+)
+
+// ErrWebhookAddress is returned when a webhook's URL resolves to an address which isn't public.
+var ErrWebhookAddress = errors.New("webhook address is not public")
+
+// webhookDialControl refuses to connect to loopback, link-local, private, multicast and unspecified addresses. The
+// webhooks' URLs are set by the namespaces' users, but they are requested from inside ShellHub's network.
+//
+// The address is checked after the host is resolved, when the connection is dialed, so a host resolving to a different
+// address on each lookup cannot bypass it.
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrWebhookAddress, host)
+	}
+
+	return nil
+}
+
+// newWebhookClient creates the HTTP client used to deliver the webhooks, which only connects to public addresses.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: webhookDialControl} //nolint:exhaustruct
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// NOTICE: A proxy would be dialed instead of the webhook's address, skipping the check.
+	transport.Proxy = nil
+
+	return &http.Client{Transport: transport} //nolint:exhaustruct
+}
+
+// webhookDelivery is the payload of the [TaskWebhookDelivery] task.
+type webhookDelivery struct {
+	WebhookID string                `json:"webhook_id"`
+	Payload   models.WebhookPayload `json:"payload"`
+}
+
+// NewWebhookEventTask creates the task that dispatches event to the namespace's webhooks subscribed to it. The data
+// is the device or the session the event is about.
+func NewWebhookEventTask(tenant string, event models.WebhookEvent, data interface{}) (*asynq.Task, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&models.WebhookPayload{
+		Event:     event,
+		TenantID:  tenant,
+		Data:      raw,
+		CreatedAt: clock.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskWebhookEvent, payload, asynq.Queue("webhook"), asynq.MaxRetry(3)), nil
+}
+
+// signWebhook returns the signature of a webhook delivery's body.
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) //nolint:errcheck
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// registerWebhooks registers the workers that deliver the device and session lifecycle events to the namespaces'
+// webhooks. An event is fanned out to a delivery task for each webhook subscribed to it, and each delivery is retried
+// up to `SHELLHUB_WEBHOOK_MAX_RETRY` times until the webhook's URL answers with a 2xx status code. Every attempt is
+// recorded in the webhook's delivery log.
+func (w *Workers) registerWebhooks() {
+	w.mux.HandleFunc(TaskWebhookEvent, w.handleWebhookEvent)
+	w.mux.HandleFunc(TaskWebhookDelivery, w.handleWebhookDelivery)
+}
+
+func (w *Workers) handleWebhookEvent(ctx context.Context, task *asynq.Task) error {
+	var payload models.WebhookPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskWebhookEvent}).
+			WithError(err).
+			Error("Failed to decode the webhook event.")
+
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	if payload.ID == "" {
+		// The task's ID is kept between retries, so the event's ID is too.
+		if id, ok := asynq.GetTaskID(ctx); ok {
+			payload.ID = id
+		} else {
+			payload.ID = uuid.Generate()
+		}
+	}
+
+	webhooks, err := w.store.WebhookListByEvent(ctx, payload.TenantID, payload.Event)
+	if err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskWebhookEvent, "tenant_id": payload.TenantID}).
+			WithError(err).
+			Error("Failed to list the webhooks subscribed to the event.")
+
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		data, err := json.Marshal(&webhookDelivery{WebhookID: webhook.ID, Payload: payload})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.client.EnqueueContext(
+			ctx,
+			asynq.NewTask(TaskWebhookDelivery, data),
+			asynq.Queue("webhook"),
+			asynq.MaxRetry(w.env.WebhookMaxRetry),
+			// The task ID avoids delivering the event twice to a webhook when this task is retried.
+			asynq.TaskID(payload.ID+":"+webhook.ID),
+		)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Workers) handleWebhookDelivery(ctx context.Context, task *asynq.Task) error {
+	var delivery webhookDelivery
+	if err := json.Unmarshal(task.Payload(), &delivery); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskWebhookDelivery}).
+			WithError(err).
+			Error("Failed to decode the webhook delivery.")
+
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	logger := log.WithFields(log.Fields{
+		"component":  "worker",
+		"task":       TaskWebhookDelivery,
+		"tenant_id":  delivery.Payload.TenantID,
+		"webhook_id": delivery.WebhookID,
+		"event":      delivery.Payload.Event,
+	})
+
+	webhook, err := w.store.WebhookGet(ctx, delivery.Payload.TenantID, delivery.WebhookID)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		logger.Info("Webhook was deleted before the delivery.")
+
+		return nil
+	case err != nil:
+		return err
+	case !webhook.Active:
+		logger.Info("Webhook was deactivated before the delivery.")
+
+		return nil
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	record := &models.WebhookDelivery{
+		TenantID:  webhook.TenantID,
+		WebhookID: webhook.ID,
+		EventID:   delivery.Payload.ID,
+		Event:     delivery.Payload.Event,
+		Attempt:   retried + 1,
+		CreatedAt: clock.Now(),
+	}
+
+	status, err := w.deliverWebhook(ctx, webhook, &delivery.Payload)
+	record.StatusCode = status
+	record.Duration = clock.Now().Sub(record.CreatedAt).Milliseconds()
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	if err := w.store.WebhookDeliveryCreate(ctx, record); err != nil {
+		logger.WithError(err).Error("Failed to record the webhook delivery.")
+	}
+
+	if err != nil {
+		logger.WithError(err).WithField("attempt", record.Attempt).Warn("Failed to deliver the webhook.")
+	}
+
+	if errors.Is(err, ErrWebhookAddress) {
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	return err
+}
+
+// deliverWebhook posts the signed payload to the webhook's URL, returning the answered status code. A status code
+// other than 2xx is an error.
+func (w *Workers) deliverWebhook(ctx context.Context, webhook *models.Webhook, payload *models.WebhookPayload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(w.env.WebhookTimeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(payload.Event))
+	req.Header.Set(WebhookDeliveryHeader, payload.ID)
+	req.Header.Set(WebhookSignatureHeader, signWebhook(webhook.Secret, body))
+
+	res, err := w.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// emitWebhookEvent enqueues the event about the device to the namespace's webhooks. As the device's state was
+// already changed, a failure is only reported.
+func (w *Workers) emitWebhookEvent(ctx context.Context, uid models.UID, event models.WebhookEvent) {
+	logger := log.WithFields(log.Fields{"component": "worker", "uid": uid, "event": event})
+
+	device, err := w.store.DeviceGet(ctx, uid)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get the device of the webhook event.")
+
+		return
+	}
+
+	task, err := NewWebhookEventTask(device.TenantID, event, device)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create the webhook event.")
+
+		return
+	}
+
+	if _, err := w.client.EnqueueContext(ctx, task); err != nil {
+		logger.WithError(err).Error("Failed to enqueue the webhook event.")
+	}
+}
@@ -0,0 +1,209 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestSignWebhook(t *testing.T) {
+	// Signature computed with `echo -n '{"event":"device.online"}' | openssl dgst -sha256 -hmac secret`.
+	assert.Equal(
+		t,
+		"sha256=7cba6de03758aa17dad89295add49018ca846f8a219134cc9d0078825e27fab7",
+		signWebhook("secret", []byte(`{"event":"device.online"}`)),
+	)
+}
+
+func TestWebhookDialControl(t *testing.T) {
+	cases := []struct {
+		description string
+		address     string
+		expected    error
+	}{
+		{
+			description: "allows a public address",
+			address:     "93.184.216.34:443",
+			expected:    nil,
+		},
+		{
+			description: "allows a public IPv6 address",
+			address:     "[2606:2800:220:1:248:1893:25c8:1946]:443",
+			expected:    nil,
+		},
+		{
+			description: "refuses a loopback address",
+			address:     "127.0.0.1:8080",
+			expected:    ErrWebhookAddress,
+		},
+		{
+			description: "refuses a loopback IPv6 address",
+			address:     "[::1]:8080",
+			expected:    ErrWebhookAddress,
+		},
+		{
+			description: "refuses a private address",
+			address:     "172.17.0.2:8080",
+			expected:    ErrWebhookAddress,
+		},
+		{
+			description: "refuses a link-local address",
+			address:     "169.254.169.254:80",
+			expected:    ErrWebhookAddress,
+		},
+		{
+			description: "refuses an IPv4-mapped private address",
+			address:     "[::ffff:10.0.0.1]:80",
+			expected:    ErrWebhookAddress,
+		},
+		{
+			description: "refuses the unspecified address",
+			address:     "0.0.0.0:80",
+			expected:    ErrWebhookAddress,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.ErrorIs(t, webhookDialControl("tcp", tc.address, nil), tc.expected)
+		})
+	}
+}
+
+func TestHandleWebhookDelivery(t *testing.T) {
+	ctx := context.TODO()
+
+	payload := models.WebhookPayload{
+		ID:       "event",
+		Event:    models.WebhookEventDeviceOnline,
+		TenantID: "tenant",
+		Data:     json.RawMessage(`{"uid":"uid"}`),
+	}
+
+	cases := []struct {
+		description   string
+		status        int
+		client        func(server *httptest.Server) *http.Client
+		requiredMocks func(mock *mocks.Store, url string)
+		expected      func(t *testing.T, err error)
+	}{
+		{
+			description: "skips when the webhook was deleted",
+			status:      http.StatusOK,
+			requiredMocks: func(mock *mocks.Store, _ string) {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			description: "skips when the webhook is inactive",
+			status:      http.StatusOK,
+			requiredMocks: func(mock *mocks.Store, url string) {
+				mock.On("WebhookGet", ctx, "tenant", "id").
+					Return(&models.Webhook{ID: "id", TenantID: "tenant", URL: url, Active: false}, nil).Once()
+			},
+			expected: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			description: "fails to be retried when the URL does not answer with 2xx",
+			status:      http.StatusInternalServerError,
+			requiredMocks: func(mock *mocks.Store, url string) {
+				mock.On("WebhookGet", ctx, "tenant", "id").
+					Return(&models.Webhook{ID: "id", TenantID: "tenant", URL: url, Secret: "secret", Active: true}, nil).Once()
+				mock.On("WebhookDeliveryCreate", ctx, gomock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+					return delivery.WebhookID == "id" &&
+						delivery.EventID == "event" &&
+						delivery.Attempt == 1 &&
+						delivery.StatusCode == http.StatusInternalServerError &&
+						delivery.Error != ""
+				})).Return(nil).Once()
+			},
+			expected: func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			description: "fails without retrying when the URL resolves to a loopback address",
+			status:      http.StatusOK,
+			client: func(*httptest.Server) *http.Client {
+				return newWebhookClient()
+			},
+			requiredMocks: func(mock *mocks.Store, url string) {
+				mock.On("WebhookGet", ctx, "tenant", "id").
+					Return(&models.Webhook{ID: "id", TenantID: "tenant", URL: url, Secret: "secret", Active: true}, nil).Once()
+				mock.On("WebhookDeliveryCreate", ctx, gomock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+					return delivery.StatusCode == 0 && delivery.Error != ""
+				})).Return(nil).Once()
+			},
+			expected: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrWebhookAddress)
+				assert.ErrorIs(t, err, asynq.SkipRetry)
+			},
+		},
+		{
+			description: "succeeds",
+			status:      http.StatusNoContent,
+			requiredMocks: func(mock *mocks.Store, url string) {
+				mock.On("WebhookGet", ctx, "tenant", "id").
+					Return(&models.Webhook{ID: "id", TenantID: "tenant", URL: url, Secret: "secret", Active: true}, nil).Once()
+				mock.On("WebhookDeliveryCreate", ctx, gomock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+					return delivery.StatusCode == http.StatusNoContent && delivery.Error == ""
+				})).Return(nil).Once()
+			},
+			expected: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				assert.Equal(t, string(models.WebhookEventDeviceOnline), r.Header.Get(WebhookEventHeader))
+				assert.Equal(t, "event", r.Header.Get(WebhookDeliveryHeader))
+				assert.Equal(t, signWebhook("secret", body), r.Header.Get(WebhookSignatureHeader))
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			mock := new(mocks.Store)
+			tc.requiredMocks(mock, server.URL)
+
+			client := server.Client()
+			if tc.client != nil {
+				client = tc.client(server)
+			}
+
+			w := &Workers{
+				store: mock,
+				env:   &Envs{WebhookTimeout: 1},
+				http:  client,
+			}
+
+			data, err := json.Marshal(&webhookDelivery{WebhookID: "id", Payload: payload})
+			assert.NoError(t, err)
+
+			tc.expected(t, w.handleWebhookDelivery(ctx, asynq.NewTask(TaskWebhookDelivery, data)))
+
+			mock.AssertExpectations(t)
+		})
+	}
+}
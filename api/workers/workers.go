@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strings"
 	"time"
@@ -12,6 +13,11 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// enqueuer enqueues new tasks to the workers.
+type enqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
 type Workers struct {
 	store store.Store
 
@@ -20,6 +26,8 @@ type Workers struct {
 	mux       *asynq.ServeMux
 	env       *Envs
 	scheduler *asynq.Scheduler
+	client    enqueuer
+	http      *http.Client
 }
 
 // New creates a new Workers instance with the provided store. It initializes
@@ -53,6 +61,7 @@ func New(store store.Store) (*Workers, error) {
 			Queues: map[string]int{
 				"api":            1,
 				"session_record": 1,
+				"webhook":        1,
 			},
 			GroupAggregator: asynq.GroupAggregatorFunc(
 				func(group string, tasks []*asynq.Task) *asynq.Task {
@@ -79,6 +88,8 @@ func New(store store.Store) (*Workers, error) {
 		srv:       srv,
 		mux:       mux,
 		scheduler: scheduler,
+		client:    asynq.NewClient(addr),
+		http:      newWebhookClient(),
 		store:     store,
 	}
 
@@ -124,4 +135,6 @@ func (w *Workers) Start(ctx context.Context) {
 func (w *Workers) setupHandlers() {
 	w.registerSessionCleanup()
 	w.registerHeartbeat()
+	w.registerDeviceOffline()
+	w.registerWebhooks()
 }
@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"context"
+	"net"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -302,3 +304,68 @@ func TestAgent_GetInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestProbeTargets(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	closed.Close()
+
+	port, closedPort := listener.Addr().(*net.TCPAddr).Port, closed.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		description   string
+		requiredMocks func(cli *client_mocks.Client)
+		expected      error
+	}{
+		{
+			description: "fails when the targets cannot be listed",
+			requiredMocks: func(cli *client_mocks.Client) {
+				cli.On("ListDeviceTargets", "token").Return(nil, errors.New("error")).Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds without reporting when there is no target",
+			requiredMocks: func(cli *client_mocks.Client) {
+				cli.On("ListDeviceTargets", "token").Return([]models.DeviceTarget{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds reporting the online status of each target",
+			requiredMocks: func(cli *client_mocks.Client) {
+				cli.On("ListDeviceTargets", "token").Return([]models.DeviceTarget{
+					{UID: "online", Host: "127.0.0.1", Port: uint32(port)},
+					{UID: "offline", Host: "127.0.0.1", Port: uint32(closedPort)},
+				}, nil).Once()
+				cli.On("ProbeDeviceTargets", "token", []models.DeviceTargetProbe{
+					{UID: "online", Online: true},
+					{UID: "offline", Online: false},
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli := new(client_mocks.Client)
+			test.requiredMocks(cli)
+
+			ag := &Agent{cli: cli, authData: &models.DeviceAuthResponse{Token: "token"}}
+
+			err := ag.probeTargets(context.TODO())
+			if test.expected != nil {
+				assert.EqualError(t, err, test.expected.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			cli.AssertExpectations(t)
+		})
+	}
+}
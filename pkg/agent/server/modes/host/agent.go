@@ -0,0 +1,62 @@
+package host
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	log "github.com/sirupsen/logrus"
+)
+
+// agentSocketName is the name of the unix socket that exposes the forwarded SSH agent.
+const agentSocketName = "agent.sock"
+
+// forwardAgent exposes the client's SSH agent to the session's commands, when the client requested its forwarding,
+// through a unix socket owned by the user. The connections to the socket are tunneled back over the SSH connection.
+//
+// It returns the session's environment, including SSH_AUTH_SOCK when the agent is forwarded, and a function to remove
+// the socket when the session ends.
+func forwardAgent(session gliderssh.Session, user *osauth.User) ([]string, func()) {
+	envs := session.Environ()
+
+	if !gliderssh.AgentRequested(session) {
+		return envs, func() {}
+	}
+
+	logger := log.WithFields(log.Fields{"user": session.User()})
+
+	// The socket is created on the device's temporary directory, as the commands see it, and not on the agent's.
+	dir, err := os.MkdirTemp(command.HostPath(os.TempDir()), "ssh-agent-")
+	if err != nil {
+		logger.WithError(err).Warn("failed to create the agent forwarding directory")
+
+		return envs, func() {}
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, agentSocketName))
+	if err != nil {
+		logger.WithError(err).Warn("failed to listen on the agent forwarding socket")
+
+		os.RemoveAll(dir) //nolint:errcheck
+
+		return envs, func() {}
+	}
+
+	for _, path := range []string{dir, filepath.Join(dir, agentSocketName)} {
+		if err := os.Chown(path, int(user.UID), int(user.GID)); err != nil {
+			logger.WithError(err).Warn("failed to change the owner of the agent forwarding socket")
+		}
+	}
+
+	go gliderssh.ForwardAgentConnections(listener, session)
+
+	envs = append(envs, "SSH_AUTH_SOCK="+filepath.Join(os.TempDir(), filepath.Base(dir), agentSocketName))
+
+	return envs, func() {
+		listener.Close()
+		os.RemoveAll(dir) //nolint:errcheck
+	}
+}
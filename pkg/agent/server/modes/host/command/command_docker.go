@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
@@ -87,3 +88,9 @@ func nsenterCommandWrapper(uid, gid uint32, home string, command ...string) ([]s
 func SFTPServerCommand() *exec.Cmd {
 	return exec.Command("/proc/self/exe", []string{"sftp", string(SFTPServerModeDocker)}...)
 }
+
+// HostPath returns the path, on the agent's file system, of a path on the device's file system. As the agent runs
+// inside a container, the device's file system is mounted at "/host".
+func HostPath(path string) string {
+	return filepath.Join("/host", path)
+}
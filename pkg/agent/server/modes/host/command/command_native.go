@@ -57,3 +57,8 @@ func NewCmd(u *osauth.User, shell, term, host string, envs []string, command ...
 func SFTPServerCommand() *exec.Cmd {
 	return exec.Command("/proc/self/exe", []string{"sftp", string(SFTPServerModeNative)}...)
 }
+
+// HostPath returns the path, on the agent's file system, of a path on the device's file system.
+func HostPath(path string) string {
+	return path
+}
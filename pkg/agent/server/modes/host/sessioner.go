@@ -68,15 +68,21 @@ func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
 func (s *Sessioner) Shell(session gliderssh.Session) error {
 	sspty, winCh, isPty := session.Pty()
 
-	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term, session.Environ())
+	u := new(osauth.OSAuth).LookupUser(session.User())
+
+	envs, closeAgent := forwardAgent(session, u)
+	defer closeAgent()
+
+	envs, closeX11 := forwardX11(session, u, *s.deviceName, envs)
+	defer closeX11()
+
+	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term, envs)
 
 	pts, err := startPty(scmd, session, winCh)
 	if err != nil {
 		log.Warn(err)
 	}
 
-	u := new(osauth.OSAuth).LookupUser(session.User())
-
 	err = os.Chown(pts.Name(), int(u.UID), -1)
 	if err != nil {
 		log.Warn(err)
@@ -125,7 +131,15 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 	_, _, isPty := session.Pty()
 
-	cmd := newShellCmd(*s.deviceName, session.User(), "", session.Environ())
+	u := new(osauth.OSAuth).LookupUser(session.User())
+
+	envs, closeAgent := forwardAgent(session, u)
+	defer closeAgent()
+
+	envs, closeX11 := forwardX11(session, u, *s.deviceName, envs)
+	defer closeX11()
+
+	cmd := newShellCmd(*s.deviceName, session.User(), "", envs)
 
 	stdout, _ := cmd.StdoutPipe()
 	stdin, _ := cmd.StdinPipe()
@@ -213,7 +227,13 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, session.Environ(), shell, "-c", session.RawCommand())
+	envs, closeAgent := forwardAgent(session, user)
+	defer closeAgent()
+
+	envs, closeX11 := forwardX11(session, user, *s.deviceName, envs)
+	defer closeX11()
+
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, envs, shell, "-c", session.RawCommand())
 
 	wg := &sync.WaitGroup{}
 	if sIsPty {
@@ -0,0 +1,222 @@
+package host
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// X11RequestType is the session request sent by the client to forward its X11 display.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.3.1
+	X11RequestType = "x11-req"
+	// X11ChannelType is the channel type opened to the client for each connection to the display allocated.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.3.2
+	X11ChannelType = "x11"
+)
+
+const (
+	// x11DisplayOffset is the first display number allocated to the sessions, to not conflict with the device's own
+	// X servers.
+	x11DisplayOffset = 10
+	// x11DisplayMax is the maximum number of displays allocated at the same time.
+	x11DisplayMax = 1000
+	// x11BasePort is the TCP port of the display zero.
+	x11BasePort = 6000
+)
+
+// x11Request is the payload of the X11RequestType request.
+type x11Request struct {
+	SingleConnection bool
+	AuthProtocol     string
+	AuthCookie       string
+	ScreenNumber     uint32
+}
+
+// contextKeyX11Request is the context key for the X11 forwarding requested by the client.
+var contextKeyX11Request = &struct{ name string }{"x11-req"}
+
+// x11Channel wraps a session channel to intercept its X11RequestType requests, what are not handled by
+// [gliderssh.DefaultSessionHandler].
+type x11Channel struct {
+	gossh.NewChannel
+	ctx gliderssh.Context
+}
+
+func (c *x11Channel) Accept() (gossh.Channel, <-chan *gossh.Request, error) {
+	channel, reqs, err := c.NewChannel.Accept()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filtered := make(chan *gossh.Request)
+	go func() {
+		defer close(filtered)
+
+		for req := range reqs {
+			if req.Type != X11RequestType {
+				filtered <- req
+
+				continue
+			}
+
+			x11 := new(x11Request)
+			if err := gossh.Unmarshal(req.Payload, x11); err != nil {
+				req.Reply(false, nil) //nolint:errcheck
+
+				continue
+			}
+
+			c.ctx.SetValue(contextKeyX11Request, x11)
+
+			req.Reply(true, nil) //nolint:errcheck
+		}
+	}()
+
+	return channel, filtered, nil
+}
+
+// X11SessionHandler wraps a session channel handler to accept the X11 forwarding requests. The forwarding requested
+// is served by the host mode [Sessioner] when the session starts.
+func X11SessionHandler(handler gliderssh.ChannelHandler) gliderssh.ChannelHandler {
+	return func(srv *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		handler(srv, conn, &x11Channel{NewChannel: newChan, ctx: ctx}, ctx)
+	}
+}
+
+// forwardX11 allocates a display to the session's commands, when the client requested the X11 forwarding, writing the
+// client's cookie to the user's xauth file. The connections to the display are tunneled back over the SSH connection.
+//
+// It returns the session's environment, including DISPLAY when the X11 is forwarded, and a function to release the
+// display when the session ends.
+func forwardX11(session gliderssh.Session, user *osauth.User, deviceName string, envs []string) ([]string, func()) {
+	x11, ok := session.Context().Value(contextKeyX11Request).(*x11Request)
+	if !ok {
+		return envs, func() {}
+	}
+
+	logger := log.WithFields(log.Fields{"user": session.User()})
+
+	listener, display, err := listenX11Display()
+	if err != nil {
+		logger.WithError(err).Warn("failed to allocate a display to the X11 forwarding")
+
+		return envs, func() {}
+	}
+
+	xauth := command.NewCmd(user, "", "", deviceName, nil, "xauth", "-q", "-")
+	// NOTICE: The cookie is written through the standard input to not expose it on the process' arguments.
+	xauth.Stdin = strings.NewReader(fmt.Sprintf(
+		"remove unix:%d\nadd unix:%d.%d %s %s\n",
+		display,
+		display,
+		x11.ScreenNumber,
+		x11.AuthProtocol,
+		x11.AuthCookie,
+	))
+
+	if output, err := xauth.CombinedOutput(); err != nil {
+		logger.WithError(err).WithField("output", string(output)).Warn("failed to write the X11 forwarding cookie")
+
+		listener.Close()
+
+		return envs, func() {}
+	}
+
+	conn, ok := session.Context().Value(gliderssh.ContextKeyConn).(gossh.Conn)
+	if !ok {
+		listener.Close()
+
+		return envs, func() {}
+	}
+
+	go func() {
+		for {
+			x, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			if x11.SingleConnection {
+				listener.Close()
+			}
+
+			go forwardX11Connection(conn, x)
+		}
+	}()
+
+	envs = append(envs, fmt.Sprintf("DISPLAY=localhost:%d.%d", display, x11.ScreenNumber))
+
+	return envs, func() {
+		listener.Close()
+	}
+}
+
+// listenX11Display listens on the TCP port of the first display available on the loopback interface, returning the
+// listener and the display number.
+func listenX11Display() (net.Listener, int, error) {
+	var err error
+	for display := x11DisplayOffset; display < x11DisplayOffset+x11DisplayMax; display++ {
+		var listener net.Listener
+		if listener, err = net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(x11BasePort+display))); err == nil {
+			return listener, display, nil
+		}
+	}
+
+	return nil, 0, err
+}
+
+// forwardX11Connection opens a X11ChannelType channel to the client and pipes the connection to the display through
+// it.
+func forwardX11Connection(conn gossh.Conn, x net.Conn) {
+	defer x.Close()
+
+	host, port, _ := net.SplitHostPort(x.RemoteAddr().String())
+	originPort, _ := strconv.Atoi(port)
+
+	channel, reqs, err := conn.OpenChannel(X11ChannelType, gossh.Marshal(&struct {
+		OriginatorAddress string
+		OriginatorPort    uint32
+	}{host, uint32(originPort)}))
+	if err != nil {
+		log.WithError(err).Warn("failed to open the X11 channel")
+
+		return
+	}
+
+	defer channel.Close()
+
+	go gossh.DiscardRequests(reqs)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(x, channel) //nolint:errcheck
+		if tcp, ok := x.(*net.TCPConn); ok {
+			tcp.CloseWrite() //nolint:errcheck
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(channel, x)  //nolint:errcheck
+		channel.CloseWrite() //nolint:errcheck
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package host
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// testNewChannel is a [gossh.NewChannel] what delivers the requests sent on reqs once accepted.
+type testNewChannel struct {
+	gossh.NewChannel
+
+	reqs chan *gossh.Request
+}
+
+func (c *testNewChannel) Accept() (gossh.Channel, <-chan *gossh.Request, error) {
+	return nil, c.reqs, nil
+}
+
+func TestX11ChannelAccept(t *testing.T) {
+	ctx := &testSSHContext{Context: context.Background(), Mutex: new(sync.Mutex)}
+
+	newChan := &testNewChannel{reqs: make(chan *gossh.Request, 2)}
+	newChan.reqs <- &gossh.Request{
+		Type: X11RequestType,
+		Payload: gossh.Marshal(&x11Request{
+			AuthProtocol: "MIT-MAGIC-COOKIE-1",
+			AuthCookie:   "0123456789abcdef",
+			ScreenNumber: 0,
+		}),
+	}
+	newChan.reqs <- &gossh.Request{Type: "shell"}
+	close(newChan.reqs)
+
+	_, reqs, err := (&x11Channel{NewChannel: newChan, ctx: ctx}).Accept()
+	assert.NoError(t, err)
+
+	types := make([]string, 0)
+	for req := range reqs {
+		types = append(types, req.Type)
+	}
+
+	assert.Equal(t, []string{"shell"}, types)
+	assert.Equal(t, &x11Request{
+		AuthProtocol: "MIT-MAGIC-COOKIE-1",
+		AuthCookie:   "0123456789abcdef",
+	}, ctx.Value(contextKeyX11Request))
+}
+
+func TestListenX11Display(t *testing.T) {
+	first, display, err := listenX11Display()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer first.Close()
+
+	second, next, err := listenX11Display()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer second.Close()
+
+	assert.GreaterOrEqual(t, display, x11DisplayOffset)
+	assert.Greater(t, next, display)
+}
@@ -79,6 +79,22 @@ const (
 	ChannelDirectTcpip string = "direct-tcpip"
 )
 
+// List of SSH global requests handled by the agent.
+//
+// Check www.ietf.org/rfc/rfc4254.txt for more information.
+const (
+	// RequestTcpipForward asks the agent to listen on an address and port, forwarding the connections accepted there
+	// back through "forwarded-tcpip" channels. Besides the SSH server, the agent checks the namespace's permission
+	// to forward before listening.
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestTcpipForward string = "tcpip-forward"
+	// RequestCancelTcpipForward asks the agent to stop a forwarding started by a [RequestTcpipForward] request.
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestCancelTcpipForward string = "cancel-tcpip-forward"
+)
+
 // NewServer creates a new server SSH agent server.
 func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKey string, keepAliveInterval uint, singleUserPassword string, mode modes.Mode) *Server {
 	server := &Server{
@@ -119,15 +135,24 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
 			return true
 		},
-		ReversePortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
-			return false
-		},
+		ReversePortForwardingCallback: server.reversePortForwardingCallback,
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
 			ChannelSession:     gliderssh.DefaultSessionHandler,
 			ChannelDirectTcpip: gliderssh.DirectTCPIPHandler,
 		},
 	}
 
+	forward := &gliderssh.ForwardedTCPHandler{}
+	server.sshd.RequestHandlers = map[string]gliderssh.RequestHandler{
+		RequestTcpipForward:       forward.HandleSSHRequest,
+		RequestCancelTcpipForward: forward.HandleSSHRequest,
+	}
+
+	// NOTICE: Only the host mode allocates displays to the X11 forwarding.
+	if _, ok := mode.(*host.Mode); ok {
+		server.sshd.ChannelHandlers[ChannelSession] = host.X11SessionHandler(gliderssh.DefaultSessionHandler)
+	}
+
 	err := server.sshd.SetOption(gliderssh.HostKeyFile(privateKey))
 	if err != nil {
 		log.Warn(err)
@@ -191,6 +216,22 @@ func (s *Server) sessionRequestCallback(session gliderssh.Session, requestType s
 	return true
 }
 
+// reversePortForwardingCallback allows the agent to listen on the device only when the device's namespace allows the
+// reverse port forwarding, the same setting checked by the SSH server.
+func (s *Server) reversePortForwardingCallback(_ gliderssh.Context, host string, port uint32) bool {
+	ok, err := s.api.EvaluateReverseForwarding(s.authData.Token)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"host": host,
+			"port": port,
+		}).Error("failed to evaluate the reverse port forwarding")
+
+		return false
+	}
+
+	return ok
+}
+
 func (s *Server) HandleConn(conn net.Conn) {
 	s.sshd.HandleConn(conn)
 }
@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// AgentProbeDefaultInterval is the default time interval between probes to the agentless targets.
+const AgentProbeDefaultInterval time.Duration = 0
+
+// probeTimeout is the maximum time an agentless target has to accept the probe's connection.
+const probeTimeout = 5 * time.Second
+
+// Probe probes the agentless targets reached through the device every interval, reporting to the server whether each
+// one accepts connections on its SSH port.
+//
+// If the interval is 0, the default value set to it will be 30 seconds, keeping the online targets from expiring on
+// the server between the probes.
+func (a *Agent) Probe(ctx context.Context, interval time.Duration) error {
+	if interval == AgentProbeDefaultInterval {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if a.isClosed() {
+				return nil
+			}
+
+			if err := a.probeTargets(ctx); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"version":        AgentVersion,
+					"tenant_id":      a.authData.Namespace,
+					"server_address": a.config.ServerAddress,
+				}).Warn("failed to probe the agentless targets")
+			}
+		}
+	}
+}
+
+// probeTargets probes every agentless target reached through the device at once, reporting the results to the server.
+func (a *Agent) probeTargets(ctx context.Context) error {
+	targets, err := a.cli.ListDeviceTargets(a.authData.Token)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	probes := make([]models.DeviceTargetProbe, len(targets))
+
+	wg := new(sync.WaitGroup)
+	for i, target := range targets {
+		wg.Add(1)
+
+		go func(i int, target models.DeviceTarget) {
+			defer wg.Done()
+
+			probes[i] = models.DeviceTargetProbe{UID: target.UID, Online: probeTarget(ctx, target)}
+		}(i, target)
+	}
+
+	wg.Wait()
+
+	return a.cli.ProbeDeviceTargets(a.authData.Token, probes)
+}
+
+// probeTarget checks whether the agentless target accepts connections on its SSH port.
+func probeTarget(ctx context.Context, target models.DeviceTarget) bool {
+	dialer := &net.Dialer{Timeout: probeTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(target.Host, strconv.FormatUint(uint64(target.Port), 10)))
+	if err != nil {
+		return false
+	}
+
+	conn.Close()
+
+	return true
+}
@@ -26,6 +26,12 @@ type publicAPI interface {
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
 	NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error)
+	// ListDeviceTargets lists the agentless targets the device's agent probes.
+	ListDeviceTargets(token string) ([]models.DeviceTarget, error)
+	// ProbeDeviceTargets reports the agent's probes to the agentless targets.
+	ProbeDeviceTargets(token string, probes []models.DeviceTargetProbe) error
+	// EvaluateReverseForwarding checks if the device's namespace allows the agent to accept remote port forwardings.
+	EvaluateReverseForwarding(token string) (bool, error)
 }
 
 //go:generate mockery --name=Client --filename=client.go
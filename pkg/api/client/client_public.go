@@ -102,6 +102,55 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 	return res, nil
 }
 
+func (c *client) ListDeviceTargets(token string) ([]models.DeviceTarget, error) {
+	var targets []models.DeviceTarget
+
+	response, err := c.http.R().
+		SetResult(&targets).
+		SetAuthToken(token).
+		Get("/api/targets")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+func (c *client) ProbeDeviceTargets(token string, probes []models.DeviceTargetProbe) error {
+	response, err := c.http.R().
+		SetBody(map[string]interface{}{"probes": probes}).
+		SetAuthToken(token).
+		Post("/api/targets/probes")
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
+func (c *client) EvaluateReverseForwarding(token string) (bool, error) {
+	response, err := c.http.R().
+		SetAuthToken(token).
+		Get("/api/forwarding/reverse")
+	if err != nil {
+		return false, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 // NewReverseListener creates a new reverse listener connection for the Agent from ShellHub's SSH server.
 //
 // Every time the ShellHub's SSH server receives a new connection to the Agent, the server sends that connection
@@ -20,6 +20,10 @@ type Client struct {
 func (_m *Client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error) {
 	ret := _m.Called(req)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AuthDevice")
+	}
+
 	var r0 *models.DeviceAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)); ok {
@@ -46,6 +50,10 @@ func (_m *Client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthR
 func (_m *Client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(req, token)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AuthPublicKey")
+	}
+
 	var r0 *models.PublicKeyAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*models.PublicKeyAuthRequest, string) (*models.PublicKeyAuthResponse, error)); ok {
@@ -72,6 +80,10 @@ func (_m *Client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string)
 func (_m *Client) Endpoints() (*models.Endpoints, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for Endpoints")
+	}
+
 	var r0 *models.Endpoints
 	var r1 error
 	if rf, ok := ret.Get(0).(func() (*models.Endpoints, error)); ok {
@@ -94,10 +106,42 @@ func (_m *Client) Endpoints() (*models.Endpoints, error) {
 	return r0, r1
 }
 
+// EvaluateReverseForwarding provides a mock function with given fields: token
+func (_m *Client) EvaluateReverseForwarding(token string) (bool, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateReverseForwarding")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
 	var r0 *models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Device, error)); ok {
@@ -124,6 +168,10 @@ func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 func (_m *Client) GetInfo(agentVersion string) (*models.Info, error) {
 	ret := _m.Called(agentVersion)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetInfo")
+	}
+
 	var r0 *models.Info
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Info, error)); ok {
@@ -146,10 +194,44 @@ func (_m *Client) GetInfo(agentVersion string) (*models.Info, error) {
 	return r0, r1
 }
 
+// ListDeviceTargets provides a mock function with given fields: token
+func (_m *Client) ListDeviceTargets(token string) ([]models.DeviceTarget, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceTargets")
+	}
+
+	var r0 []models.DeviceTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.DeviceTarget, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.DeviceTarget); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDevices provides a mock function with given fields:
 func (_m *Client) ListDevices() ([]models.Device, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevices")
+	}
+
 	var r0 []models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]models.Device, error)); ok {
@@ -176,6 +258,10 @@ func (_m *Client) ListDevices() ([]models.Device, error) {
 func (_m *Client) NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error) {
 	ret := _m.Called(ctx, token)
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewReverseListener")
+	}
+
 	var r0 *revdial.Listener
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*revdial.Listener, error)); ok {
@@ -198,13 +284,30 @@ func (_m *Client) NewReverseListener(ctx context.Context, token string) (*revdia
 	return r0, r1
 }
 
-type mockConstructorTestingTNewClient interface {
-	mock.TestingT
-	Cleanup(func())
+// ProbeDeviceTargets provides a mock function with given fields: token, probes
+func (_m *Client) ProbeDeviceTargets(token string, probes []models.DeviceTargetProbe) error {
+	ret := _m.Called(token, probes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProbeDeviceTargets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []models.DeviceTargetProbe) error); ok {
+		r0 = rf(token, probes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewClient(t mockConstructorTestingTNewClient) *Client {
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
 	mock := &Client{}
 	mock.Mock.Test(t)
 
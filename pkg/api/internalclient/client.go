@@ -24,6 +24,7 @@ type Client interface {
 	sessionAPI
 	sshkeyAPI
 	firewallAPI
+	forwardingAPI
 }
 
 // Ensures the client implements Client.
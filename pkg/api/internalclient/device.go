@@ -19,6 +19,9 @@ type deviceAPI interface {
 
 	GetDeviceByPublicURLAddress(address string) (*models.Device, error)
 
+	// GetDeviceCredentials retrieves the credentials stored to authenticate on the agentless target with the UID.
+	GetDeviceCredentials(uid string) (*models.DeviceCredentials, error)
+
 	// DevicesOffline updates a device's status to offline.
 	DevicesOffline(id string) error
 
@@ -120,6 +123,27 @@ func (c *client) GetDevice(uid string) (*models.Device, error) {
 	}
 }
 
+func (c *client) GetDeviceCredentials(uid string) (*models.DeviceCredentials, error) {
+	credentials := new(models.DeviceCredentials)
+
+	resp, err := c.http.
+		R().
+		SetResult(&credentials).
+		Get(fmt.Sprintf("/internal/devices/%s/credentials", uid))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 404:
+		return nil, ErrNotFound
+	case 200:
+		return credentials, nil
+	default:
+		return nil, ErrUnknown
+	}
+}
+
 func (c *client) GetDeviceByPublicURLAddress(address string) (*models.Device, error) {
 	httpClient := resty.New()
 
@@ -21,7 +21,10 @@ var (
 )
 
 func (c *client) FirewallEvaluate(lookup map[string]string) error {
+	// NOTICE: A client with the same base URL is used to limit the number of retries, as the connection waits for the
+	// evaluation.
 	local := resty.New()
+	local.SetBaseURL(c.http.BaseURL)
 	local.AddRetryCondition(func(r *resty.Response, err error) bool {
 		if _, ok := err.(net.Error); ok {
 			return true
@@ -34,7 +37,7 @@ func (c *client) FirewallEvaluate(lookup map[string]string) error {
 		SetRetryCount(10).
 		R().
 		SetQueryParams(lookup).
-		Get("http://cloud-api:8080/internal/firewall/rules/evaluate")
+		Get("/internal/firewall/evaluate")
 	if err != nil {
 		return ErrFirewallConnection
 	}
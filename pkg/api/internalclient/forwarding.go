@@ -0,0 +1,46 @@
+package internalclient
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+// forwardingAPI defines methods for interacting with port forwarding-related functionality.
+type forwardingAPI interface {
+	// ForwardingEvaluate evaluates the namespace's forwarding rules against a local port forwarding request.
+	// It returns an error if the evaluation fails or if a forwarding rule prohibits the forwarding.
+	ForwardingEvaluate(req requests.ForwardingEvaluate) error
+}
+
+var (
+	ErrForwardingConnection = errors.New("failed to make the request to evaluate the port forwarding")
+	ErrForwardingBlock      = errors.New("a forwarding rule prohibit this port forwarding")
+)
+
+func (c *client) ForwardingEvaluate(req requests.ForwardingEvaluate) error {
+	resp, err := c.http.
+		R().
+		SetQueryParams(map[string]string{
+			"tenant_id":   req.TenantID,
+			"username":    req.Username,
+			"fingerprint": req.Fingerprint,
+			"host":        req.Host,
+			"port":        strconv.FormatUint(uint64(req.Port), 10),
+		}).
+		Get("/internal/forwarding/evaluate")
+	if err != nil {
+		return ErrForwardingConnection
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden:
+		return ErrForwardingBlock
+	default:
+		return ErrForwardingConnection
+	}
+}
@@ -205,17 +205,17 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
-// FinishSession provides a mock function with given fields: uid
-func (_m *Client) FinishSession(uid string) []error {
-	ret := _m.Called(uid)
+// FinishSession provides a mock function with given fields: uid, reason
+func (_m *Client) FinishSession(uid string, reason models.SessionCloseReason) []error {
+	ret := _m.Called(uid, reason)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FinishSession")
 	}
 
 	var r0 []error
-	if rf, ok := ret.Get(0).(func(string) []error); ok {
-		r0 = rf(uid)
+	if rf, ok := ret.Get(0).(func(string, models.SessionCloseReason) []error); ok {
+		r0 = rf(uid, reason)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]error)
@@ -243,6 +243,24 @@ func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 	return r0
 }
 
+// ForwardingEvaluate provides a mock function with given fields: req
+func (_m *Client) ForwardingEvaluate(req requests.ForwardingEvaluate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForwardingEvaluate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(requests.ForwardingEvaluate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -303,6 +321,36 @@ func (_m *Client) GetDeviceByPublicURLAddress(address string) (*models.Device, e
 	return r0, r1
 }
 
+// GetDeviceCredentials provides a mock function with given fields: uid
+func (_m *Client) GetDeviceCredentials(uid string) (*models.DeviceCredentials, error) {
+	ret := _m.Called(uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceCredentials")
+	}
+
+	var r0 *models.DeviceCredentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.DeviceCredentials, error)); ok {
+		return rf(uid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.DeviceCredentials); ok {
+		r0 = rf(uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceCredentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPublicKey provides a mock function with given fields: fingerprint, tenant
 func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.PublicKey, error) {
 	ret := _m.Called(fingerprint, tenant)
@@ -353,6 +401,36 @@ func (_m *Client) KeepAliveSession(uid string) []error {
 	return r0
 }
 
+// ListCertificateAuthorities provides a mock function with given fields: tenant
+func (_m *Client) ListCertificateAuthorities(tenant string) ([]models.CertificateAuthority, error) {
+	ret := _m.Called(tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCertificateAuthorities")
+	}
+
+	var r0 []models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.CertificateAuthority, error)); ok {
+		return rf(tenant)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.CertificateAuthority); ok {
+		r0 = rf(tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDevices provides a mock function with given fields:
 func (_m *Client) ListDevices() ([]models.Device, error) {
 	ret := _m.Called()
@@ -501,6 +579,42 @@ func (_m *Client) SessionCreate(session requests.SessionCreate) error {
 	return r0
 }
 
+// SessionEvent provides a mock function with given fields: uid, event
+func (_m *Client) SessionEvent(uid string, event *models.SessionEvent) error {
+	ret := _m.Called(uid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *models.SessionEvent) error); ok {
+		r0 = rf(uid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShadowSession provides a mock function with given fields: uid, tenant, role
+func (_m *Client) ShadowSession(uid string, tenant string, role string) error {
+	ret := _m.Called(uid, tenant, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShadowSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(uid, tenant, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
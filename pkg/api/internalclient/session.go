@@ -1,7 +1,9 @@
 package internalclient
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -17,9 +19,10 @@ type sessionAPI interface {
 	// It returns a slice of errors encountered during the operation.
 	SessionAsAuthenticated(uid string) []error
 
-	// FinishSession finishes the session with the specified uid.
+	// FinishSession finishes the session with the specified uid, recording the reason why ShellHub closed it, if it
+	// did.
 	// It returns a slice of errors encountered during the operation.
-	FinishSession(uid string) []error
+	FinishSession(uid string, reason models.SessionCloseReason) []error
 
 	// KeepAliveSession sends a keep-alive signal for the session with the specified uid.
 	// It returns a slice of errors encountered during the operation.
@@ -27,8 +30,18 @@ type sessionAPI interface {
 
 	// RecordSession records a session with the provided session information and record URL.
 	RecordSession(session *models.SessionRecorded, recordURL string) error
+
+	// SessionEvent records something done in the session with the specified uid besides its terminal.
+	SessionEvent(uid string, event *models.SessionEvent) error
+
+	// ShadowSession checks if a namespace's member, with the tenant and role got from its token, can shadow the
+	// session with the specified uid.
+	// It returns [ErrSessionShadowDenied] when the member cannot shadow the session.
+	ShadowSession(uid, tenant, role string) error
 }
 
+var ErrSessionShadowDenied = errors.New("not allowed to shadow the session")
+
 func (c *client) SessionCreate(session requests.SessionCreate) error {
 	_, err := c.http.
 		R().
@@ -54,11 +67,14 @@ func (c *client) SessionAsAuthenticated(uid string) []error {
 	return errors
 }
 
-func (c *client) FinishSession(uid string) []error {
+func (c *client) FinishSession(uid string, reason models.SessionCloseReason) []error {
 	var errors []error
 
 	_, err := c.http.
 		R().
+		SetBody(map[string]interface{}{
+			"reason": reason,
+		}).
 		Post(fmt.Sprintf("/internal/sessions/%s/finish", uid))
 	if err != nil {
 		errors = append(errors, err)
@@ -88,3 +104,32 @@ func (c *client) RecordSession(session *models.SessionRecorded, recordURL string
 
 	return err
 }
+
+func (c *client) SessionEvent(uid string, event *models.SessionEvent) error {
+	_, err := c.http.
+		R().
+		SetBody(event).
+		Post(fmt.Sprintf("/internal/sessions/%s/events", uid))
+
+	return err
+}
+
+func (c *client) ShadowSession(uid, tenant, role string) error {
+	resp, err := c.http.
+		R().
+		SetHeader("X-Tenant-ID", tenant).
+		SetHeader("X-Role", role).
+		Get(fmt.Sprintf("/internal/sessions/%s/shadow", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden, http.StatusNotFound:
+		return ErrSessionShadowDenied
+	default:
+		return ErrUnknown
+	}
+}
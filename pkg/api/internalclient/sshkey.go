@@ -16,6 +16,9 @@ type sshkeyAPI interface {
 
 	// EvaluateKey evaluates whether a given public key identified by fingerprint is valid for a device and username combination.
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
+
+	// ListCertificateAuthorities retrieves the certificate authorities trusted by the namespace identified by tenant.
+	ListCertificateAuthorities(tenant string) ([]models.CertificateAuthority, error)
 }
 
 func (c *client) GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error) {
@@ -55,6 +58,24 @@ func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username st
 	return false, nil
 }
 
+func (c *client) ListCertificateAuthorities(tenant string) ([]models.CertificateAuthority, error) {
+	cas := make([]models.CertificateAuthority, 0)
+
+	resp, err := c.http.
+		R().
+		SetResult(&cas).
+		Get(fmt.Sprintf("/internal/sshkeys/certificate-authorities/%s", tenant))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, ErrUnknown
+	}
+
+	return cas, nil
+}
+
 func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
 	privKey := new(models.PrivateKey)
 
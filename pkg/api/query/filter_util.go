@@ -17,6 +17,9 @@ const (
 // Examples:
 // A FilterProperty with Operator "gt", Name "count", and Value 12 will filter documents with the attribute "count" greater than 12.
 // Another FilterProperty with Operator "eq", Name "alias", and Value "foobar" will filter documents with the attribute "alias" equal to "foobar".
+// Nested attributes are addressed with dots: a FilterProperty with Operator "eq", Name "attributes.site", and Value "lab"
+// will filter devices with the custom attribute "site" equal to "lab", while Operator "exists" and Value true will filter
+// devices with the attribute "site" set.
 type FilterProperty struct {
 	// Name is the attribute to be observed in the operation.
 	Name string `json:"name"`
@@ -0,0 +1,10 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// AuditList is the structure to represent the request data for list the audit logs of a namespace.
+type AuditList struct {
+	TenantParam
+	query.Paginator
+	query.Filters
+}
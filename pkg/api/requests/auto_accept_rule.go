@@ -0,0 +1,57 @@
+package requests
+
+// AutoAcceptRuleIDParam is a structure to represent and validate an auto-accept rule ID as path param.
+type AutoAcceptRuleIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AutoAcceptFilter is the structure to represent the filter of an auto-accept rule. A device matches the filter when
+// it matches every non-empty criterion, so at least one criterion must be set.
+type AutoAcceptFilter struct {
+	// ID, Version, Arch and Platform are compared with the device's info sent by the agent.
+	ID       string `json:"id" validate:"required_without_all=Version Arch Platform MACPrefix Hostname SourceIP,max=255"`
+	Version  string `json:"version" validate:"required_without_all=ID Arch Platform MACPrefix Hostname SourceIP,max=255"`
+	Arch     string `json:"arch" validate:"required_without_all=ID Version Platform MACPrefix Hostname SourceIP,max=255"`
+	Platform string `json:"platform" validate:"required_without_all=ID Version Arch MACPrefix Hostname SourceIP,max=255"`
+	// MACPrefix is a case-insensitive prefix of the device's MAC address.
+	MACPrefix string `json:"mac_prefix" validate:"required_without_all=ID Version Arch Platform Hostname SourceIP,max=17"`
+	// Hostname is a regexp matched against the device's name.
+	Hostname string `json:"hostname" validate:"required_without_all=ID Version Arch Platform MACPrefix SourceIP,omitempty,regexp"`
+	// SourceIP is a CIDR containing the address the device registered from.
+	SourceIP string `json:"source_ip" validate:"required_without_all=ID Version Arch Platform MACPrefix Hostname,omitempty,cidr"`
+}
+
+// AutoAcceptRuleFields is the structure to represent the fields of an auto-accept rule.
+type AutoAcceptRuleFields struct {
+	// Priority is the rule's priority. Rules with lower priority are evaluated first.
+	Priority int `json:"priority"`
+	// Action is the action applied when the rule matches. It can be either "accept" or "reject".
+	Action string `json:"action" validate:"required,oneof=accept reject"`
+	// Active indicates if the rule is evaluated.
+	Active bool `json:"active"`
+	// Tags are applied to the devices accepted by the rule. Rules rejecting devices can't have tags.
+	Tags []string `json:"tags" validate:"max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Filter is the rule's filter to match the registering devices.
+	Filter AutoAcceptFilter `json:"filter"`
+}
+
+// AutoAcceptRuleGet is the structure to represent the request data for get auto-accept rule endpoint.
+type AutoAcceptRuleGet struct {
+	AutoAcceptRuleIDParam
+}
+
+// AutoAcceptRuleCreate is the structure to represent the request data for create auto-accept rule endpoint.
+type AutoAcceptRuleCreate struct {
+	AutoAcceptRuleFields
+}
+
+// AutoAcceptRuleUpdate is the structure to represent the request data for update auto-accept rule endpoint.
+type AutoAcceptRuleUpdate struct {
+	AutoAcceptRuleIDParam
+	AutoAcceptRuleFields
+}
+
+// AutoAcceptRuleDelete is the structure to represent the request data for delete auto-accept rule endpoint.
+type AutoAcceptRuleDelete struct {
+	AutoAcceptRuleIDParam
+}
@@ -0,0 +1,20 @@
+package requests
+
+// CertificateAuthorityList is the structure to represent the request data for the internal list certificate
+// authorities endpoint.
+type CertificateAuthorityList struct {
+	TenantParam
+}
+
+// CertificateAuthorityCreate is the structure to represent the request data for create certificate authority endpoint.
+type CertificateAuthorityCreate struct {
+	// Data is the certificate authority's public key, in the authorized keys format.
+	Data []byte `json:"data" validate:"required"`
+	// Name is the certificate authority's name.
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// CertificateAuthorityDelete is the structure to represent the request data for delete certificate authority endpoint.
+type CertificateAuthorityDelete struct {
+	FingerprintParam
+}
@@ -1,5 +1,10 @@
 package requests
 
+import (
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
 // DeviceParam is a structure to represent and validate a device UID as path param.
 type DeviceParam struct {
 	UID string `param:"uid" validate:"required"`
@@ -63,6 +68,64 @@ type DeviceUpdateTag struct {
 	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// DeviceSetAttributes is the structure to represent the request data for device set attributes endpoint.
+type DeviceSetAttributes struct {
+	DeviceParam
+	// Attributes are the attributes set on the device. Attributes already set and not present are kept.
+	Attributes map[string]string `json:"attributes" validate:"required,min=1,max=32,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+}
+
+// DeviceSetVia is the structure to represent the request data for set device via endpoint.
+type DeviceSetVia struct {
+	DeviceParam
+	// Device is the UID of the device which the connections jump from.
+	Device string `json:"device" validate:"required"`
+	// Host is the device's address on the network of the device which the connections jump from.
+	Host string `json:"host" validate:"required,hostname_rfc1123|ip"`
+	// Port is the device's SSH port. When zero, the port 22 is used.
+	Port uint32 `json:"port" validate:"omitempty,min=1,max=65535"`
+}
+
+// DeviceUnsetVia is the structure to represent the request data for unset device via endpoint.
+type DeviceUnsetVia struct {
+	DeviceParam
+}
+
+// DeviceTargetCreate is the structure to represent the request data for create agentless target endpoint.
+type DeviceTargetCreate struct {
+	Name string `json:"name" validate:"required,device_name"`
+	// Gateway is the UID of the device whose agent reaches the target.
+	Gateway string `json:"gateway" validate:"required"`
+	// Host is the target's address on the network of the gateway device.
+	Host string `json:"host" validate:"required,hostname_rfc1123|ip"`
+	// Port is the target's SSH port. When zero, the port 22 is used.
+	Port uint32 `json:"port" validate:"omitempty,min=1,max=65535"`
+	// Password is the stored password used to authenticate on the target.
+	Password string `json:"password" validate:"omitempty,max=256"`
+	// PrivateKey is the stored private key, PEM encoded, used to authenticate on the target.
+	PrivateKey string `json:"private_key" validate:"omitempty,max=16384"`
+}
+
+// DeviceTargetList is the structure to represent the request data for list agentless targets endpoint, used by the
+// agent of the gateway device.
+type DeviceTargetList struct {
+	// Gateway is the UID of the device authenticated by its token.
+	Gateway string `json:"-" validate:"required"`
+}
+
+// DeviceTargetProbe is the structure to represent the request data for probe agentless targets endpoint, used by the
+// agent of the gateway device.
+type DeviceTargetProbe struct {
+	DeviceTargetList
+	Probes []models.DeviceTargetProbe `json:"probes" validate:"max=1024,dive"`
+}
+
+// DeviceUnsetAttribute is the structure to represent the request data for device unset attribute endpoint.
+type DeviceUnsetAttribute struct {
+	DeviceParam
+	Key string `param:"key" validate:"required"`
+}
+
 type DeviceIdentity struct {
 	MAC string `json:"mac"`
 }
@@ -99,3 +162,18 @@ type DeviceUpdate struct {
 type DevicePublicURLAddress struct {
 	PublicURLAddress string `param:"address" validate:"required"`
 }
+
+// DeviceBulk is the structure to represent the request data for device bulk operation endpoint.
+//
+// The devices are selected either by their UIDs or by a filter, never both.
+type DeviceBulk struct {
+	UIDs   []string       `json:"uids" validate:"required_without=Filter,excluded_with=Filter,unique,dive,required"`
+	Filter []query.Filter `json:"filter" validate:"required_without=UIDs"`
+	// Operation is the operation applied to each selected device.
+	Operation string `json:"operation" validate:"required,oneof=accept reject remove rename add_tag remove_tag"`
+	// Tag is the tag added or removed by the add_tag and remove_tag operations.
+	Tag string `json:"tag" validate:"required_if=Operation add_tag,required_if=Operation remove_tag,omitempty,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Name is the template of the devices' new names for the rename operation, where {name} is replaced by the
+	// device's current name.
+	Name string `json:"name" validate:"required_if=Operation rename,omitempty,contains={name}"`
+}
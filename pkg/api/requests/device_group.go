@@ -0,0 +1,46 @@
+package requests
+
+// DeviceGroupIDParam is a structure to represent and validate a device group ID as path param.
+type DeviceGroupIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// DeviceGroupFilter is the structure to represent the filter of a device group. A device matches the filter when it
+// has any of its tags and every one of its attributes.
+type DeviceGroupFilter struct {
+	Tags       []string          `json:"tags" validate:"max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Attributes map[string]string `json:"attributes" validate:"max=8,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+}
+
+// DeviceGroupFields is the structure to represent the fields of a device group.
+type DeviceGroupFields struct {
+	Name        string `json:"name" validate:"required,min=1,max=64"`
+	Description string `json:"description" validate:"max=255"`
+	// Parent is the ID of the group's parent group, if any.
+	Parent string `json:"parent" validate:"omitempty,len=24,hexadecimal"`
+	// Devices are the UIDs of the devices statically added to the group.
+	Devices []string `json:"devices" validate:"max=1000,unique,dive,required"`
+	// Filter selects the devices dynamically added to the group.
+	Filter DeviceGroupFilter `json:"filter"`
+}
+
+// DeviceGroupGet is the structure to represent the request data for get device group endpoint.
+type DeviceGroupGet struct {
+	DeviceGroupIDParam
+}
+
+// DeviceGroupCreate is the structure to represent the request data for create device group endpoint.
+type DeviceGroupCreate struct {
+	DeviceGroupFields
+}
+
+// DeviceGroupUpdate is the structure to represent the request data for update device group endpoint.
+type DeviceGroupUpdate struct {
+	DeviceGroupIDParam
+	DeviceGroupFields
+}
+
+// DeviceGroupDelete is the structure to represent the request data for delete device group endpoint.
+type DeviceGroupDelete struct {
+	DeviceGroupIDParam
+}
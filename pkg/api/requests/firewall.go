@@ -0,0 +1,74 @@
+package requests
+
+// FirewallRuleIDParam is a structure to represent and validate a firewall rule ID as path param.
+type FirewallRuleIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// FirewallFilter is the structure to represent the filter of a firewall rule. It can contain only one of Hostname, Tags,
+// Attributes or Group.
+type FirewallFilter struct {
+	Hostname string   `json:"hostname,omitempty" validate:"required_without_all=Tags Attributes Group,excluded_with=Tags Attributes Group,regexp"`
+	Tags     []string `json:"tags,omitempty" validate:"required_without_all=Hostname Attributes Group,excluded_with=Hostname Attributes Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Attributes are the device attributes that must all be set on the device.
+	Attributes map[string]string `json:"attributes,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,max=8,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+	// Group is the ID of a device group the device must be a member of.
+	Group string `json:"group,omitempty" validate:"omitempty,excluded_with=Hostname Tags Attributes,len=24,hexadecimal"`
+}
+
+// FirewallRuleFields is the structure to represent the fields of a firewall rule.
+type FirewallRuleFields struct {
+	// Priority is the rule's priority. Rules with lower priority are evaluated first.
+	Priority int `json:"priority"`
+	// Action is the action applied when the rule matches. It can be either "allow" or "deny".
+	Action string `json:"action" validate:"required,oneof=allow deny"`
+	// Active indicates if the rule is evaluated.
+	Active bool `json:"active"`
+	// SourceIP is a regexp matched against the connection's source IP address.
+	SourceIP string `json:"source_ip" validate:"required,regexp"`
+	// Username is a regexp matched against the username used to connect to the device.
+	Username string `json:"username" validate:"required,regexp"`
+	// Filter is the rule's filter to match the device by hostname or tags.
+	Filter FirewallFilter `json:"filter" validate:"required"`
+}
+
+// FirewallRuleGet is the structure to represent the request data for get firewall rule endpoint.
+type FirewallRuleGet struct {
+	FirewallRuleIDParam
+}
+
+// FirewallRuleCreate is the structure to represent the request data for create firewall rule endpoint.
+type FirewallRuleCreate struct {
+	FirewallRuleFields
+}
+
+// FirewallRuleUpdate is the structure to represent the request data for update firewall rule endpoint.
+type FirewallRuleUpdate struct {
+	FirewallRuleIDParam
+	FirewallRuleFields
+}
+
+// FirewallRuleDelete is the structure to represent the request data for delete firewall rule endpoint.
+type FirewallRuleDelete struct {
+	FirewallRuleIDParam
+}
+
+// FirewallRulesReorder is the structure to represent the request data for reorder firewall rules endpoint.
+type FirewallRulesReorder struct {
+	// Rules is the list of firewall rule's IDs in the desired evaluation order.
+	Rules []string `json:"rules" validate:"required,min=1,unique"`
+}
+
+// FirewallEvaluate is the structure to represent the request data for evaluate firewall endpoint.
+//
+// The fields are the lookup values sent by the SSH server when a connection is being established.
+type FirewallEvaluate struct {
+	// Domain is the namespace's name.
+	Domain string `query:"domain" validate:"required"`
+	// Name is the device's name.
+	Name string `query:"name" validate:"required"`
+	// Username is the username used to connect to the device.
+	Username string `query:"username" validate:"required"`
+	// IPAddress is the source IP address of the connection.
+	IPAddress string `query:"ip_address" validate:"required"`
+}
@@ -0,0 +1,81 @@
+package requests
+
+// ForwardingRuleIDParam is a structure to represent and validate a forwarding rule ID as path param.
+type ForwardingRuleIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// ForwardingPortRange is the structure to represent an inclusive range of TCP ports.
+type ForwardingPortRange struct {
+	Start uint32 `json:"start" validate:"required,min=1,max=65535"`
+	End   uint32 `json:"end" validate:"required,min=1,max=65535,gtefield=Start"`
+}
+
+// ForwardingFilter is the structure to represent the filter of a forwarding rule. A connection matches the filter
+// when it matches every non-empty criterion.
+type ForwardingFilter struct {
+	// Username is a regexp matched against the whole username used to connect to the device.
+	Username string `json:"username" validate:"omitempty,regexp"`
+	// Fingerprint is the fingerprint of the public key used to authenticate the connection.
+	Fingerprint string `json:"fingerprint" validate:"omitempty,max=255"`
+}
+
+// ForwardingRuleFields is the structure to represent the fields of a forwarding rule.
+type ForwardingRuleFields struct {
+	// Priority is the rule's priority. Rules with lower priority are evaluated first.
+	Priority int `json:"priority"`
+	// Action is the action applied when the rule matches. It can be either "allow" or "deny".
+	Action string `json:"action" validate:"required,oneof=allow deny"`
+	// Active indicates if the rule is evaluated.
+	Active bool `json:"active"`
+	// Host is a CIDR containing the forwarding's destination address.
+	Host string `json:"host" validate:"required,cidr"`
+	// Ports is the range of the forwarding's destination ports.
+	Ports ForwardingPortRange `json:"ports"`
+	// Filter is the rule's filter to match the connections requesting the forwarding.
+	Filter ForwardingFilter `json:"filter"`
+}
+
+// ForwardingRuleGet is the structure to represent the request data for get forwarding rule endpoint.
+type ForwardingRuleGet struct {
+	ForwardingRuleIDParam
+}
+
+// ForwardingRuleCreate is the structure to represent the request data for create forwarding rule endpoint.
+type ForwardingRuleCreate struct {
+	ForwardingRuleFields
+}
+
+// ForwardingRuleUpdate is the structure to represent the request data for update forwarding rule endpoint.
+type ForwardingRuleUpdate struct {
+	ForwardingRuleIDParam
+	ForwardingRuleFields
+}
+
+// ForwardingRuleDelete is the structure to represent the request data for delete forwarding rule endpoint.
+type ForwardingRuleDelete struct {
+	ForwardingRuleIDParam
+}
+
+// ReverseForwardingEvaluate is the structure to represent the request data for evaluate reverse forwarding endpoint,
+// used by the agent before listening on the device for a remote port forwarding.
+type ReverseForwardingEvaluate struct {
+	// UID is the UID of the device authenticated by its token.
+	UID string `json:"-" validate:"required"`
+}
+
+// ForwardingEvaluate is the structure to represent the request data for evaluate forwarding endpoint.
+//
+// The fields are sent by the SSH server when a client requests a local port forwarding.
+type ForwardingEvaluate struct {
+	// TenantID is the device's namespace.
+	TenantID string `query:"tenant_id" validate:"required"`
+	// Username is the username used to connect to the device.
+	Username string `query:"username" validate:"required"`
+	// Fingerprint is the fingerprint of the public key used to authenticate the connection, if any.
+	Fingerprint string `query:"fingerprint"`
+	// Host is the forwarding's destination host.
+	Host string `query:"host" validate:"required"`
+	// Port is the forwarding's destination port.
+	Port uint32 `query:"port" validate:"required,min=1,max=65535"`
+}
@@ -1,5 +1,7 @@
 package requests
 
+import "encoding/json"
+
 // TenantParam is a structure to represent and validate a namespace tenant as path param.
 type TenantParam struct {
 	Tenant string `param:"tenant" validate:"required,uuid"`
@@ -31,13 +33,41 @@ type NamespaceDelete struct {
 	TenantParam
 }
 
+// RecordRetentionDays is the number of days the namespace's session records are kept. Zero keeps the records forever,
+// and an explicit null unsets the retention, falling back to the instance's default.
+type RecordRetentionDays struct {
+	// Days is the retention. It's nil when the field is omitted or null.
+	Days *int `validate:"omitempty,min=0,max=3650"`
+	// Set indicates the field was sent, even when it's null.
+	Set bool
+}
+
+func (r *RecordRetentionDays) UnmarshalJSON(data []byte) error {
+	r.Set = true
+
+	return json.Unmarshal(data, &r.Days)
+}
+
+// Unset checks if the request unsets the retention.
+func (r *RecordRetentionDays) Unset() bool {
+	return r.Set && r.Days == nil
+}
+
 // NamespaceEdit is the structure to represent the request data for edit namespace endpoint.
 type NamespaceEdit struct {
 	TenantParam
 	Name     string `json:"name" validate:"omitempty,hostname_rfc1123,excludes=."`
 	Settings struct {
-		SessionRecord          *bool   `json:"session_record" validate:"omitempty"`
-		ConnectionAnnouncement *string `json:"connection_announcement" validate:"omitempty,min=0,max=127"`
+		SessionRecord          *bool               `json:"session_record" validate:"omitempty"`
+		ConnectionAnnouncement *string             `json:"connection_announcement" validate:"omitempty,min=0,max=127"`
+		RecordRetentionDays    RecordRetentionDays `json:"record_retention_days"`
+		ReversePortForwarding  *bool               `json:"reverse_port_forwarding" validate:"omitempty"`
+		AgentForwarding        *bool               `json:"agent_forwarding" validate:"omitempty"`
+		IdleTimeout            *int                `json:"idle_timeout" validate:"omitempty,min=0,max=10080"`
+		MaxSessionDuration     *int                `json:"max_session_duration" validate:"omitempty,min=0,max=10080"`
+		ShadowNotice           *bool               `json:"shadow_notice" validate:"omitempty"`
+		RecordExecOutput       *bool               `json:"record_exec_output" validate:"omitempty"`
+		RecordInput            *bool               `json:"record_input" validate:"omitempty"`
 	} `json:"settings"`
 }
 
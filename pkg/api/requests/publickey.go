@@ -12,22 +12,31 @@ type PublicKeyGet struct {
 }
 
 type PublicKeyFilter struct {
-	Hostname string `json:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
+	Hostname string `json:"hostname,omitempty" validate:"required_without_all=Tags Attributes Group,excluded_with=Tags Attributes Group,regexp"`
 	// FIXME: add validation for tags when it has at least one item.
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without_all=Hostname Attributes Group,excluded_with=Hostname Attributes Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Attributes are the device attributes that must all be set on the device.
+	Attributes map[string]string `json:"attributes,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,max=8,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+	// Group is the ID of a device group the device must be a member of.
+	Group string `json:"group,omitempty" validate:"omitempty,excluded_with=Hostname Tags Attributes,len=24,hexadecimal"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
 type PublicKeyCreate struct {
-	Data        []byte          `json:"data" validate:"required"`
-	Filter      PublicKeyFilter `json:"filter" validate:"required"`
-	Name        string          `json:"name" validate:"required"`
-	Username    string          `json:"username" validate:"required,regexp"`
-	TenantID    string          `json:"-"`
-	Fingerprint string          `json:"-"`
+	Data     []byte          `json:"data" validate:"required"`
+	Filter   PublicKeyFilter `json:"filter" validate:"required"`
+	Name     string          `json:"name" validate:"required"`
+	Username string          `json:"username" validate:"required,regexp"`
+	// ForceCommand is the command executed on every session authenticated by the public key.
+	ForceCommand string `json:"force_command,omitempty" validate:"omitempty,max=4096"`
+	// AllowedCommands are the regular expressions of the commands allowed on the sessions authenticated by the public
+	// key.
+	AllowedCommands []string `json:"allowed_commands,omitempty" validate:"omitempty,excluded_with=ForceCommand,max=32,dive,required,max=1024,regexp"`
+	TenantID        string   `json:"-"`
+	Fingerprint     string   `json:"-"`
 }
 
 // PublicKeyUpdate is the structure to represent the request data for update public key endpoint.
@@ -39,6 +48,11 @@ type PublicKeyUpdate struct {
 	Username string `json:"username" validate:"required,regexp"`
 	// Filter is the public key's filter.
 	Filter PublicKeyFilter `json:"filter" validate:"required"`
+	// ForceCommand is the command executed on every session authenticated by the public key.
+	ForceCommand string `json:"force_command,omitempty" validate:"omitempty,max=4096"`
+	// AllowedCommands are the regular expressions of the commands allowed on the sessions authenticated by the public
+	// key.
+	AllowedCommands []string `json:"allowed_commands,omitempty" validate:"omitempty,excluded_with=ForceCommand,max=32,dive,required,max=1024,regexp"`
 }
 
 // PublicKeyDelete is the structure to represent the request data for delete public key endpoint.
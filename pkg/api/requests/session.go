@@ -1,5 +1,11 @@
 package requests
 
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
 // SessionIDParam is a structure to represent and validate a session UID as path param.
 type SessionIDParam struct {
 	// UID is the session's UID.
@@ -25,11 +31,59 @@ type SessionCreate struct {
 	IPAddress string `json:"ip_address" validate:"required"`
 	Type      string `json:"type" validate:"required"`
 	Term      string `json:"term" validate:""`
+	// Certificate is the SSH certificate used to authenticate the session, if any.
+	Certificate *SessionCertificate `json:"certificate,omitempty"`
+	// Hops are the UIDs of the devices the session jumped through to reach the device, if any.
+	Hops []string `json:"hops,omitempty"`
+}
+
+// SessionCertificate is the structure to represent the SSH certificate used to authenticate a session.
+type SessionCertificate struct {
+	Serial uint64 `json:"serial"`
+	KeyID  string `json:"key_id"`
 }
 
 // SessionFinish is the structure to represent the request data for finish session endpoint.
 type SessionFinish struct {
 	SessionIDParam
+	// Reason is the reason why the session was closed by ShellHub, if it was.
+	Reason string `json:"reason" validate:"omitempty,oneof=idle_timeout max_duration user"`
+}
+
+// SessionClose is the structure to represent the request data for close session endpoint.
+type SessionClose struct {
+	SessionIDParam
+}
+
+// SessionShare is the structure to represent the request data for share session endpoint.
+type SessionShare struct {
+	SessionIDParam
+	// Guest is the name of the guest invited to the session.
+	Guest string `json:"guest" validate:"required,min=1,max=64,printascii,excludes=/"`
+	// Input allows the guest to type on the session since it attaches.
+	Input bool `json:"input"`
+}
+
+// SessionShareInput is the structure to represent the request data for grant or revoke the guest's input endpoint.
+type SessionShareInput struct {
+	SessionIDParam
+	Guest string `param:"guest" validate:"required"`
+	Input bool   `json:"input"`
+}
+
+// SessionEventCreate is the structure to represent the request data for create session's event endpoint.
+type SessionEventCreate struct {
+	SessionIDParam
+	Type string `json:"type" validate:"required,oneof=pty-req window-change shell exec subsystem direct-tcpip exit-status exit-signal exec-result sftp"`
+	// Timestamp is when the event happened. When zero, the time the event is created is used.
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// SessionEventList is the structure to represent the request data for list session's events endpoint.
+type SessionEventList struct {
+	SessionIDParam
+	query.Paginator
 }
 
 // SessionFinish is the structure to represent the request data for keep alive session endpoint.
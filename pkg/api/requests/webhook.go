@@ -0,0 +1,46 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// WebhookIDParam is a structure to represent and validate a webhook ID as path param.
+type WebhookIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// WebhookCreate is the structure to represent the request data for create webhook endpoint.
+type WebhookCreate struct {
+	// URL is where the events' payloads are posted to. The events aren't delivered when it resolves to a loopback,
+	// link-local or private address.
+	URL string `json:"url" validate:"required,url,startswith=http,max=2048"`
+	// Events are the lifecycle events the webhook subscribes to.
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=device.pending device.accepted device.online device.offline session.started session.authenticated session.finished"`
+	// Secret is the key used to sign the payloads. When empty, a random one is generated.
+	Secret string `json:"secret" validate:"omitempty,min=16,max=255"`
+	// Active indicates if the events are delivered to the webhook. When nil, the webhook is active.
+	Active *bool `json:"active"`
+}
+
+// WebhookGet is the structure to represent the request data for get webhook endpoint.
+type WebhookGet struct {
+	WebhookIDParam
+}
+
+// WebhookUpdate is the structure to represent the request data for update webhook endpoint. Omitted fields are left
+// unchanged.
+type WebhookUpdate struct {
+	WebhookIDParam
+	URL    *string  `json:"url" validate:"omitempty,url,startswith=http,max=2048"`
+	Events []string `json:"events" validate:"omitempty,min=1,unique,dive,oneof=device.pending device.accepted device.online device.offline session.started session.authenticated session.finished"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookDelete is the structure to represent the request data for delete webhook endpoint.
+type WebhookDelete struct {
+	WebhookIDParam
+}
+
+// WebhookDeliveryList is the structure to represent the request data for list the delivery log of a webhook.
+type WebhookDeliveryList struct {
+	WebhookIDParam
+	query.Paginator
+}
@@ -0,0 +1,10 @@
+package responses
+
+// DeviceBulkResult is the outcome of a bulk operation on a device.
+type DeviceBulkResult struct {
+	UID string `json:"uid"`
+	// Status is the HTTP status code the single-device route would have answered with.
+	Status int `json:"status"`
+	// Error is the reason of the failure, when Status isn't 200.
+	Error string `json:"error,omitempty"`
+}
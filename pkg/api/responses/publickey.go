@@ -6,7 +6,9 @@ type PublicKeyFilter struct {
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags       []string          `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Group      string            `json:"group,omitempty"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
@@ -17,4 +19,9 @@ type PublicKeyCreate struct {
 	Username    string          `json:"username"`
 	TenantID    string          `json:"tenant_id"`
 	Fingerprint string          `json:"fingerprint"`
+	// ForceCommand is the command executed on every session authenticated by the public key.
+	ForceCommand string `json:"force_command,omitempty"`
+	// AllowedCommands are the regular expressions of the commands allowed on the sessions authenticated by the public
+	// key.
+	AllowedCommands []string `json:"allowed_commands,omitempty"`
 }
@@ -0,0 +1,9 @@
+package responses
+
+// SessionShare is the structure to represent the response data for share session endpoint.
+type SessionShare struct {
+	// Token is the invite's token, used by the guest to attach to the session.
+	Token string `json:"token"`
+	Guest string `json:"guest"`
+	Input bool   `json:"input"`
+}
@@ -0,0 +1,113 @@
+package models
+
+import "time"
+
+// AuditAction is the action recorded by an audit log.
+type AuditAction string
+
+const (
+	AuditActionDeviceCreate       AuditAction = "device.create"
+	AuditActionDeviceUpdateStatus AuditAction = "device.update_status"
+	AuditActionDeviceUpdate       AuditAction = "device.update"
+	AuditActionDeviceUpdateTags   AuditAction = "device.update_tags"
+	AuditActionDeviceDelete       AuditAction = "device.delete"
+
+	AuditActionNamespaceCreate       AuditAction = "namespace.create"
+	AuditActionNamespaceUpdate       AuditAction = "namespace.update"
+	AuditActionNamespaceDelete       AuditAction = "namespace.delete"
+	AuditActionNamespaceMemberAdd    AuditAction = "namespace.member.add"
+	AuditActionNamespaceMemberUpdate AuditAction = "namespace.member.update"
+	AuditActionNamespaceMemberRemove AuditAction = "namespace.member.remove"
+
+	AuditActionPublicKeyCreate     AuditAction = "public_key.create"
+	AuditActionPublicKeyUpdate     AuditAction = "public_key.update"
+	AuditActionPublicKeyUpdateTags AuditAction = "public_key.update_tags"
+	AuditActionPublicKeyDelete     AuditAction = "public_key.delete"
+
+	AuditActionFirewallRuleCreate  AuditAction = "firewall_rule.create"
+	AuditActionFirewallRuleUpdate  AuditAction = "firewall_rule.update"
+	AuditActionFirewallRuleReorder AuditAction = "firewall_rule.reorder"
+	AuditActionFirewallRuleDelete  AuditAction = "firewall_rule.delete"
+
+	AuditActionSessionRecordDelete AuditAction = "session.record.delete"
+	AuditActionSessionClose        AuditAction = "session.close"
+	AuditActionSessionShare        AuditAction = "session.share"
+	AuditActionSessionShareInput   AuditAction = "session.share.input"
+
+	AuditActionWebhookCreate AuditAction = "webhook.create"
+	AuditActionWebhookUpdate AuditAction = "webhook.update"
+	AuditActionWebhookDelete AuditAction = "webhook.delete"
+
+	AuditActionAutoAcceptRuleCreate AuditAction = "auto_accept_rule.create"
+	AuditActionAutoAcceptRuleUpdate AuditAction = "auto_accept_rule.update"
+	AuditActionAutoAcceptRuleDelete AuditAction = "auto_accept_rule.delete"
+
+	AuditActionDeviceGroupCreate AuditAction = "device_group.create"
+	AuditActionDeviceGroupUpdate AuditAction = "device_group.update"
+	AuditActionDeviceGroupDelete AuditAction = "device_group.delete"
+
+	AuditActionForwardingRuleCreate AuditAction = "forwarding_rule.create"
+	AuditActionForwardingRuleUpdate AuditAction = "forwarding_rule.update"
+	AuditActionForwardingRuleDelete AuditAction = "forwarding_rule.delete"
+
+	AuditActionCertificateAuthorityCreate AuditAction = "certificate_authority.create"
+	AuditActionCertificateAuthorityDelete AuditAction = "certificate_authority.delete"
+
+	AuditActionTagRename AuditAction = "tag.rename"
+	AuditActionTagDelete AuditAction = "tag.delete"
+
+	AuditActionAPIKeyCreate AuditAction = "api_key.create"
+	AuditActionAPIKeyUpdate AuditAction = "api_key.update"
+	AuditActionAPIKeyDelete AuditAction = "api_key.delete"
+)
+
+// AuditTargetType is the type of the resource changed by an audited action.
+type AuditTargetType string
+
+const (
+	AuditTargetDevice       AuditTargetType = "device"
+	AuditTargetNamespace    AuditTargetType = "namespace"
+	AuditTargetMember       AuditTargetType = "member"
+	AuditTargetPublicKey    AuditTargetType = "public_key"
+	AuditTargetFirewallRule AuditTargetType = "firewall_rule"
+	AuditTargetSession      AuditTargetType = "session"
+
+	AuditTargetWebhook              AuditTargetType = "webhook"
+	AuditTargetAutoAcceptRule       AuditTargetType = "auto_accept_rule"
+	AuditTargetDeviceGroup          AuditTargetType = "device_group"
+	AuditTargetForwardingRule       AuditTargetType = "forwarding_rule"
+	AuditTargetCertificateAuthority AuditTargetType = "certificate_authority"
+	AuditTargetTag                  AuditTargetType = "tag"
+	AuditTargetAPIKey               AuditTargetType = "api_key"
+)
+
+// AuditActor is who performed an audited action.
+type AuditActor struct {
+	ID       string `json:"id" bson:"id"`
+	Username string `json:"username" bson:"username"`
+}
+
+// AuditTarget is the resource changed by an audited action.
+type AuditTarget struct {
+	Type AuditTargetType `json:"type" bson:"type"`
+	ID   string          `json:"id" bson:"id"`
+}
+
+// AuditChange is a field changed by an audited action. Nested fields are represented by a dot-separated path, like
+// "settings.session_record".
+type AuditChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+// AuditLog is the record of a mutation performed on a namespace's resource.
+type AuditLog struct {
+	ID        string        `json:"id" bson:"_id,omitempty"`
+	TenantID  string        `json:"tenant_id" bson:"tenant_id"`
+	Actor     AuditActor    `json:"actor" bson:"actor"`
+	Action    AuditAction   `json:"action" bson:"action"`
+	Target    AuditTarget   `json:"target" bson:"target"`
+	Changes   []AuditChange `json:"changes" bson:"changes"`
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+}
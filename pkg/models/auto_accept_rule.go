@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// AutoAcceptRuleAction is the action applied to a device matched by an auto-accept rule.
+type AutoAcceptRuleAction string
+
+const (
+	// AutoAcceptRuleActionAccept accepts the device, applying the rule's tags, if any.
+	AutoAcceptRuleActionAccept AutoAcceptRuleAction = "accept"
+	// AutoAcceptRuleActionReject rejects the device.
+	AutoAcceptRuleActionReject AutoAcceptRuleAction = "reject"
+)
+
+// AutoAcceptFilter selects the devices matched by an auto-accept rule. A device matches the filter when it matches
+// every non-empty criterion.
+type AutoAcceptFilter struct {
+	// ID, Version, Arch and Platform are compared with the device's [DeviceInfo] fields.
+	ID       string `json:"id,omitempty" bson:"id,omitempty"`
+	Version  string `json:"version,omitempty" bson:"version,omitempty"`
+	Arch     string `json:"arch,omitempty" bson:"arch,omitempty"`
+	Platform string `json:"platform,omitempty" bson:"platform,omitempty"`
+	// MACPrefix is a case-insensitive prefix of the device's MAC address.
+	MACPrefix string `json:"mac_prefix,omitempty" bson:"mac_prefix,omitempty"`
+	// Hostname is a regexp matched against the device's name.
+	Hostname string `json:"hostname,omitempty" bson:"hostname,omitempty"`
+	// SourceIP is a CIDR containing the address the device registered from.
+	SourceIP string `json:"source_ip,omitempty" bson:"source_ip,omitempty"`
+}
+
+type AutoAcceptRuleFields struct {
+	// Priority is the rule's priority. Rules with lower priority are evaluated first.
+	Priority int                  `json:"priority" bson:"priority"`
+	Action   AutoAcceptRuleAction `json:"action" bson:"action"`
+	Active   bool                 `json:"active" bson:"active"`
+	// Tags are applied to the devices accepted by the rule.
+	Tags   []string         `json:"tags" bson:"tags"`
+	Filter AutoAcceptFilter `json:"filter" bson:"filter"`
+}
+
+// AutoAcceptRule decides the status of the namespace's devices when they register for the first time. The first
+// active rule matching the device, by priority, is applied; devices matching no rule are left pending.
+type AutoAcceptRule struct {
+	ID                   string `json:"id" bson:"_id,omitempty"`
+	TenantID             string `json:"tenant_id" bson:"tenant_id"`
+	AutoAcceptRuleFields `bson:",inline"`
+	CreatedAt            time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" bson:"updated_at"`
+}
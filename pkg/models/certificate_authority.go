@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// CertificateAuthority is a SSH certificate authority trusted by the namespace. The OpenSSH user certificates signed by
+// it are accepted as the public key authentication of the namespace's devices, when the certificate is valid and one
+// of its principals is the username used to connect.
+type CertificateAuthority struct {
+	// Data is the certificate authority's public key, in the authorized keys format.
+	Data        []byte    `json:"data"`
+	Fingerprint string    `json:"fingerprint"`
+	Name        string    `json:"name"`
+	TenantID    string    `json:"tenant_id" bson:"tenant_id"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
@@ -19,24 +19,64 @@ const (
 
 type Device struct {
 	// UID is the unique identifier for a device.
-	UID              string          `json:"uid"`
-	Name             string          `json:"name" bson:"name,omitempty" validate:"required,device_name"`
-	Identity         *DeviceIdentity `json:"identity"`
-	Info             *DeviceInfo     `json:"info"`
-	PublicKey        string          `json:"public_key" bson:"public_key"`
-	TenantID         string          `json:"tenant_id" bson:"tenant_id"`
-	LastSeen         time.Time       `json:"last_seen" bson:"last_seen"`
-	Online           bool            `json:"online" bson:",omitempty"`
-	Namespace        string          `json:"namespace" bson:",omitempty"`
-	Status           DeviceStatus    `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused"`
-	StatusUpdatedAt  time.Time       `json:"status_updated_at" bson:"status_updated_at,omitempty"`
-	CreatedAt        time.Time       `json:"created_at" bson:"created_at,omitempty"`
-	RemoteAddr       string          `json:"remote_addr" bson:"remote_addr"`
-	Position         *DevicePosition `json:"position" bson:"position"`
-	Tags             []string        `json:"tags" bson:"tags,omitempty"`
-	PublicURL        bool            `json:"public_url" bson:"public_url,omitempty"`
-	PublicURLAddress string          `json:"public_url_address" bson:"public_url_address,omitempty"`
-	Acceptable       bool            `json:"acceptable" bson:"acceptable,omitempty"`
+	UID             string          `json:"uid"`
+	Name            string          `json:"name" bson:"name,omitempty" validate:"required,device_name"`
+	Identity        *DeviceIdentity `json:"identity"`
+	Info            *DeviceInfo     `json:"info"`
+	PublicKey       string          `json:"public_key" bson:"public_key"`
+	TenantID        string          `json:"tenant_id" bson:"tenant_id"`
+	LastSeen        time.Time       `json:"last_seen" bson:"last_seen"`
+	Online          bool            `json:"online" bson:",omitempty"`
+	Namespace       string          `json:"namespace" bson:",omitempty"`
+	Status          DeviceStatus    `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused"`
+	StatusUpdatedAt time.Time       `json:"status_updated_at" bson:"status_updated_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at" bson:"created_at,omitempty"`
+	RemoteAddr      string          `json:"remote_addr" bson:"remote_addr"`
+	Position        *DevicePosition `json:"position" bson:"position"`
+	Tags            []string        `json:"tags" bson:"tags,omitempty"`
+	// Attributes are free-form key/value pairs describing the device, such as its site, rack or owner.
+	Attributes       map[string]string `json:"attributes" bson:"attributes,omitempty"`
+	PublicURL        bool              `json:"public_url" bson:"public_url,omitempty"`
+	PublicURLAddress string            `json:"public_url_address" bson:"public_url_address,omitempty"`
+	Acceptable       bool              `json:"acceptable" bson:"acceptable,omitempty"`
+	// Via is the device which the connections jump from to reach the device, when it sits on a network reachable only
+	// from there.
+	Via *DeviceVia `json:"via,omitempty" bson:"via,omitempty"`
+	// Agentless reports whether the device is an agentless target, a host registered by its address that cannot run
+	// the agent, reached through the agent of the device set as its via.
+	Agentless bool `json:"agentless,omitempty" bson:"agentless,omitempty"`
+	// Credentials are used to authenticate on the agentless target's own SSH server. They are never sent to the users.
+	Credentials *DeviceCredentials `json:"-" bson:"credentials,omitempty"`
+}
+
+// DeviceVia is the device which the connections jump from to reach another device, as a SSH jump host.
+type DeviceVia struct {
+	// Device is the UID of the device which the connections jump from.
+	Device string `json:"device" bson:"device"`
+	// Host is the device's address on the network of the device which the connections jump from.
+	Host string `json:"host" bson:"host"`
+	// Port is the device's SSH port.
+	Port uint32 `json:"port" bson:"port"`
+}
+
+// DeviceCredentials are the credentials stored to authenticate on an agentless target. They're encrypted by the API
+// before being stored, and only decrypted when the SSH server gets them to authenticate on the target.
+type DeviceCredentials struct {
+	Password   string `json:"password,omitempty" bson:"password,omitempty"`
+	PrivateKey string `json:"private_key,omitempty" bson:"private_key,omitempty"`
+}
+
+// DeviceTarget is an agentless target probed by the agent of the device it is reached through.
+type DeviceTarget struct {
+	UID  string `json:"uid"`
+	Host string `json:"host"`
+	Port uint32 `json:"port"`
+}
+
+// DeviceTargetProbe is the result of an agent's probe to an agentless target.
+type DeviceTargetProbe struct {
+	UID    string `json:"uid"`
+	Online bool   `json:"online"`
 }
 
 type DeviceAuthClaims struct {
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// DeviceGroupFilter selects the devices dynamically added to a device group. A device matches the filter when it has
+// any of its tags and every one of its attributes. An empty filter matches no device.
+type DeviceGroupFilter struct {
+	Tags       []string          `json:"tags,omitempty" bson:"tags,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty" bson:"attributes,omitempty"`
+}
+
+// IsEmpty checks if the filter has no criteria.
+func (f *DeviceGroupFilter) IsEmpty() bool {
+	return len(f.Tags) == 0 && len(f.Attributes) == 0
+}
+
+type DeviceGroupFields struct {
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description" bson:"description"`
+	// Parent is the ID of the group's parent group, if any. A group's members are also members of its ancestors.
+	Parent string `json:"parent,omitempty" bson:"parent,omitempty"`
+	// Devices are the UIDs of the devices statically added to the group.
+	Devices []string `json:"devices" bson:"devices"`
+	// Filter selects the devices dynamically added to the group.
+	Filter DeviceGroupFilter `json:"filter" bson:"filter"`
+}
+
+// DeviceGroup is a named set of a namespace's devices, used to manage the access to them as a whole. Groups can be
+// nested, the members of a group being its own members plus the members of its descendants.
+type DeviceGroup struct {
+	ID                string `json:"id" bson:"_id,omitempty"`
+	TenantID          string `json:"tenant_id" bson:"tenant_id"`
+	DeviceGroupFields `bson:",inline"`
+	CreatedAt         time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// HasMember checks if the device is a direct member of the group, either statically or through the group's filter.
+// Members of the group's descendants are not considered.
+func (g *DeviceGroup) HasMember(device *Device) bool {
+	for _, uid := range g.Devices {
+		if uid == device.UID {
+			return true
+		}
+	}
+
+	if g.Filter.IsEmpty() {
+		return false
+	}
+
+	if len(g.Filter.Tags) > 0 {
+		tagged := false
+		for _, tag := range device.Tags {
+			for _, t := range g.Filter.Tags {
+				if tag == t {
+					tagged = true
+				}
+			}
+		}
+
+		if !tagged {
+			return false
+		}
+	}
+
+	for key, value := range g.Filter.Attributes {
+		if current, ok := device.Attributes[key]; !ok || current != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -6,12 +6,22 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+const (
+	// FirewallRuleActionAllow allows the connection when the firewall rule matches.
+	FirewallRuleActionAllow = "allow"
+	// FirewallRuleActionDeny denies the connection when the firewall rule matches.
+	FirewallRuleActionDeny = "deny"
+)
+
 // FirewallFilter contains the filter rule of a Public Key.
 //
-// A FirewallFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A FirewallFilter can contain only one of Hostname, string, Tags, slice of strings, Attributes, a map of device
+// attributes that must all be set on the device, or Group, the ID of a device group the device must be a member of.
 type FirewallFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname   string            `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Attributes Group,excluded_with=Tags Attributes Group,regexp"`
+	Tags       []string          `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Attributes Group,excluded_with=Hostname Attributes Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Attributes map[string]string `json:"attributes,omitempty" bson:"attributes,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,max=8,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+	Group      string            `json:"group,omitempty" bson:"group,omitempty" validate:"omitempty,excluded_with=Hostname Tags Attributes,len=24,hexadecimal"`
 }
 
 type FirewallRuleFields struct {
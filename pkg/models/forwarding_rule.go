@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ForwardingRuleAction is the action applied to a port forwarding matched by a forwarding rule.
+type ForwardingRuleAction string
+
+const (
+	// ForwardingRuleActionAllow allows the port forwarding.
+	ForwardingRuleActionAllow ForwardingRuleAction = "allow"
+	// ForwardingRuleActionDeny denies the port forwarding.
+	ForwardingRuleActionDeny ForwardingRuleAction = "deny"
+)
+
+// ForwardingPortRange is an inclusive range of TCP ports.
+type ForwardingPortRange struct {
+	Start uint32 `json:"start" bson:"start"`
+	End   uint32 `json:"end" bson:"end"`
+}
+
+// ForwardingFilter selects the connections a forwarding rule applies to. A connection matches the filter when it
+// matches every non-empty criterion.
+type ForwardingFilter struct {
+	// Username is a regexp matched against the whole username used to connect to the device.
+	Username string `json:"username,omitempty" bson:"username,omitempty"`
+	// Fingerprint is the fingerprint of the public key used to authenticate the connection.
+	Fingerprint string `json:"fingerprint,omitempty" bson:"fingerprint,omitempty"`
+}
+
+type ForwardingRuleFields struct {
+	// Priority is the rule's priority. Rules with lower priority are evaluated first.
+	Priority int                  `json:"priority" bson:"priority"`
+	Action   ForwardingRuleAction `json:"action" bson:"action"`
+	Active   bool                 `json:"active" bson:"active"`
+	// Host is a CIDR containing the forwarding's destination address. Destinations set as a hostname, instead of an
+	// IP address, are resolved by the device, so they are allowed only by the CIDRs containing every address, as
+	// "0.0.0.0/0", but denied by any CIDR.
+	Host   string              `json:"host" bson:"host"`
+	Ports  ForwardingPortRange `json:"ports" bson:"ports"`
+	Filter ForwardingFilter    `json:"filter" bson:"filter"`
+}
+
+// ForwardingRule decides whether a local port forwarding, requested through a "direct-tcpip" channel, is allowed to
+// reach its destination from the device. The first active rule matching the forwarding, by priority, is applied;
+// forwardings matching no rule are allowed.
+type ForwardingRule struct {
+	ID                   string `json:"id" bson:"_id,omitempty"`
+	TenantID             string `json:"tenant_id" bson:"tenant_id"`
+	ForwardingRuleFields `bson:",inline"`
+	CreatedAt            time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" bson:"updated_at"`
+}
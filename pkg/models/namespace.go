@@ -52,6 +52,33 @@ func (n *Namespace) FindMember(id string) (*Member, bool) {
 type NamespaceSettings struct {
 	SessionRecord          bool   `json:"session_record" bson:"session_record,omitempty"`
 	ConnectionAnnouncement string `json:"connection_announcement" bson:"connection_announcement"`
+	// RecordRetentionDays is the number of days the session records are kept. When nil, the instance's default
+	// retention is used; when zero, the records are kept forever.
+	RecordRetentionDays *int `json:"record_retention_days,omitempty" bson:"record_retention_days,omitempty"`
+	// ReversePortForwarding allows the namespace's devices to accept remote port forwarding requests, as `ssh -R`.
+	ReversePortForwarding bool `json:"reverse_port_forwarding" bson:"reverse_port_forwarding,omitempty"`
+	// AgentForwarding allows the namespace's sessions to forward the client's SSH agent to the device, as `ssh -A`.
+	// When nil, the agent forwarding is allowed.
+	AgentForwarding *bool `json:"agent_forwarding,omitempty" bson:"agent_forwarding,omitempty"`
+	// IdleTimeout is the number of minutes without input from the client after which the namespace's sessions are
+	// closed. When zero, the sessions are never closed due to inactivity.
+	IdleTimeout int `json:"idle_timeout" bson:"idle_timeout,omitempty"`
+	// MaxSessionDuration is the maximum number of minutes the namespace's sessions can last. When zero, the sessions
+	// have no maximum duration.
+	MaxSessionDuration int `json:"max_session_duration" bson:"max_session_duration,omitempty"`
+	// ShadowNotice notifies the users of the namespace's sessions when someone starts to shadow them.
+	ShadowNotice bool `json:"shadow_notice" bson:"shadow_notice,omitempty"`
+	// RecordExecOutput keeps the beginning of the output of the commands executed without a terminal on the
+	// sessions' events.
+	RecordExecOutput bool `json:"record_exec_output" bson:"record_exec_output,omitempty"`
+	// RecordInput records the input typed on the namespace's sessions alongside their output. The input typed while
+	// the terminal doesn't echo it, like passwords, is masked.
+	RecordInput bool `json:"record_input" bson:"record_input,omitempty"`
+}
+
+// AllowAgentForwarding checks if the namespace's sessions can forward the client's SSH agent to the device.
+func (s *NamespaceSettings) AllowAgentForwarding() bool {
+	return s.AgentForwarding == nil || *s.AgentForwarding
 }
 
 type Member struct {
@@ -64,4 +91,14 @@ type NamespaceChanges struct {
 	Name                   string  `bson:"name,omitempty"`
 	SessionRecord          *bool   `bson:"settings.session_record,omitempty"`
 	ConnectionAnnouncement *string `bson:"settings.connection_announcement,omitempty"`
+	RecordRetentionDays    *int    `bson:"settings.record_retention_days,omitempty"`
+	ReversePortForwarding  *bool   `bson:"settings.reverse_port_forwarding,omitempty"`
+	AgentForwarding        *bool   `bson:"settings.agent_forwarding,omitempty"`
+	IdleTimeout            *int    `bson:"settings.idle_timeout,omitempty"`
+	MaxSessionDuration     *int    `bson:"settings.max_session_duration,omitempty"`
+	ShadowNotice           *bool   `bson:"settings.shadow_notice,omitempty"`
+	RecordExecOutput       *bool   `bson:"settings.record_exec_output,omitempty"`
+	RecordInput            *bool   `bson:"settings.record_input,omitempty"`
+	// UnsetRecordRetentionDays removes the namespace's retention, falling back to the instance's default.
+	UnsetRecordRetentionDays bool `bson:"-"`
 }
@@ -9,16 +9,25 @@ import (
 
 // PublicKeyFilter contains the filter rule of a Public Key.
 //
-// A PublicKeyFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A PublicKeyFilter can contain only one of Hostname, string, Tags, slice of strings, Attributes, a map of device
+// attributes that must all be set on the device, or Group, the ID of a device group the device must be a member of.
 type PublicKeyFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname   string            `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Attributes Group,excluded_with=Tags Attributes Group,regexp"`
+	Tags       []string          `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Attributes Group,excluded_with=Hostname Attributes Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Attributes map[string]string `json:"attributes,omitempty" bson:"attributes,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,max=8,dive,keys,min=1,max=64,excludesall=.$,endkeys,max=255"`
+	Group      string            `json:"group,omitempty" bson:"group,omitempty" validate:"omitempty,excluded_with=Hostname Tags Attributes,len=24,hexadecimal"`
 }
 
 type PublicKeyFields struct {
 	Name     string          `json:"name"`
 	Username string          `json:"username" bson:"username" validate:"regexp"`
 	Filter   PublicKeyFilter `json:"filter" bson:"filter" validate:"required"`
+	// ForceCommand is the command executed on every session authenticated by the public key, replacing the shell,
+	// the command or the subsystem requested by the client, like the OpenSSH's ForceCommand.
+	ForceCommand string `json:"force_command,omitempty" bson:"force_command"`
+	// AllowedCommands are the regular expressions of the commands allowed to be executed on the sessions authenticated
+	// by the public key. Each expression must match the whole command. When set, shells and subsystems are denied.
+	AllowedCommands []string `json:"allowed_commands,omitempty" bson:"allowed_commands" validate:"omitempty,excluded_with=ForceCommand,dive,regexp"`
 }
 
 func (p *PublicKeyFields) Validate() error {
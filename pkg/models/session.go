@@ -25,6 +25,37 @@ type Session struct {
 	Type          string          `json:"type" bson:"type"`
 	Term          string          `json:"term" bson:"term"`
 	Position      SessionPosition `json:"position" bson:"position"`
+	// Certificate is the SSH certificate used to authenticate the session, when authenticated by a certificate
+	// authority trusted by the namespace.
+	Certificate *SessionCertificate `json:"certificate,omitempty" bson:"certificate,omitempty"`
+	// CloseReason is the reason why ShellHub closed the session. It is empty when the session was ended by the client
+	// or by the device.
+	CloseReason SessionCloseReason `json:"close_reason,omitempty" bson:"close_reason,omitempty"`
+	// ClosedBy is the ID of the user who closed the session, when closed by a namespace's member.
+	ClosedBy string `json:"closed_by,omitempty" bson:"closed_by,omitempty"`
+	// Hops are the UIDs of the devices the session jumped through to reach the device, starting from the device
+	// dialed through its agent.
+	Hops []string `json:"hops,omitempty" bson:"hops,omitempty"`
+}
+
+// SessionCloseReason is the reason why ShellHub closed a session.
+type SessionCloseReason string
+
+const (
+	// SessionCloseReasonIdleTimeout is used when the session had no input from the client for longer than the
+	// namespace's idle timeout.
+	SessionCloseReasonIdleTimeout SessionCloseReason = "idle_timeout"
+	// SessionCloseReasonMaxDuration is used when the session lasted longer than the namespace's maximum session
+	// duration.
+	SessionCloseReasonMaxDuration SessionCloseReason = "max_duration"
+	// SessionCloseReasonUser is used when a namespace's member closed the session.
+	SessionCloseReasonUser SessionCloseReason = "user"
+)
+
+// SessionCertificate identifies the SSH certificate used to authenticate a session.
+type SessionCertificate struct {
+	Serial uint64 `json:"serial" bson:"serial"`
+	KeyID  string `json:"key_id" bson:"key_id"`
 }
 
 type ActiveSession struct {
@@ -40,16 +71,32 @@ type RecordedSession struct {
 	Time     time.Time `json:"time" bson:"time,omitempty"`
 	Width    int       `json:"width" bson:"width,omitempty"`
 	Height   int       `json:"height" bson:"height,omitempty"`
+	// Direction is the direction of the frame's message. When empty, the frame is an output.
+	Direction RecordDirection `json:"direction,omitempty" bson:"direction,omitempty"`
+	// Participant is who typed the frame's input in a shared session.
+	Participant string `json:"participant,omitempty" bson:"participant,omitempty"`
 }
 
+// RecordDirection is the direction of a recorded frame's message.
+type RecordDirection string
+
+const (
+	// RecordDirectionOutput is used by the frames written by the device to the terminal.
+	RecordDirectionOutput RecordDirection = "o"
+	// RecordDirectionInput is used by the frames typed by the session's participants.
+	RecordDirectionInput RecordDirection = "i"
+)
+
 type Status struct {
 	Authenticated bool `json:"authenticated"`
 }
 
 type SessionRecorded struct {
-	UID       string `json:"uid"`
-	Namespace string `json:"namespace" bson:"namespace"`
-	Message   string `json:"message" bson:"message"`
-	Width     int    `json:"width" bson:"width,omitempty"`
-	Height    int    `json:"height" bson:"height,omitempty"`
+	UID         string          `json:"uid"`
+	Namespace   string          `json:"namespace" bson:"namespace"`
+	Message     string          `json:"message" bson:"message"`
+	Width       int             `json:"width" bson:"width,omitempty"`
+	Height      int             `json:"height" bson:"height,omitempty"`
+	Direction   RecordDirection `json:"direction,omitempty" bson:"direction,omitempty"`
+	Participant string          `json:"participant,omitempty" bson:"participant,omitempty"`
 }
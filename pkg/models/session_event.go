@@ -0,0 +1,128 @@
+package models
+
+import "time"
+
+// SessionEventType is the type of something done in a session besides its terminal.
+type SessionEventType string
+
+const (
+	// SessionEventTypePty is used when the client requests a terminal. Its data is a [SessionEventPty].
+	SessionEventTypePty SessionEventType = "pty-req"
+	// SessionEventTypeWindowChange is used when the client's terminal is resized. Its data is a
+	// [SessionEventWindowChange].
+	SessionEventTypeWindowChange SessionEventType = "window-change"
+	// SessionEventTypeShell is used when the client starts a shell. It has no data.
+	SessionEventTypeShell SessionEventType = "shell"
+	// SessionEventTypeExec is used when the client executes a command. Its data is a [SessionEventExec].
+	SessionEventTypeExec SessionEventType = "exec"
+	// SessionEventTypeSubsystem is used when the client starts a subsystem. Its data is a [SessionEventSubsystem].
+	SessionEventTypeSubsystem SessionEventType = "subsystem"
+	// SessionEventTypeDirectTCPIP is used when the client forwards a connection through the device. Its data is a
+	// [SessionEventDirectTCPIP].
+	SessionEventTypeDirectTCPIP SessionEventType = "direct-tcpip"
+	// SessionEventTypeExitStatus is used when the program started by the client exits by itself. Its data is a
+	// [SessionEventExitStatus].
+	SessionEventTypeExitStatus SessionEventType = "exit-status"
+	// SessionEventTypeExitSignal is used when the program started by the client is terminated by a signal. Its data
+	// is a [SessionEventExitSignal].
+	SessionEventTypeExitSignal SessionEventType = "exit-signal"
+	// SessionEventTypeExecResult is used when a command executed without a terminal finishes. Its data is a
+	// [SessionEventExecResult].
+	SessionEventTypeExecResult SessionEventType = "exec-result"
+	// SessionEventTypeSFTP is used for each file operation done through the SFTP subsystem. Its data is a
+	// [SessionEventSFTP].
+	SessionEventTypeSFTP SessionEventType = "sftp"
+)
+
+// SessionEvent is something done in a session besides its terminal, recorded by the SSH server as it happens.
+type SessionEvent struct {
+	// Session is the UID of the session where the event happened.
+	Session   string           `json:"session" bson:"session"`
+	TenantID  string           `json:"tenant_id" bson:"tenant_id"`
+	Type      SessionEventType `json:"type" bson:"type"`
+	Timestamp time.Time        `json:"timestamp" bson:"timestamp"`
+	// Data is what happened, whose structure depends on the event's type.
+	Data interface{} `json:"data" bson:"data"`
+}
+
+// SessionEventPty is the terminal requested by the client.
+type SessionEventPty struct {
+	Term    string `json:"term" bson:"term"`
+	Columns uint32 `json:"columns" bson:"columns"`
+	Rows    uint32 `json:"rows" bson:"rows"`
+}
+
+// SessionEventWindowChange is the new dimensions of the client's terminal.
+type SessionEventWindowChange struct {
+	Columns uint32 `json:"columns" bson:"columns"`
+	Rows    uint32 `json:"rows" bson:"rows"`
+}
+
+// SessionEventExec is the command executed by the client.
+type SessionEventExec struct {
+	Command string `json:"command" bson:"command"`
+}
+
+// SessionEventSubsystem is the subsystem started by the client.
+type SessionEventSubsystem struct {
+	Name string `json:"name" bson:"name"`
+}
+
+// SessionEventDirectTCPIP is the connection forwarded by the client through the device.
+type SessionEventDirectTCPIP struct {
+	// Host and Port are the connection's destination, dialed from the device.
+	Host string `json:"host" bson:"host"`
+	Port uint32 `json:"port" bson:"port"`
+	// OriginHost and OriginPort are where the connection came from on the client's side.
+	OriginHost string `json:"origin_host" bson:"origin_host"`
+	OriginPort uint32 `json:"origin_port" bson:"origin_port"`
+}
+
+// SessionEventExitStatus is the exit status of the program started by the client.
+type SessionEventExitStatus struct {
+	Status uint32 `json:"status" bson:"status"`
+}
+
+// SessionEventExitSignal is the signal which terminated the program started by the client.
+type SessionEventExitSignal struct {
+	Signal     string `json:"signal" bson:"signal"`
+	CoreDumped bool   `json:"core_dumped" bson:"core_dumped"`
+	Error      string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// SessionEventExecResult is a command executed without a terminal, recorded when it finishes.
+type SessionEventExecResult struct {
+	Command string `json:"command" bson:"command"`
+	// ExitStatus is the command's exit status, when it exited by itself.
+	ExitStatus *uint32 `json:"exit_status,omitempty" bson:"exit_status,omitempty"`
+	// ExitSignal is the signal which terminated the command, when it didn't exit by itself.
+	ExitSignal string `json:"exit_signal,omitempty" bson:"exit_signal,omitempty"`
+	// Stdout and Stderr are the number of bytes written by the command to each output.
+	Stdout int64 `json:"stdout" bson:"stdout"`
+	Stderr int64 `json:"stderr" bson:"stderr"`
+	// Output is the beginning of the command's output, when the namespace records it.
+	Output string `json:"output,omitempty" bson:"output,omitempty"`
+}
+
+// SessionEventSFTPOperation is a file operation done through the SFTP subsystem.
+type SessionEventSFTPOperation string
+
+const (
+	SessionEventSFTPOperationOpen   SessionEventSFTPOperation = "open"
+	SessionEventSFTPOperationRead   SessionEventSFTPOperation = "read"
+	SessionEventSFTPOperationWrite  SessionEventSFTPOperation = "write"
+	SessionEventSFTPOperationRemove SessionEventSFTPOperation = "remove"
+	SessionEventSFTPOperationRename SessionEventSFTPOperation = "rename"
+	SessionEventSFTPOperationMkdir  SessionEventSFTPOperation = "mkdir"
+	SessionEventSFTPOperationRmdir  SessionEventSFTPOperation = "rmdir"
+)
+
+// SessionEventSFTP is a file operation done through the SFTP subsystem.
+type SessionEventSFTP struct {
+	Operation SessionEventSFTPOperation `json:"operation" bson:"operation"`
+	Path      string                    `json:"path" bson:"path"`
+	// Target is the file's new path, when it was renamed.
+	Target string `json:"target,omitempty" bson:"target,omitempty"`
+	// Bytes is the number of bytes read from or written to the file, from when it was opened until it was closed.
+	Bytes int64 `json:"bytes,omitempty" bson:"bytes,omitempty"`
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvent is a device or session lifecycle event that can be delivered to a webhook.
+type WebhookEvent string
+
+const (
+	// WebhookEventDevicePending is emitted when a device is registered or moved back to pending.
+	WebhookEventDevicePending WebhookEvent = "device.pending"
+	// WebhookEventDeviceAccepted is emitted when a device is accepted, by a member or by an auto-accept rule.
+	WebhookEventDeviceAccepted WebhookEvent = "device.accepted"
+	// WebhookEventDeviceOnline is emitted when a device connects to the server.
+	WebhookEventDeviceOnline WebhookEvent = "device.online"
+	// WebhookEventDeviceOffline is emitted when a device disconnects from the server, or when it stops sending
+	// heartbeats.
+	WebhookEventDeviceOffline WebhookEvent = "device.offline"
+	// WebhookEventSessionStarted is emitted when an SSH session is started.
+	WebhookEventSessionStarted WebhookEvent = "session.started"
+	// WebhookEventSessionAuthenticated is emitted when an SSH session is authenticated.
+	WebhookEventSessionAuthenticated WebhookEvent = "session.authenticated"
+	// WebhookEventSessionFinished is emitted when an SSH session is finished.
+	WebhookEventSessionFinished WebhookEvent = "session.finished"
+)
+
+// Webhook is a namespace's subscription to lifecycle events. Each event matching the subscription is sent to URL as
+// a [WebhookPayload], signed with Secret.
+type Webhook struct {
+	ID       string         `json:"id" bson:"_id,omitempty"`
+	TenantID string         `json:"tenant_id" bson:"tenant_id"`
+	URL      string         `json:"url" bson:"url"`
+	Events   []WebhookEvent `json:"events" bson:"events"`
+	// Secret is the key used to sign the payloads with HMAC-SHA256. It's only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty" bson:"secret"`
+	Active    bool      `json:"active" bson:"active"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Subscribes reports whether the webhook subscribes to event.
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookChanges are the fields of a webhook that can be updated. A nil field is left unchanged.
+type WebhookChanges struct {
+	URL       *string        `bson:"url,omitempty"`
+	Events    []WebhookEvent `bson:"events,omitempty"`
+	Active    *bool          `bson:"active,omitempty"`
+	UpdatedAt time.Time      `bson:"updated_at"`
+}
+
+// WebhookPayload is the body sent to a webhook's URL.
+type WebhookPayload struct {
+	// ID identifies the event. It's the same on every retry of a delivery.
+	ID       string       `json:"id"`
+	Event    WebhookEvent `json:"event"`
+	TenantID string       `json:"tenant_id"`
+	// Data is the device or the session the event is about.
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// WebhookDelivery is the log of an attempt to deliver a payload to a webhook.
+type WebhookDelivery struct {
+	ID        string       `json:"id" bson:"_id,omitempty"`
+	TenantID  string       `json:"tenant_id" bson:"tenant_id"`
+	WebhookID string       `json:"webhook_id" bson:"webhook_id"`
+	EventID   string       `json:"event_id" bson:"event_id"`
+	Event     WebhookEvent `json:"event" bson:"event"`
+	// Attempt is the number of the attempt, starting from 1.
+	Attempt int `json:"attempt" bson:"attempt"`
+	// StatusCode is the HTTP status code answered by the webhook's URL. It's 0 when no answer was received.
+	StatusCode int `json:"status_code" bson:"status_code"`
+	// Error describes why the attempt failed. It's empty when the payload was delivered.
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+	// Duration is how long the attempt took, in milliseconds.
+	Duration  int64     `json:"duration" bson:"duration"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,82 @@
+// Package pubsub broadcasts messages between ShellHub's services through the Redis' Pub/Sub.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// SessionCloseChannel is the channel where the API publishes the sessions closed by the namespaces' members, to
+	// the SSH server holding each session close it.
+	SessionCloseChannel = "shellhub:sessions:close"
+	// SessionShareChannel is the channel where the API publishes the guests invited to the sessions and the changes on
+	// their input control, to the SSH server holding each session apply them.
+	SessionShareChannel = "shellhub:sessions:share"
+)
+
+// SessionClose is the message published on [SessionCloseChannel].
+type SessionClose struct {
+	// UID is the session's UID.
+	UID string `json:"uid"`
+}
+
+// SessionShare is the message published on [SessionShareChannel].
+type SessionShare struct {
+	// UID is the session's UID.
+	UID string `json:"uid"`
+	// Token is the invite's token. It is empty when the message only changes the guest's input control.
+	Token string `json:"token,omitempty"`
+	// Guest is the name of the guest invited to the session.
+	Guest string `json:"guest"`
+	// Input allows the guest to type on the session.
+	Input bool `json:"input"`
+}
+
+// PubSub publishes and subscribes to messages on Redis' channels.
+type PubSub struct {
+	client *redis.Client
+}
+
+// NewRedis creates a [PubSub] connected to the Redis server at uri.
+func NewRedis(uri string) (*PubSub, error) {
+	opt, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PubSub{client: redis.NewClient(opt)}, nil
+}
+
+// Publish publishes the message, encoded as JSON, on the channel.
+func (p *PubSub) Publish(ctx context.Context, channel string, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return p.client.Publish(ctx, channel, data).Err()
+}
+
+// Subscribe subscribes to the channel, calling the handler with each message received until the context is done. The
+// subscription is restored when the connection to Redis is lost.
+func (p *PubSub) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	subscription := p.client.Subscribe(ctx, channel)
+	defer subscription.Close()
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			handler([]byte(message.Payload))
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/pkg/pubsub"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
 	"github.com/shellhub-io/shellhub/ssh/web"
@@ -120,8 +121,6 @@ func main() {
 		return c.String(http.StatusOK, "OK")
 	})
 
-	web.NewSSHServerBridge(router.Router())
-
 	if envs.IsDevelopment() {
 		runtime.SetBlockProfileRate(1)
 		pprof.Register(router)
@@ -129,7 +128,24 @@ func main() {
 		log.Info("Profiling enabled at http://0.0.0.0:8080/debug/pprof/")
 	}
 
+	signals, err := pubsub.NewRedis(env.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("failed to create the session signals subscriber")
+	}
+
+	srv := server.NewServer(env, tunnel.Tunnel)
+
+	web.NewSSHServerBridge(router.Router())
+	web.NewSessionShadowBridge(router.Router(), tunnel.API, srv)
+	web.NewSessionShareBridge(router.Router(), srv)
+
 	go http.ListenAndServe(":8080", router) // nolint:errcheck
 
-	log.Fatal(server.NewServer(env, tunnel.Tunnel).ListenAndServe())
+	go func() {
+		if err := srv.HandleSignals(context.Background(), signals); err != nil {
+			log.WithError(err).Error("stopped to handle the session signals")
+		}
+	}()
+
+	log.Fatal(srv.ListenAndServe())
 }
@@ -0,0 +1,310 @@
+// Package sftp records the file operations done through the SFTP subsystem, parsing the protocol's packets piped
+// between the client and the device.
+//
+// https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-02
+package sftp
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Packets' types used to record the file operations.
+const (
+	packetOpen   byte = 3
+	packetClose  byte = 4
+	packetRead   byte = 5
+	packetWrite  byte = 6
+	packetRemove byte = 13
+	packetMkdir  byte = 14
+	packetRmdir  byte = 15
+	packetRename byte = 18
+	packetStatus byte = 101
+	packetHandle byte = 102
+	packetData   byte = 103
+)
+
+// statusOK is the status code replied by the device when a request succeeds.
+const statusOK uint32 = 0
+
+// maxPacketLength is the length of the largest packet parsed. Clients and servers don't send packets that large, so
+// the recording stops when one is found instead of buffering it.
+const maxPacketLength = 1 << 20
+
+// operations are the operations recorded when the device replies the request's packet with success.
+var operations = map[byte]models.SessionEventSFTPOperation{
+	packetRemove: models.SessionEventSFTPOperationRemove,
+	packetMkdir:  models.SessionEventSFTPOperationMkdir,
+	packetRmdir:  models.SessionEventSFTPOperationRmdir,
+	packetRename: models.SessionEventSFTPOperationRename,
+}
+
+// request is a request sent by the client waiting for the device's response.
+type request struct {
+	kind   byte
+	path   string
+	target string
+	handle string
+}
+
+// file is a file opened by the client, identified by the handle replied by the device.
+type file struct {
+	path    string
+	read    int64
+	written int64
+}
+
+// Recorder records the file operations done through the SFTP subsystem. The data sent by the client is written to
+// [Recorder.Requests] and the data sent by the device to [Recorder.Responses].
+//
+// Only the operations the device succeeded are recorded. The bytes read from and written to a file are recorded when
+// the client closes it.
+type Recorder struct {
+	mu       sync.Mutex
+	record   func(models.SessionEventSFTP)
+	requests map[uint32]request
+	files    map[string]*file
+	// events are the operations parsed and not recorded yet.
+	events []models.SessionEventSFTP
+	closed bool
+
+	input  *stream
+	output *stream
+}
+
+// NewRecorder creates a [Recorder] calling record for each file operation done.
+func NewRecorder(record func(models.SessionEventSFTP)) *Recorder {
+	r := &Recorder{
+		record:   record,
+		requests: make(map[uint32]request),
+		files:    make(map[string]*file),
+	}
+
+	r.input = &stream{recorder: r, handle: r.request}
+	r.output = &stream{recorder: r, handle: r.response}
+
+	return r
+}
+
+// Requests returns where the data sent by the client to the device is written.
+func (r *Recorder) Requests() io.Writer {
+	return r.input
+}
+
+// Responses returns where the data sent by the device to the client is written.
+func (r *Recorder) Responses() io.Writer {
+	return r.output
+}
+
+// Close records the bytes read from and written to the files the client didn't close, ignoring the data written
+// after it.
+func (r *Recorder) Close() {
+	r.mu.Lock()
+
+	if !r.closed {
+		for handle := range r.files {
+			r.close(handle)
+		}
+
+		r.closed = true
+	}
+
+	r.flush()
+}
+
+// flush unlocks the recorder and records the operations parsed. The operations are recorded without holding the lock,
+// so recording them doesn't block the other direction's data.
+func (r *Recorder) flush() {
+	events := r.events
+	r.events = nil
+
+	r.mu.Unlock()
+
+	for _, event := range events {
+		r.record(event)
+	}
+}
+
+// request handles a packet sent by the client.
+func (r *Recorder) request(kind byte, payload packet) {
+	id, ok := payload.uint32()
+	if !ok {
+		return
+	}
+
+	switch kind {
+	case packetOpen, packetRemove, packetMkdir, packetRmdir:
+		if path, ok := payload.string(); ok {
+			r.requests[id] = request{kind: kind, path: path}
+		}
+	case packetRename:
+		path, _ := payload.string()
+		if target, ok := payload.string(); ok {
+			r.requests[id] = request{kind: kind, path: path, target: target}
+		}
+	case packetRead:
+		if handle, ok := payload.string(); ok {
+			r.requests[id] = request{kind: kind, handle: handle}
+		}
+	case packetWrite:
+		handle, _ := payload.string()
+		payload.uint64() // offset
+
+		if length, ok := payload.uint32(); ok {
+			if f, ok := r.files[handle]; ok {
+				f.written += int64(length)
+			}
+		}
+	case packetClose:
+		if handle, ok := payload.string(); ok {
+			r.close(handle)
+		}
+	}
+}
+
+// response handles a packet sent by the device, replying a request sent by the client.
+func (r *Recorder) response(kind byte, payload packet) {
+	id, ok := payload.uint32()
+	if !ok {
+		return
+	}
+
+	req, ok := r.requests[id]
+	if !ok {
+		return
+	}
+
+	delete(r.requests, id)
+
+	switch kind {
+	case packetHandle:
+		if handle, ok := payload.string(); ok && req.kind == packetOpen {
+			r.files[handle] = &file{path: req.path}
+			r.events = append(r.events, models.SessionEventSFTP{
+				Operation: models.SessionEventSFTPOperationOpen,
+				Path:      req.path,
+			})
+		}
+	case packetData:
+		if length, ok := payload.uint32(); ok && req.kind == packetRead {
+			if f, ok := r.files[req.handle]; ok {
+				f.read += int64(length)
+			}
+		}
+	case packetStatus:
+		if code, ok := payload.uint32(); ok && code == statusOK {
+			if operation, ok := operations[req.kind]; ok {
+				r.events = append(r.events, models.SessionEventSFTP{
+					Operation: operation,
+					Path:      req.path,
+					Target:    req.target,
+				})
+			}
+		}
+	}
+}
+
+// close records the bytes read from and written to the file opened with the handle.
+func (r *Recorder) close(handle string) {
+	f, ok := r.files[handle]
+	if !ok {
+		return
+	}
+
+	delete(r.files, handle)
+
+	if f.read > 0 {
+		r.events = append(r.events, models.SessionEventSFTP{
+			Operation: models.SessionEventSFTPOperationRead,
+			Path:      f.path,
+			Bytes:     f.read,
+		})
+	}
+
+	if f.written > 0 {
+		r.events = append(r.events, models.SessionEventSFTP{
+			Operation: models.SessionEventSFTPOperationWrite,
+			Path:      f.path,
+			Bytes:     f.written,
+		})
+	}
+}
+
+// stream splits the data sent in one direction into packets.
+type stream struct {
+	recorder *Recorder
+	handle   func(kind byte, payload packet)
+	buffer   []byte
+	// broken is set when the data cannot be parsed anymore.
+	broken bool
+}
+
+// Write parses the packets of the data. It never fails, so the data is piped even when it cannot be parsed.
+func (s *stream) Write(data []byte) (int, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.flush()
+
+	if s.recorder.closed || s.broken {
+		return len(data), nil
+	}
+
+	s.buffer = append(s.buffer, data...)
+	for len(s.buffer) >= 4 {
+		length := binary.BigEndian.Uint32(s.buffer)
+		if length == 0 || length > maxPacketLength {
+			s.broken = true
+			s.buffer = nil
+
+			break
+		}
+
+		if uint32(len(s.buffer)-4) < length {
+			break
+		}
+
+		s.handle(s.buffer[4], packet(s.buffer[5:4+length]))
+		s.buffer = s.buffer[4+length:]
+	}
+
+	return len(data), nil
+}
+
+// packet is the payload of a packet, consumed as its fields are read.
+type packet []byte
+
+func (p *packet) uint32() (uint32, bool) {
+	if len(*p) < 4 {
+		return 0, false
+	}
+
+	v := binary.BigEndian.Uint32(*p)
+	*p = (*p)[4:]
+
+	return v, true
+}
+
+func (p *packet) uint64() (uint64, bool) {
+	if len(*p) < 8 {
+		return 0, false
+	}
+
+	v := binary.BigEndian.Uint64(*p)
+	*p = (*p)[8:]
+
+	return v, true
+}
+
+func (p *packet) string() (string, bool) {
+	length, ok := p.uint32()
+	if !ok || uint32(len(*p)) < length {
+		return "", false
+	}
+
+	v := string((*p)[:length])
+	*p = (*p)[length:]
+
+	return v, true
+}
@@ -0,0 +1,125 @@
+package sftp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// build builds a packet with the fields, encoding strings and uint32s as the protocol does.
+func build(kind byte, fields ...interface{}) []byte {
+	payload := []byte{kind}
+	for _, field := range fields {
+		switch v := field.(type) {
+		case uint32:
+			payload = binary.BigEndian.AppendUint32(payload, v)
+		case uint64:
+			payload = binary.BigEndian.AppendUint64(payload, v)
+		case string:
+			payload = binary.BigEndian.AppendUint32(payload, uint32(len(v)))
+			payload = append(payload, v...)
+		}
+	}
+
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(payload))), payload...)
+}
+
+// exchange is a packet sent by the client or by the device.
+type exchange struct {
+	response bool
+	packet   []byte
+}
+
+func TestRecorder(t *testing.T) {
+	cases := []struct {
+		description string
+		exchanges   []exchange
+		expected    []models.SessionEventSFTP
+	}{
+		{
+			description: "records a file opened, read and closed",
+			exchanges: []exchange{
+				{packet: build(packetOpen, uint32(1), "/etc/hosts", uint32(1), uint32(0))},
+				{response: true, packet: build(packetHandle, uint32(1), "h1")},
+				{packet: build(packetRead, uint32(2), "h1", uint64(0), uint32(32768))},
+				{response: true, packet: build(packetData, uint32(2), "127.0.0.1 localhost")},
+				{packet: build(packetRead, uint32(3), "h1", uint64(19), uint32(32768))},
+				{response: true, packet: build(packetStatus, uint32(3), uint32(1), "EOF", "")},
+				{packet: build(packetClose, uint32(4), "h1")},
+				{response: true, packet: build(packetStatus, uint32(4), statusOK, "", "")},
+			},
+			expected: []models.SessionEventSFTP{
+				{Operation: models.SessionEventSFTPOperationOpen, Path: "/etc/hosts"},
+				{Operation: models.SessionEventSFTPOperationRead, Path: "/etc/hosts", Bytes: 19},
+			},
+		},
+		{
+			description: "records a file written when the session ends before closing it",
+			exchanges: []exchange{
+				{packet: build(packetOpen, uint32(1), "/tmp/file", uint32(0x1a), uint32(0))},
+				{response: true, packet: build(packetHandle, uint32(1), "h1")},
+				{packet: build(packetWrite, uint32(2), "h1", uint64(0), "hello")},
+				{packet: build(packetWrite, uint32(3), "h1", uint64(5), " world")},
+			},
+			expected: []models.SessionEventSFTP{
+				{Operation: models.SessionEventSFTPOperationOpen, Path: "/tmp/file"},
+				{Operation: models.SessionEventSFTPOperationWrite, Path: "/tmp/file", Bytes: 11},
+			},
+		},
+		{
+			description: "records the operations the device succeeded",
+			exchanges: []exchange{
+				{packet: build(packetRemove, uint32(1), "/tmp/a")},
+				{packet: build(packetRename, uint32(2), "/tmp/b", "/tmp/c")},
+				{packet: build(packetMkdir, uint32(3), "/tmp/d", uint32(0))},
+				{packet: build(packetOpen, uint32(4), "/root/secret", uint32(1), uint32(0))},
+				{response: true, packet: build(packetStatus, uint32(1), statusOK, "", "")},
+				{response: true, packet: build(packetStatus, uint32(2), statusOK, "", "")},
+				{response: true, packet: build(packetStatus, uint32(3), uint32(3), "Permission denied", "")},
+				{response: true, packet: build(packetStatus, uint32(4), uint32(3), "Permission denied", "")},
+			},
+			expected: []models.SessionEventSFTP{
+				{Operation: models.SessionEventSFTPOperationRemove, Path: "/tmp/a"},
+				{Operation: models.SessionEventSFTPOperationRename, Path: "/tmp/b", Target: "/tmp/c"},
+			},
+		},
+		{
+			description: "stops recording when a packet is too large",
+			exchanges: []exchange{
+				{packet: binary.BigEndian.AppendUint32(nil, maxPacketLength+1)},
+				{packet: build(packetRemove, uint32(1), "/tmp/a")},
+				{response: true, packet: build(packetStatus, uint32(1), statusOK, "", "")},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var events []models.SessionEventSFTP
+			recorder := NewRecorder(func(event models.SessionEventSFTP) {
+				events = append(events, event)
+			})
+
+			for _, e := range tc.exchanges {
+				w := recorder.Requests()
+				if e.response {
+					w = recorder.Responses()
+				}
+
+				// NOTICE: The packets are written a byte at a time, as the data piped can split them anywhere.
+				for i := range e.packet {
+					n, err := w.Write(e.packet[i : i+1])
+					assert.NoError(t, err)
+					assert.Equal(t, 1, n)
+				}
+			}
+
+			recorder.Close()
+
+			assert.Equal(t, tc.expected, events)
+		})
+	}
+}
@@ -15,8 +15,8 @@ import (
 type Conn struct {
 	l      net.Listener
 	config *gossh.ClientConfig
-	client *gossh.Client
 
+	Client *gossh.Client
 	Agent  *gossh.Session
 	Server *gliderssh.Server
 }
@@ -57,7 +57,7 @@ func (c *Conn) Start() {
 func (c *Conn) Teardown() {
 	c.Server.Close()
 	c.Agent.Close()
-	c.client.Close()
+	c.Client.Close()
 	c.l.Close()
 }
 
@@ -116,7 +116,7 @@ func (c *Conn) startConnection() {
 	if err != nil {
 		exit(err, "failed to dial")
 	}
-	c.client = client
+	c.Client = client
 
 	session, err := client.NewSession()
 	if err != nil {
@@ -12,5 +12,32 @@ const (
 	//
 	// Example of dynamic application-level port forwarding: `ssh -D 1080 user@sshid`.
 	DirectTCPIPChannel = "direct-tcpip"
-	SessionChannel     = "session"
+	// ForwardedTCPIPChannel is the channel type opened to the client for each connection accepted on a port forwarded
+	// from the device, as requested by a "tcpip-forward" global request.
+	//
+	// Example of remote port forwarding: `ssh -R 8080:localhost:80 user@sshid`.
+	ForwardedTCPIPChannel = "forwarded-tcpip"
+	// AuthAgentChannel is the channel type opened by the device for each connection to the forwarded SSH agent. It is
+	// relayed to the client, who serves it with its local agent.
+	//
+	// Example of agent forwarding: `ssh -A user@sshid`.
+	AuthAgentChannel = "auth-agent@openssh.com"
+	// X11Channel is the channel type opened by the device for each connection to the X11 display allocated to the
+	// session. It is relayed to the client, who serves it with its local X server.
+	//
+	// Example of X11 forwarding: `ssh -X user@sshid`.
+	X11Channel     = "x11"
+	SessionChannel = "session"
+)
+
+const (
+	// TCPIPForwardRequestType is the global request type to ask the server to listen on a port and forward the
+	// connections accepted on it to the client.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	TCPIPForwardRequestType = "tcpip-forward"
+	// CancelTCPIPForwardRequestType is the global request type to cancel a port forwarding previously requested.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	CancelTCPIPForwardRequestType = "cancel-tcpip-forward"
 )
@@ -0,0 +1,148 @@
+package channels
+
+import (
+	"io"
+	"sync"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// execOutputLimit is the number of bytes of an exec's output kept on its event, when the namespace records it.
+const execOutputLimit = 64 * 1024
+
+const (
+	// ExitStatusRequestType is sent by the agent when the command exits by itself.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.10
+	ExitStatusRequestType = "exit-status"
+	// ExitSignalRequestType is sent by the agent when the command is terminated by a signal.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.10
+	ExitSignalRequestType = "exit-signal"
+)
+
+// exitEvent decodes the agent's exit-status or exit-signal request as the session's event. It returns false when the
+// request is neither of them or cannot be decoded.
+func exitEvent(req *gossh.Request) (models.SessionEventType, interface{}, bool) {
+	switch req.Type {
+	case ExitStatusRequestType:
+		var status models.SessionEventExitStatus
+		if err := gossh.Unmarshal(req.Payload, &status); err != nil {
+			return "", nil, false
+		}
+
+		return models.SessionEventTypeExitStatus, &status, true
+	case ExitSignalRequestType:
+		var signal struct {
+			Signal     string
+			CoreDumped bool
+			Error      string
+			Language   string
+		}
+		if err := gossh.Unmarshal(req.Payload, &signal); err != nil {
+			return "", nil, false
+		}
+
+		return models.SessionEventTypeExitSignal, &models.SessionEventExitSignal{
+			Signal:     signal.Signal,
+			CoreDumped: signal.CoreDumped,
+			Error:      signal.Error,
+		}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// tap receives a copy of the data piped on a channel, to record what was done on it. The nil writers are skipped.
+type tap struct {
+	// input receives the data sent by the client.
+	input io.Writer
+	// stdout and stderr receive the data sent by the agent.
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// wrap copies the data read from the client and from the agent to the tap's writers.
+func (t *tap) wrap(input, stdout, stderr io.Reader) (io.Reader, io.Reader, io.Reader) {
+	if t == nil {
+		return input, stdout, stderr
+	}
+
+	if t.input != nil {
+		input = io.TeeReader(input, t.input)
+	}
+
+	if t.stdout != nil {
+		stdout = io.TeeReader(stdout, t.stdout)
+	}
+
+	if t.stderr != nil {
+		stderr = io.TeeReader(stderr, t.stderr)
+	}
+
+	return input, stdout, stderr
+}
+
+// execRecord is the record of a command executed without a terminal, built while the command runs.
+type execRecord struct {
+	mu   sync.Mutex
+	exec models.SessionEventExecResult
+	// output keeps the beginning of the command's output on the event.
+	output bool
+}
+
+func newExecRecord(command string, output bool) *execRecord {
+	return &execRecord{exec: models.SessionEventExecResult{Command: command}, output: output}
+}
+
+// tap creates the [tap] counting the command's output.
+func (r *execRecord) tap() *tap {
+	return &tap{stdout: &execOutput{record: r}, stderr: &execOutput{record: r, stderr: true}}
+}
+
+// exit records how the command exited from the agent's exit-status or exit-signal event.
+func (r *execRecord) exit(data interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch exit := data.(type) {
+	case *models.SessionEventExitStatus:
+		r.exec.ExitStatus = &exit.Status
+	case *models.SessionEventExitSignal:
+		r.exec.ExitSignal = exit.Signal
+	}
+}
+
+// event returns the command's event, as recorded until now.
+func (r *execRecord) event() models.SessionEventExecResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.exec
+}
+
+// execOutput counts the bytes written by the command to one of its outputs.
+type execOutput struct {
+	record *execRecord
+	stderr bool
+}
+
+func (o *execOutput) Write(p []byte) (int, error) {
+	o.record.mu.Lock()
+	defer o.record.mu.Unlock()
+
+	if o.stderr {
+		o.record.exec.Stderr += int64(len(p))
+	} else {
+		o.record.exec.Stdout += int64(len(p))
+	}
+
+	if o.record.output {
+		if left := execOutputLimit - len(o.record.exec.Output); left > 0 {
+			o.record.exec.Output += string(p[:min(len(p), left)])
+		}
+	}
+
+	return len(p), nil
+}
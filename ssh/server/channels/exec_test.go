@@ -0,0 +1,134 @@
+package channels
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestExecRecord(t *testing.T) {
+	status := uint32(2)
+
+	cases := []struct {
+		description string
+		output      bool
+		stdout      string
+		stderr      string
+		exit        *gossh.Request
+		expected    models.SessionEventExecResult
+	}{
+		{
+			description: "records the exit status and the output's size",
+			stdout:      "hello\n",
+			stderr:      "ls: cannot access 'x'\n",
+			exit:        &gossh.Request{Type: ExitStatusRequestType, Payload: gossh.Marshal(&struct{ Status uint32 }{2})},
+			expected: models.SessionEventExecResult{
+				Command:    "ls x",
+				ExitStatus: &status,
+				Stdout:     6,
+				Stderr:     22,
+			},
+		},
+		{
+			description: "records the exit signal and the output when the namespace records it",
+			output:      true,
+			stdout:      "hello\n",
+			exit: &gossh.Request{Type: ExitSignalRequestType, Payload: gossh.Marshal(&struct {
+				Signal     string
+				CoreDumped bool
+				Error      string
+				Language   string
+			}{Signal: "KILL"})},
+			expected: models.SessionEventExecResult{
+				Command:    "ls x",
+				ExitSignal: "KILL",
+				Stdout:     6,
+				Output:     "hello\n",
+			},
+		},
+		{
+			description: "keeps the output until the limit",
+			output:      true,
+			stdout:      strings.Repeat("a", execOutputLimit+1),
+			expected: models.SessionEventExecResult{
+				Command: "ls x",
+				Stdout:  execOutputLimit + 1,
+				Output:  strings.Repeat("a", execOutputLimit),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			record := newExecRecord("ls x", tc.output)
+
+			_, stdout, stderr := record.tap().wrap(nil, strings.NewReader(tc.stdout), strings.NewReader(tc.stderr))
+
+			piped := new(bytes.Buffer)
+			_, err := io.Copy(piped, io.MultiReader(stdout, stderr))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.stdout+tc.stderr, piped.String())
+
+			if tc.exit != nil {
+				_, data, ok := exitEvent(tc.exit)
+				assert.True(t, ok)
+
+				record.exit(data)
+			}
+
+			assert.Equal(t, tc.expected, record.event())
+		})
+	}
+}
+
+func TestExitEvent(t *testing.T) {
+	cases := []struct {
+		description string
+		req         *gossh.Request
+		kind        models.SessionEventType
+		data        interface{}
+		ok          bool
+	}{
+		{
+			description: "decodes the exit status",
+			req:         &gossh.Request{Type: ExitStatusRequestType, Payload: gossh.Marshal(&struct{ Status uint32 }{1})},
+			kind:        models.SessionEventTypeExitStatus,
+			data:        &models.SessionEventExitStatus{Status: 1},
+			ok:          true,
+		},
+		{
+			description: "decodes the exit signal",
+			req: &gossh.Request{Type: ExitSignalRequestType, Payload: gossh.Marshal(&struct {
+				Signal     string
+				CoreDumped bool
+				Error      string
+				Language   string
+			}{"SEGV", true, "Segmentation fault", "en"})},
+			kind: models.SessionEventTypeExitSignal,
+			data: &models.SessionEventExitSignal{Signal: "SEGV", CoreDumped: true, Error: "Segmentation fault"},
+			ok:   true,
+		},
+		{
+			description: "fails when the exit status cannot be decoded",
+			req:         &gossh.Request{Type: ExitStatusRequestType, Payload: []byte{0}},
+		},
+		{
+			description: "fails when the request is not an exit",
+			req:         &gossh.Request{Type: "keepalive@openssh.com"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			kind, data, ok := exitEvent(tc.req)
+			assert.Equal(t, tc.kind, kind)
+			assert.Equal(t, tc.data, data)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
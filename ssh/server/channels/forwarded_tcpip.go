@@ -0,0 +1,235 @@
+package channels
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Listener listens on the device's side of a connection. It is implemented by the [gossh.Client] connected to the
+// agent, what asks the agent to listen on the address and carries the accepted connections back.
+type Listener interface {
+	Listen(n, addr string) (net.Listener, error)
+}
+
+// TCPIPForwardHandler handles the "tcpip-forward" and "cancel-tcpip-forward" global requests, used by the remote port
+// forwarding. For each forwarding requested, it listens on the device's side and opens a "forwarded-tcpip" channel to
+// the client for each connection accepted there.
+type TCPIPForwardHandler struct {
+	// Listener returns the [Listener] to the device of the connection within the context.
+	Listener func(ctx gliderssh.Context) (Listener, error)
+
+	mu       sync.Mutex
+	forwards map[string]net.Listener
+}
+
+// DefaultTCPIPForwardHandler creates a [TCPIPForwardHandler] what listens on the device through the session's agent
+// client.
+func DefaultTCPIPForwardHandler() *TCPIPForwardHandler {
+	return &TCPIPForwardHandler{
+		Listener: func(ctx gliderssh.Context) (Listener, error) {
+			sess, _ := session.ObtainSession(ctx)
+			if sess == nil || sess.AgentClient == nil {
+				return nil, errors.New("session is not connected to the agent")
+			}
+
+			return sess.AgentClient, nil
+		},
+	}
+}
+
+type tcpipForwardRequest struct {
+	BindAddr string
+	BindPort uint32
+}
+
+type tcpipForwardResponse struct {
+	BindPort uint32
+}
+
+type forwardedTCPIPChannelData struct {
+	DestAddr   string
+	DestPort   uint32
+	OriginAddr string
+	OriginPort uint32
+}
+
+// forwardKey identifies a forwarding within the server, as the same address can be forwarded by different connections.
+func forwardKey(ctx gliderssh.Context, addr string) string {
+	return ctx.SessionID() + "/" + addr
+}
+
+// bindHost converts the address requested to be bound by the client to the IP address the agent listens on. The
+// agent's client only matches the forwarded connections to IP addresses.
+func bindHost(addr string) (string, bool) {
+	switch addr {
+	case "", "*":
+		return "0.0.0.0", true
+	case "localhost":
+		return "127.0.0.1", true
+	}
+
+	if net.ParseIP(addr) == nil {
+		return "", false
+	}
+
+	return addr, true
+}
+
+// HandleSSHRequest is the [gliderssh.RequestHandler] for "tcpip-forward" and "cancel-tcpip-forward" requests.
+func (h *TCPIPForwardHandler) HandleSSHRequest(ctx gliderssh.Context, srv *gliderssh.Server, req *gossh.Request) (bool, []byte) {
+	h.mu.Lock()
+	if h.forwards == nil {
+		h.forwards = make(map[string]net.Listener)
+	}
+	h.mu.Unlock()
+
+	conn, ok := ctx.Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+	if !ok {
+		return false, nil
+	}
+
+	data := new(tcpipForwardRequest)
+	if err := gossh.Unmarshal(req.Payload, data); err != nil {
+		log.WithError(err).WithFields(log.Fields{"session": ctx.SessionID()}).Error("failed to parse the port forwarding request")
+
+		return false, []byte{}
+	}
+
+	logger := log.WithFields(log.Fields{
+		"session":   ctx.SessionID(),
+		"sshid":     ctx.User(),
+		"bind_addr": data.BindAddr,
+		"bind_port": data.BindPort,
+	})
+
+	addr := net.JoinHostPort(data.BindAddr, strconv.FormatUint(uint64(data.BindPort), 10))
+
+	switch req.Type {
+	case TCPIPForwardRequestType:
+		if srv.ReversePortForwardingCallback == nil || !srv.ReversePortForwardingCallback(ctx, data.BindAddr, data.BindPort) {
+			logger.Info("remote port forwarding is disabled")
+
+			return false, []byte("port forwarding is disabled")
+		}
+
+		host, ok := bindHost(data.BindAddr)
+		if !ok {
+			logger.Info("remote port forwarding bind address is not supported")
+
+			return false, []byte("bind address is not supported")
+		}
+
+		device, err := h.Listener(ctx)
+		if err != nil {
+			logger.WithError(err).Error("failed to get the device's listener")
+
+			return false, []byte{}
+		}
+
+		ln, err := device.Listen("tcp", net.JoinHostPort(host, strconv.FormatUint(uint64(data.BindPort), 10)))
+		if err != nil {
+			logger.WithError(err).Error("failed to listen on the device")
+
+			return false, []byte{}
+		}
+
+		_, port, _ := net.SplitHostPort(ln.Addr().String())
+		destPort, _ := strconv.Atoi(port)
+
+		// When the port requested is zero, the device chooses the port, and the client must use it to cancel the
+		// forwarding.
+		addr = net.JoinHostPort(data.BindAddr, port)
+
+		h.mu.Lock()
+		h.forwards[forwardKey(ctx, addr)] = ln
+		h.mu.Unlock()
+
+		logger.WithField("dest_port", destPort).Info("remote port forwarding started")
+
+		go func() {
+			<-ctx.Done()
+
+			h.mu.Lock()
+			listener, ok := h.forwards[forwardKey(ctx, addr)]
+			delete(h.forwards, forwardKey(ctx, addr))
+			h.mu.Unlock()
+
+			if ok {
+				listener.Close()
+			}
+		}()
+
+		go func() {
+			for {
+				c, err := ln.Accept()
+				if err != nil {
+					break
+				}
+
+				originAddr, originPort, _ := net.SplitHostPort(c.RemoteAddr().String())
+				originPortNumber, _ := strconv.Atoi(originPort)
+
+				payload := gossh.Marshal(&forwardedTCPIPChannelData{
+					DestAddr:   data.BindAddr,
+					DestPort:   uint32(destPort),
+					OriginAddr: originAddr,
+					OriginPort: uint32(originPortNumber),
+				})
+
+				go func() {
+					channel, reqs, err := conn.OpenChannel(ForwardedTCPIPChannel, payload)
+					if err != nil {
+						logger.WithError(err).Error("failed to open the forwarded-tcpip channel to the client")
+						c.Close()
+
+						return
+					}
+
+					go gossh.DiscardRequests(reqs)
+
+					go func() {
+						defer channel.Close()
+						defer c.Close()
+
+						io.Copy(channel, c) //nolint:errcheck
+					}()
+					go func() {
+						defer channel.Close()
+						defer c.Close()
+
+						io.Copy(c, channel) //nolint:errcheck
+					}()
+				}()
+			}
+
+			h.mu.Lock()
+			delete(h.forwards, forwardKey(ctx, addr))
+			h.mu.Unlock()
+
+			logger.Info("remote port forwarding finished")
+		}()
+
+		return true, gossh.Marshal(&tcpipForwardResponse{uint32(destPort)})
+	case CancelTCPIPForwardRequestType:
+		h.mu.Lock()
+		ln, ok := h.forwards[forwardKey(ctx, addr)]
+		delete(h.forwards, forwardKey(ctx, addr))
+		h.mu.Unlock()
+
+		if ok {
+			ln.Close()
+		}
+
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,97 @@
+package channels
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sshsrvtest"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// localListener listens on the local host, as if it was the device.
+type localListener struct{}
+
+func (localListener) Listen(n, addr string) (net.Listener, error) {
+	return net.Listen(n, addr)
+}
+
+func TestTCPIPForwardHandler(t *testing.T) {
+	cases := []struct {
+		description string
+		enabled     bool
+	}{
+		{
+			description: "fails when the reverse port forwarding is disabled",
+			enabled:     false,
+		},
+		{
+			description: "forwards the connections accepted on the device to the client",
+			enabled:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			forward := &TCPIPForwardHandler{
+				Listener: func(_ gliderssh.Context) (Listener, error) {
+					return localListener{}, nil
+				},
+			}
+
+			srv := &gliderssh.Server{
+				Handler: func(s gliderssh.Session) {
+					<-s.Context().Done()
+				},
+				ReversePortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
+					return tc.enabled
+				},
+				RequestHandlers: map[string]gliderssh.RequestHandler{
+					TCPIPForwardRequestType:       forward.HandleSSHRequest,
+					CancelTCPIPForwardRequestType: forward.HandleSSHRequest,
+				},
+			}
+
+			conn := sshsrvtest.New(srv, &gossh.ClientConfig{
+				User:            "root",
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+			})
+			conn.Start()
+			defer conn.Teardown()
+
+			ln, err := conn.Client.Listen("tcp", "127.0.0.1:0")
+			if !tc.enabled {
+				assert.Error(t, err)
+
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer ln.Close()
+
+			remote, err := net.Dial("tcp", ln.Addr().String())
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer remote.Close()
+
+			local, err := ln.Accept()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer local.Close()
+
+			_, err = remote.Write([]byte("ping"))
+			assert.NoError(t, err)
+
+			buffer := make([]byte, 4)
+			_, err = io.ReadFull(local, buffer)
+			assert.NoError(t, err)
+			assert.Equal(t, "ping", string(buffer))
+		})
+	}
+}
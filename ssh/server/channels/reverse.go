@@ -0,0 +1,62 @@
+package channels
+
+import (
+	"io"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// handleReverseChannels relays the channels opened by the device, as the "auth-agent@openssh.com" channels for the agent
+// forwarding and the "x11" channels for the X11 forwarding, to the client. It blocks until the connection to the
+// device is closed.
+func handleReverseChannels(conn *gossh.ServerConn, chans <-chan gossh.NewChannel, logger *log.Entry) {
+	for newChan := range chans {
+		go func(newChan gossh.NewChannel) {
+			logger := logger.WithField("channel", newChan.ChannelType())
+
+			client, clientReqs, err := conn.OpenChannel(newChan.ChannelType(), newChan.ExtraData())
+			if err != nil {
+				logger.WithError(err).Error("failed to open the channel on client")
+
+				newChan.Reject(gossh.ConnectionFailed, "failed to open the channel on client") //nolint:errcheck
+
+				return
+			}
+
+			defer client.Close()
+
+			agent, agentReqs, err := newChan.Accept()
+			if err != nil {
+				logger.WithError(err).Error("failed to accept the channel from agent")
+
+				return
+			}
+
+			defer agent.Close()
+
+			go gossh.DiscardRequests(clientReqs)
+			go gossh.DiscardRequests(agentReqs)
+
+			wg := new(sync.WaitGroup)
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				io.Copy(client, agent) //nolint:errcheck
+				client.CloseWrite()    //nolint:errcheck
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				io.Copy(agent, client) //nolint:errcheck
+				agent.CloseWrite()     //nolint:errcheck
+			}()
+
+			wg.Wait()
+		}(newChan)
+	}
+}
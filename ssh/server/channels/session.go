@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sftp"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -48,6 +50,20 @@ const (
 	//
 	// https://www.rfc-editor.org/rfc/rfc4254#section-6.7
 	WindowChangeRequestType = "window-change"
+	// The client requests the forwarding of its SSH agent to the session. Once accepted, each connection to the agent
+	// from the device is opened as an "auth-agent@openssh.com" channel.
+	//
+	// https://datatracker.ietf.org/doc/html/draft-ietf-secsh-agent-02#section-4.1
+	AuthAgentRequestType = "auth-agent-req@openssh.com"
+	// The client requests the forwarding of its X11 display to the session. Once accepted, each connection to the
+	// display allocated on the device is opened as a "x11" channel.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.3.1
+	X11RequestType = "x11-req"
+	// This message specifies an environment variable to be passed into the shell or command to be started later.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.4
+	EnvRequestType = "env"
 	// In a defined interval, the Agent sends a keepalive request to maintain the session apoint, even when no data is
 	// send.
 	KeepAliveRequestType = KeepAliveRequestTypePrefix + "@shellhub.io"
@@ -111,6 +127,20 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 		defer agent.Close()
 
+		// NOTICE: The command executed without a terminal is recorded when the channel closes, after the agent informed
+		// how it exited. The files the client didn't close through the SFTP subsystem are recorded at the same time.
+		var exec *execRecord
+		var files *sftp.Recorder
+		defer func() {
+			if exec != nil {
+				sess.Event(models.SessionEventTypeExecResult, exec.event())
+			}
+
+			if files != nil {
+				files.Close()
+			}
+		}()
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -166,7 +196,83 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 				logger.Debugf("request from client to agent: %s", req.Type)
 
-				ok, err := agent.SendRequest(req.Type, req.WantReply, req.Payload)
+				if req.Type == AuthAgentRequestType {
+					if settings, err := sess.NamespaceSettings(); err != nil || !settings.AllowAgentForwarding() {
+						logger.WithError(err).Info("agent forwarding is not allowed")
+
+						if err := req.Reply(false, nil); err != nil {
+							logger.WithError(err).Error("failed to reply the agent forwarding request")
+						}
+
+						continue
+					}
+
+					// NOTICE: The channels are handled once per connection, as every session channel shares the agent
+					// forwarded. When already handled, [gossh.Client.HandleChannelOpen] returns nil.
+					if chans := sess.AgentClient.HandleChannelOpen(AuthAgentChannel); chans != nil {
+						go handleReverseChannels(conn, chans, logger)
+					}
+				}
+
+				if req.Type == X11RequestType {
+					// NOTICE: As the agent forwarding's, the X11 channels are handled once per connection.
+					if chans := sess.AgentClient.HandleChannelOpen(X11Channel); chans != nil {
+						go handleReverseChannels(conn, chans, logger)
+					}
+				}
+
+				// NOTICE: The request sent to the agent may differ from the client's one when the public key used to
+				// authenticate forces a command. The client's request type is kept to handle the session as requested.
+				reqType, reqPayload := req.Type, req.Payload
+
+				switch req.Type {
+				case ShellRequestType, ExecRequestType, SubsystemRequestType:
+					var command string
+					if req.Type == ExecRequestType {
+						var exec struct{ Command string }
+						if err := gossh.Unmarshal(req.Payload, &exec); err != nil {
+							logger.WithError(err).Error("failed to decode the exec request")
+
+							if err := req.Reply(false, nil); err != nil {
+								logger.WithError(err).Error("failed to reply the exec request")
+							}
+
+							continue
+						}
+
+						command = exec.Command
+					}
+
+					forced, err := sess.EvaluateCommand(command)
+					if err != nil {
+						logger.WithError(err).WithField("command", command).Warn("command is not allowed")
+
+						if err := req.Reply(false, nil); err != nil {
+							logger.WithError(err).Error("failed to reply the not allowed request")
+						}
+
+						continue
+					}
+
+					if forced != "" {
+						logger.WithField("command", forced).Info("running the command forced by the public key")
+
+						// Like OpenSSH, the command requested by the client is available to the forced one.
+						if command != "" {
+							if _, err := agent.SendRequest(EnvRequestType, false, gossh.Marshal(&struct {
+								Name  string
+								Value string
+							}{"SSH_ORIGINAL_COMMAND", command})); err != nil {
+								logger.WithError(err).Warn("failed to send the original command to agent")
+							}
+						}
+
+						reqType = ExecRequestType
+						reqPayload = gossh.Marshal(&struct{ Command string }{forced})
+					}
+				}
+
+				ok, err := agent.SendRequest(reqType, req.WantReply, reqPayload)
 				if err != nil {
 					logger.WithError(err).Error("failed to send the request from client to agent")
 
@@ -204,12 +310,55 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 						}
 					}
 
+					if req.Type == ShellRequestType {
+						sess.ShadowNotice(client.Stderr())
+					}
+
+					// NOTICE: The program recorded is the one started on the agent, which differs from the client's
+					// request when the public key forces a command.
+					var t *tap
+					switch reqType {
+					case ShellRequestType:
+						sess.Event(models.SessionEventTypeShell, nil)
+					case ExecRequestType:
+						var command models.SessionEventExec
+						gossh.Unmarshal(reqPayload, &command) //nolint:errcheck
+
+						sess.Event(models.SessionEventTypeExec, &command)
+
+						if ok && req.Type == ExecRequestType {
+							settings, err := sess.NamespaceSettings()
+
+							exec = newExecRecord(command.Command, err == nil && settings.RecordExecOutput)
+							t = exec.tap()
+						}
+					case SubsystemRequestType:
+						var subsystem models.SessionEventSubsystem
+						gossh.Unmarshal(reqPayload, &subsystem) //nolint:errcheck
+
+						sess.Event(models.SessionEventTypeSubsystem, &subsystem)
+
+						if ok && subsystem.Name == "sftp" {
+							files = sftp.NewRecorder(func(operation models.SessionEventSFTP) {
+								sess.Event(models.SessionEventTypeSFTP, operation)
+							})
+							t = &tap{input: files.Requests(), stdout: files.Responses()}
+						}
+					}
+
 					// The server SHOULD NOT halt the execution of the protocol stack when starting a shell or a
 					// program.  All input and output from these SHOULD be redirected to the channel or to the
 					// encrypted tunnel.
 					//
 					// https://www.rfc-editor.org/rfc/rfc4254#section-6.5
-					go pipe(ctx, sess, client, agent, req.Type, opts)
+					done := make(chan struct{})
+					go func() {
+						defer close(done)
+
+						pipe(ctx, sess, client, agent, req.Type, opts, t)
+					}()
+
+					go enforceTimeouts(ctx, sess, conn, client, done)
 				case PtyRequestType:
 					var pty session.Pty
 
@@ -218,6 +367,12 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 					}
 
 					sess.Pty = pty
+					sess.ShadowResize(pty.Columns, pty.Rows)
+					sess.Event(models.SessionEventTypePty, &models.SessionEventPty{
+						Term:    pty.Term,
+						Columns: pty.Columns,
+						Rows:    pty.Rows,
+					})
 
 					if req.WantReply {
 						// req.Reply(ok, nil) //nolint:errcheck
@@ -236,6 +391,11 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 					sess.Pty.Columns = dimensions.Columns
 					sess.Pty.Rows = dimensions.Rows
+					sess.ShadowResize(dimensions.Columns, dimensions.Rows)
+					sess.Event(models.SessionEventTypeWindowChange, &models.SessionEventWindowChange{
+						Columns: dimensions.Columns,
+						Rows:    dimensions.Rows,
+					})
 
 					if req.WantReply {
 						if err := req.Reply(ok, nil); err != nil {
@@ -262,6 +422,14 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 				logger.Debugf("request from agent to client: %s", req.Type)
 
+				if kind, data, ok := exitEvent(req); ok {
+					sess.Event(kind, data)
+
+					if exec != nil {
+						exec.exit(data)
+					}
+				}
+
 				ok, err := client.SendRequest(req.Type, req.WantReply, req.Payload)
 				if err != nil {
 					logger.WithError(err).Error("failed to send the request from agent to client")
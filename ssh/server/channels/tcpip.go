@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -47,7 +48,7 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 	}
 
 	if server.LocalPortForwardingCallback == nil || !server.LocalPortForwardingCallback(ctx, data.DestAddr, data.DestPort) {
-		newChan.Reject(gossh.Prohibited, "port forwarding is disabled") //nolint:errcheck
+		newChan.Reject(gossh.Prohibited, "port forwarding is not allowed") //nolint:errcheck
 		log.WithFields(log.Fields{
 			"username":    sess.Target.Username,
 			"sshid":       sess.Target.Data,
@@ -55,7 +56,7 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 			"origin_addr": data.OriginPort,
 			"dest_port":   data.DestPort,
 			"dest_addr":   data.DestAddr,
-		}).Info("port forwarding is disabled")
+		}).Warn("port forwarding is not allowed")
 
 		return
 	}
@@ -100,6 +101,13 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 
 	go gossh.DiscardRequests(reqs)
 
+	sess.Event(models.SessionEventTypeDirectTCPIP, &models.SessionEventDirectTCPIP{
+		Host:       data.DestAddr,
+		Port:       data.DestPort,
+		OriginHost: data.OriginAddr,
+		OriginPort: data.OriginPort,
+	})
+
 	log.WithFields(log.Fields{
 		"username":    sess.Target.Username,
 		"sshid":       sess.Target.Data,
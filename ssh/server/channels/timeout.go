@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// timeoutWarning is how long before the session's closing the client is warned about it.
+	timeoutWarning = time.Minute
+	// timeoutInterval is the interval between the evaluations of the session's timeouts.
+	timeoutInterval = time.Second
+)
+
+type timeoutState int
+
+const (
+	timeoutNone timeoutState = iota
+	timeoutWarn
+	timeoutExpired
+)
+
+// evaluateTimeout checks how the elapsed time relates to a limit. A zero limit never expires.
+func evaluateTimeout(elapsed, limit time.Duration) timeoutState {
+	switch {
+	case limit <= 0:
+		return timeoutNone
+	case elapsed >= limit:
+		return timeoutExpired
+	case limit > timeoutWarning && elapsed >= limit-timeoutWarning:
+		return timeoutWarn
+	default:
+		return timeoutNone
+	}
+}
+
+// activityReader marks the session's activity on each input read from the client.
+type activityReader struct {
+	io.Reader
+	sess *session.Session
+}
+
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.sess.Activity()
+	}
+
+	return n, err
+}
+
+// enforceTimeouts enforces the namespace's idle timeout and maximum session duration while the session's channel is
+// open. Before closing the session, the client is warned through the channel's standard error, what doesn't break
+// the protocols running over the standard output, like the SFTP.
+//
+// When a timeout expires, the session is closed with the reason recorded on the API and the connection is closed.
+func enforceTimeouts(ctx gliderssh.Context, sess *session.Session, conn *gossh.ServerConn, client gossh.Channel, done <-chan struct{}) {
+	settings, err := sess.NamespaceSettings()
+	if err != nil || (settings.IdleTimeout <= 0 && settings.MaxSessionDuration <= 0) {
+		return
+	}
+
+	idleTimeout := time.Duration(settings.IdleTimeout) * time.Minute
+	maxDuration := time.Duration(settings.MaxSessionDuration) * time.Minute
+
+	logger := log.WithFields(log.Fields{"uid": sess.UID, "sshid": sess.SSHID})
+
+	ticker := time.NewTicker(timeoutInterval)
+	defer ticker.Stop()
+
+	// NOTICE: The client is warned about the idle timeout once per input, as each input restarts it.
+	var warnedActivity time.Time
+	var durationWarned bool
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		now := clock.Now()
+
+		var reason models.SessionCloseReason
+		var message string
+
+		switch evaluateTimeout(now.Sub(sess.StartedAt), maxDuration) {
+		case timeoutExpired:
+			reason = models.SessionCloseReasonMaxDuration
+			message = "session closed as it reached the maximum duration"
+		case timeoutWarn:
+			if !durationWarned {
+				durationWarned = true
+
+				warn(client, logger, fmt.Sprintf("session will be closed in %s as it reaches the maximum duration", timeoutWarning))
+			}
+		}
+
+		last := sess.LastActivity()
+
+		switch evaluateTimeout(now.Sub(last), idleTimeout) {
+		case timeoutExpired:
+			if reason == "" {
+				reason = models.SessionCloseReasonIdleTimeout
+				message = "session closed due to inactivity"
+			}
+		case timeoutWarn:
+			if !last.Equal(warnedActivity) {
+				warnedActivity = last
+
+				warn(client, logger, fmt.Sprintf("session will be closed in %s due to inactivity", timeoutWarning))
+			}
+		}
+
+		if reason == "" {
+			continue
+		}
+
+		warn(client, logger, message)
+
+		logger.WithField("reason", reason).Info("closing the session")
+
+		client.Close() //nolint:errcheck
+
+		if err := sess.Close(reason); err != nil {
+			logger.WithError(err).Warn("failed to close the session")
+		}
+
+		conn.Close() //nolint:errcheck
+
+		return
+	}
+}
+
+// warn writes a message to the client's standard error.
+func warn(client gossh.Channel, logger *log.Entry, message string) {
+	if _, err := client.Stderr().Write([]byte("\r\n" + message + "\r\n")); err != nil {
+		logger.WithError(err).Warn("failed to warn the client")
+	}
+}
@@ -0,0 +1,54 @@
+package channels
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateTimeout(t *testing.T) {
+	cases := []struct {
+		description string
+		elapsed     time.Duration
+		limit       time.Duration
+		expected    timeoutState
+	}{
+		{
+			description: "never expires when the limit is zero",
+			elapsed:     24 * time.Hour,
+			limit:       0,
+			expected:    timeoutNone,
+		},
+		{
+			description: "does nothing before the warning",
+			elapsed:     5 * time.Minute,
+			limit:       10 * time.Minute,
+			expected:    timeoutNone,
+		},
+		{
+			description: "warns a minute before the limit",
+			elapsed:     9*time.Minute + 30*time.Second,
+			limit:       10 * time.Minute,
+			expected:    timeoutWarn,
+		},
+		{
+			description: "does not warn when the limit is shorter than the warning",
+			elapsed:     30 * time.Second,
+			limit:       time.Minute,
+			expected:    timeoutNone,
+		},
+		{
+			description: "expires when the limit is reached",
+			elapsed:     10 * time.Minute,
+			limit:       10 * time.Minute,
+			expected:    timeoutExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, evaluateTimeout(tc.elapsed, tc.limit))
+		})
+	}
+}
@@ -13,7 +13,7 @@ import (
 	gossh "golang.org/x/crypto/ssh"
 )
 
-func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, agent gossh.Channel, req string, opts DefaultSessionHandlerOptions) {
+func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, agent gossh.Channel, req string, opts DefaultSessionHandlerOptions, t *tap) {
 	defer func() {
 		ctx.Lock()
 		sess.Handled = false
@@ -32,8 +32,37 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
-	c := io.MultiReader(client, client.Stderr())
-	a := io.MultiReader(agent, agent.Stderr())
+	c, stdout, stderr := t.wrap(&activityReader{Reader: io.MultiReader(client, client.Stderr()), sess: sess}, agent, agent.Stderr())
+	a := io.MultiReader(stdout, stderr)
+
+	if req == ShellRequestType {
+		inputs := newInputQueue(func(frame *models.SessionRecorded) {
+			sess.Record(frame, opts.RecordURL) //nolint:errcheck
+		})
+		defer inputs.close()
+
+		// NOTICE: When the session is shared, the input typed by each participant is recorded attributed to it.
+		sess.ShareTerminal(agent, func(participant string, input []byte) {
+			if envs.IsEnterprise() || envs.IsCloud() {
+				inputs.push(&models.SessionRecorded{
+					UID:         sess.UID,
+					Namespace:   sess.Lookup["domain"],
+					Message:     string(input),
+					Width:       int(sess.Pty.Columns),
+					Height:      int(sess.Pty.Rows),
+					Direction:   models.RecordDirectionInput,
+					Participant: participant,
+				})
+			}
+		})
+
+		// NOTICE: The input typed by the session's owner is recorded, besides while the session is shared, when the
+		// namespace records the input typed on its sessions.
+		settings, err := sess.NamespaceSettings()
+		sess.RecordInput((envs.IsEnterprise() || envs.IsCloud()) && err == nil && settings.RecordInput)
+
+		c = &typedReader{Reader: c, sess: sess}
+	}
 
 	go func() {
 		defer wg.Done()
@@ -67,6 +96,9 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 					break
 				}
 
+				sess.ShadowOutput(buffer[:read])
+				sess.Displayed(buffer[:read])
+
 				if envs.IsEnterprise() || envs.IsCloud() {
 					message := string(buffer[:read])
 
@@ -101,3 +133,72 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 
 	wg.Wait()
 }
+
+// typedReader records the input read from the session's owner when the session is shared.
+type typedReader struct {
+	io.Reader
+	sess *session.Session
+}
+
+func (r *typedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.sess.Typed(p[:n])
+	}
+
+	return n, err
+}
+
+// inputQueueSize is the number of input frames queued to be recorded before the typing waits for them to be sent.
+const inputQueueSize = 1024
+
+// inputQueue sends the input frames to be recorded from a goroutine, in the order they were typed, so recording them
+// doesn't hold the input piped from the client to the agent.
+type inputQueue struct {
+	frames chan *models.SessionRecorded
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newInputQueue(send func(frame *models.SessionRecorded)) *inputQueue {
+	q := &inputQueue{frames: make(chan *models.SessionRecorded, inputQueueSize), done: make(chan struct{})}
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+
+		for {
+			select {
+			case frame := <-q.frames:
+				send(frame)
+			case <-q.done:
+				// NOTICE: The frames queued before the queue is closed are still sent.
+				for {
+					select {
+					case frame := <-q.frames:
+						send(frame)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return q
+}
+
+// push queues the frame to be sent, waiting while the queue is full. The frames pushed after the queue is closed are
+// dropped.
+func (q *inputQueue) push(frame *models.SessionRecorded) {
+	select {
+	case q.frames <- frame:
+	case <-q.done:
+	}
+}
+
+// close stops the queue, waiting for the frames already queued to be sent.
+func (q *inputQueue) close() {
+	close(q.done)
+	q.wg.Wait()
+}
@@ -0,0 +1,35 @@
+package channels
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputQueue(t *testing.T) {
+	sent := make([]string, 0)
+	release := make(chan struct{})
+
+	queue := newInputQueue(func(frame *models.SessionRecorded) {
+		<-release
+
+		sent = append(sent, frame.Message)
+	})
+
+	expected := make([]string, 0)
+	for i := 0; i < 10; i++ {
+		// NOTICE: The frames are pushed while the first one is still being sent, so pushing doesn't wait for it.
+		queue.push(&models.SessionRecorded{Message: strconv.Itoa(i)})
+		expected = append(expected, strconv.Itoa(i))
+	}
+
+	close(release)
+	queue.close()
+
+	assert.Equal(t, expected, sent)
+
+	queue.push(&models.SessionRecorded{Message: "dropped"})
+	assert.Equal(t, expected, sent)
+}
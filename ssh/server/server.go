@@ -1,14 +1,21 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/pires/go-proxyproto"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/pubsub"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/server/channels"
@@ -31,6 +38,8 @@ type Server struct {
 	sshd   *gliderssh.Server
 	opts   *Options
 	tunnel *httptunnel.Tunnel
+	// conns are the contexts of the connections open on this server, by the session's UID.
+	conns sync.Map
 }
 
 func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
@@ -55,6 +64,8 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 
 			logger.Info("new connection established")
 
+			server.track(ctx)
+
 			target, err := target.NewTarget(ctx.User())
 			if err != nil {
 				logger.WithError(err).Error("invalid SSHID")
@@ -98,13 +109,49 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 			channels.DirectTCPIPChannel: channels.DefaultDirectTCPIPHandler,
 		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, dhost string, dport uint32) bool {
+			sess, _ := session.ObtainSession(ctx)
+			if sess == nil {
+				return false
+			}
+
+			if err := sess.EvaluateForwarding(dhost, dport); err != nil {
+				// The denied forwardings are logged by the direct-tcpip handler.
+				if !errors.Is(err, internalclient.ErrForwardingBlock) {
+					log.WithError(err).
+						WithFields(log.Fields{"uid": ctx.SessionID(), "sshid": ctx.User()}).
+						Error("failed to evaluate the local port forwarding")
+				}
+
+				return false
+			}
+
 			return true
 		},
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, bindHost string, bindPort uint32) bool {
-			return false
+			sess, _ := session.ObtainSession(ctx)
+			if sess == nil {
+				return false
+			}
+
+			settings, err := sess.NamespaceSettings()
+			if err != nil {
+				log.WithError(err).
+					WithFields(log.Fields{"uid": ctx.SessionID(), "sshid": ctx.User()}).
+					Error("failed to get the namespace settings to check the reverse port forwarding")
+
+				return false
+			}
+
+			return settings.ReversePortForwarding
 		},
 	}
 
+	forward := channels.DefaultTCPIPForwardHandler()
+	server.sshd.RequestHandlers = map[string]gliderssh.RequestHandler{
+		channels.TCPIPForwardRequestType:       forward.HandleSSHRequest,
+		channels.CancelTCPIPForwardRequestType: forward.HandleSSHRequest,
+	}
+
 	if _, err := os.Stat(os.Getenv("PRIVATE_KEY")); os.IsNotExist(err) {
 		log.WithError(err).Fatal("private key not found!")
 	}
@@ -116,6 +163,109 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 	return server
 }
 
+// track keeps the connection's context while the connection is open, to find it by the session's UID.
+func (s *Server) track(ctx gliderssh.Context) {
+	uid := ctx.SessionID()
+
+	s.conns.Store(uid, ctx)
+	go func() {
+		<-ctx.Done()
+
+		s.conns.Delete(uid)
+	}()
+}
+
+// Session gets the session with the UID when this server holds its connection.
+func (s *Server) Session(uid string) (*session.Session, bool) {
+	value, ok := s.conns.Load(uid)
+	if !ok {
+		return nil, false
+	}
+
+	sess, _ := session.ObtainSession(value.(gliderssh.Context))
+
+	return sess, sess != nil
+}
+
+// CloseSession closes the session's connection, as closed by a namespace's member, when this server holds it.
+func (s *Server) CloseSession(uid string) {
+	value, ok := s.conns.Load(uid)
+	if !ok {
+		return
+	}
+
+	ctx := value.(gliderssh.Context)
+
+	log.WithFields(log.Fields{"uid": uid, "sshid": ctx.User()}).Info("closing the session signaled by the API")
+
+	if sess, _ := session.ObtainSession(ctx); sess != nil {
+		if err := sess.Close(models.SessionCloseReasonUser); err != nil {
+			log.WithError(err).WithField("uid", uid).Warn("failed to close the session")
+		}
+	}
+
+	if conn, ok := ctx.Value("conn").(net.Conn); ok {
+		conn.Close() //nolint:errcheck
+	}
+}
+
+// ShareSession applies the sharing of the session, as invited or changed by a namespace's member, when this server
+// holds it.
+func (s *Server) ShareSession(share pubsub.SessionShare) {
+	sess, ok := s.Session(share.UID)
+	if !ok {
+		return
+	}
+
+	logger := log.WithFields(log.Fields{"uid": share.UID, "guest": share.Guest, "input": share.Input})
+
+	if share.Token != "" {
+		logger.Info("inviting a guest to the session signaled by the API")
+
+		sess.Invite(share.Token, share.Guest, share.Input)
+
+		return
+	}
+
+	logger.Info("changing the guest's input control signaled by the API")
+
+	sess.AllowInput(share.Guest, share.Input)
+}
+
+// HandleSignals subscribes to the sessions' signals published by the API, acting on the sessions held by this server,
+// until the context is done.
+func (s *Server) HandleSignals(ctx context.Context, signals *pubsub.PubSub) error {
+	errs := make(chan error, 2)
+
+	go func() {
+		errs <- signals.Subscribe(ctx, pubsub.SessionCloseChannel, func(payload []byte) {
+			var message pubsub.SessionClose
+			if err := json.Unmarshal(payload, &message); err != nil {
+				log.WithError(err).Warn("failed to decode the session close signal")
+
+				return
+			}
+
+			s.CloseSession(message.UID)
+		})
+	}()
+
+	go func() {
+		errs <- signals.Subscribe(ctx, pubsub.SessionShareChannel, func(payload []byte) {
+			var message pubsub.SessionShare
+			if err := json.Unmarshal(payload, &message); err != nil {
+				log.WithError(err).Warn("failed to decode the session share signal")
+
+				return
+			}
+
+			s.ShareSession(message)
+		})
+	}()
+
+	return <-errs
+}
+
 func (s *Server) ListenAndServe() error {
 	log.WithFields(log.Fields{
 		"addr": s.sshd.Addr,
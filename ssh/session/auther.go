@@ -1,11 +1,15 @@
 package session
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/pem"
+	"net"
+	"strings"
 
 	"github.com/Masterminds/semver"
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -49,29 +53,70 @@ func (*publicKeyAuth) Method() authMethod {
 
 func (*publicKeyAuth) Auth() authFunc {
 	return func(session *Session, config *gossh.ClientConfig) error {
-		privateKey, err := session.api.CreatePrivateKey()
-		if err != nil {
-			return err
+		// NOTICE: An agentless target runs its own SSH server, which usually doesn't trust the device key, so the
+		// credentials stored for it are used once the user's public key is accepted.
+		if session.Device.Agentless {
+			ok, err := credentialsAuth(session, config)
+			if err != nil || ok {
+				return err
+			}
 		}
 
-		block, _ := pem.Decode(privateKey.Data)
+		return deviceKeyAuth(session, config)
+	}
+}
 
-		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-		if err != nil {
-			return err
-		}
+// credentialsAuth authenticates the session with the credentials stored for the agentless target, reporting whether
+// there were credentials stored.
+func credentialsAuth(session *Session, config *gossh.ClientConfig) (bool, error) {
+	credentials, err := session.api.GetDeviceCredentials(session.Device.UID)
+	if err != nil {
+		return false, err
+	}
 
-		signer, err := gossh.NewSignerFromKey(parsed)
+	config.Auth = []gossh.AuthMethod{}
+
+	if credentials.PrivateKey != "" {
+		signer, err := gossh.ParsePrivateKey([]byte(credentials.PrivateKey))
 		if err != nil {
-			return err
+			return false, err
 		}
 
-		config.Auth = []gossh.AuthMethod{
-			gossh.PublicKeys(signer),
-		}
+		config.Auth = append(config.Auth, gossh.PublicKeys(signer))
+	}
 
-		return nil
+	if credentials.Password != "" {
+		config.Auth = append(config.Auth, gossh.Password(credentials.Password))
+	}
+
+	return len(config.Auth) > 0, nil
+}
+
+// deviceKeyAuth authenticates the session with the namespace's device key, a private key created by the API that the
+// devices trust.
+func deviceKeyAuth(session *Session, config *gossh.ClientConfig) error {
+	privateKey, err := session.api.CreatePrivateKey()
+	if err != nil {
+		return err
+	}
+
+	block, _ := pem.Decode(privateKey.Data)
+
+	parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return err
 	}
+
+	signer, err := gossh.NewSignerFromKey(parsed)
+	if err != nil {
+		return err
+	}
+
+	config.Auth = []gossh.AuthMethod{
+		gossh.PublicKeys(signer),
+	}
+
+	return nil
 }
 
 func (p *publicKeyAuth) Evaluate(session *Session) error {
@@ -80,7 +125,8 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 	// treated as "authenticated" because the connection does not raise any error.
 	// Moreover, the agent panics after the connection ends. To avoid this, connections
 	// with public key are not permitted when agent version is 0.5.x or earlier
-	if !sshconf.AllowPublickeyAccessBelow060 {
+	// NOTICE: An agentless target has no agent to have its version checked.
+	if !sshconf.AllowPublickeyAccessBelow060 && !session.Device.Agentless {
 		version := session.Device.Info.Version
 		if version != "latest" {
 			semverVersion, err := semver.NewVersion(version)
@@ -102,18 +148,106 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 	}
 
 	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = session.api.GetPublicKey(fingerprint, session.Device.TenantID); err != nil {
+		if cert, ok := p.pk.(*gossh.Certificate); ok {
+			return evaluateCertificate(session, cert)
+		}
+
+		key, err := session.api.GetPublicKey(fingerprint, session.Device.TenantID)
+		if err != nil {
 			return err
 		}
 
 		if ok, err := session.api.EvaluateKey(fingerprint, session.Device, session.Data.Target.Username); !ok || err != nil {
 			return ErrEvaluatePublicKey
 		}
+
+		session.ForceCommand = key.ForceCommand
+		session.AllowedCommands = key.AllowedCommands
 	}
 
 	return err
 }
 
+// certificateSourceAddress is the certificate's critical option what restricts the addresses allowed to use it.
+//
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.certkeys
+const certificateSourceAddress = "source-address"
+
+// evaluateCertificate evaluates an OpenSSH user certificate against the certificate authorities trusted by the device's
+// namespace. The certificate must be signed by one of them, be in its validity period, have the target's username
+// as one of its principals and, when restricted, be used from one of its source addresses.
+func evaluateCertificate(session *Session, cert *gossh.Certificate) error {
+	if cert.CertType != gossh.UserCert || len(cert.ValidPrincipals) == 0 {
+		return ErrEvaluateCertificate
+	}
+
+	cas, err := session.api.ListCertificateAuthorities(session.Device.TenantID)
+	if err != nil {
+		return err
+	}
+
+	trusted := false
+	for _, ca := range cas {
+		key, _, _, _, err := gossh.ParseAuthorizedKey(ca.Data) //nolint:dogsled
+		if err == nil && bytes.Equal(key.Marshal(), cert.SignatureKey.Marshal()) {
+			trusted = true
+
+			break
+		}
+	}
+
+	if !trusted {
+		return ErrEvaluateCertificate
+	}
+
+	checker := &gossh.CertChecker{
+		SupportedCriticalOptions: []string{certificateSourceAddress},
+	}
+
+	// NOTICE: [gossh.CertChecker.CheckCert] checks the certificate's signature, validity period, principals and
+	// critical options supported, but neither the certificate authority nor the source addresses.
+	if err := checker.CheckCert(session.Data.Target.Username, cert); err != nil {
+		return ErrEvaluateCertificate
+	}
+
+	if addresses, ok := cert.CriticalOptions[certificateSourceAddress]; ok && !matchSourceAddress(addresses, session.IPAddress) {
+		return ErrEvaluateCertificate
+	}
+
+	session.Certificate = &requests.SessionCertificate{
+		Serial: cert.Serial,
+		KeyID:  cert.KeyId,
+	}
+
+	return nil
+}
+
+// matchSourceAddress checks if the address is in the comma-separated list of addresses and CIDRs.
+func matchSourceAddress(addresses, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, source := range strings.Split(addresses, ",") {
+		source = strings.TrimSpace(source)
+
+		if _, network, err := net.ParseCIDR(source); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if allowed := net.ParseIP(source); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type passwordAuth struct {
 	pwd string
 }
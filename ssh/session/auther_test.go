@@ -0,0 +1,165 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestEvaluateCertificate(t *testing.T) {
+	_, caKey, _ := ed25519.GenerateKey(rand.Reader)
+	ca, _ := gossh.NewSignerFromKey(caKey)
+
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	other, _ := gossh.NewSignerFromKey(otherKey)
+
+	userKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	user, _ := gossh.NewPublicKey(userKey)
+
+	certificate := func(signer gossh.Signer, principals []string, options map[string]string) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user,
+			Serial:          42,
+			KeyId:           "alice@example.com",
+			CertType:        gossh.UserCert,
+			ValidPrincipals: principals,
+			ValidAfter:      uint64(time.Now().Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(time.Now().Add(time.Hour).Unix()),
+			Permissions:     gossh.Permissions{CriticalOptions: options},
+		}
+
+		assert.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		return cert
+	}
+
+	cases := []struct {
+		description string
+		cert        *gossh.Certificate
+		expected    error
+	}{
+		{
+			description: "fails when the certificate authority is not trusted by the namespace",
+			cert:        certificate(other, []string{"root"}, nil),
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the username is not a principal",
+			cert:        certificate(ca, []string{"admin"}, nil),
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the certificate has no principals",
+			cert:        certificate(ca, nil, nil),
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the address is not a source address",
+			cert:        certificate(ca, []string{"root"}, map[string]string{"source-address": "10.0.0.0/8"}),
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the certificate has an unsupported critical option",
+			cert:        certificate(ca, []string{"root"}, map[string]string{"force-command": "ls"}),
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "succeeds when the certificate is valid",
+			cert:        certificate(ca, []string{"root"}, map[string]string{"source-address": "10.0.0.0/8,192.168.0.1"}),
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			api.On("ListCertificateAuthorities", "tenant").
+				Return([]models.CertificateAuthority{{Data: gossh.MarshalAuthorizedKey(ca.PublicKey())}}, nil).
+				Maybe()
+
+			session := &Session{
+				api: api,
+				Data: Data{
+					Target:    &target.Target{Username: "root"},
+					Device:    &models.Device{TenantID: "tenant"},
+					IPAddress: "192.168.0.1",
+				},
+			}
+
+			assert.Equal(t, tc.expected, evaluateCertificate(session, tc.cert))
+
+			if tc.expected == nil {
+				assert.Equal(t, &requests.SessionCertificate{Serial: 42, KeyID: "alice@example.com"}, session.Certificate)
+			}
+		})
+	}
+}
+
+func TestCredentialsAuth(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	block, _ := gossh.MarshalPrivateKey(key, "")
+	privateKey := string(pem.EncodeToMemory(block))
+
+	cases := []struct {
+		description string
+		credentials *models.DeviceCredentials
+		err         error
+		methods     int
+		expected    bool
+	}{
+		{
+			description: "fails when the credentials cannot be retrieved",
+			credentials: nil,
+			err:         errors.New("error"),
+			expected:    false,
+		},
+		{
+			description: "succeeds without authentication when no credentials are stored",
+			credentials: &models.DeviceCredentials{},
+			methods:     0,
+			expected:    false,
+		},
+		{
+			description: "succeeds with the stored password",
+			credentials: &models.DeviceCredentials{Password: "secret"},
+			methods:     1,
+			expected:    true,
+		},
+		{
+			description: "succeeds with the stored private key and password",
+			credentials: &models.DeviceCredentials{Password: "secret", PrivateKey: privateKey},
+			methods:     2,
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			api.On("GetDeviceCredentials", "target").Return(tc.credentials, tc.err).Once()
+
+			session := &Session{api: api, Data: Data{Device: &models.Device{UID: "target", Agentless: true}}}
+			config := &gossh.ClientConfig{}
+
+			ok, err := credentialsAuth(session, config)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, ok)
+
+			if tc.err == nil {
+				assert.Len(t, config.Auth, tc.methods)
+			}
+
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -10,9 +10,16 @@ var (
 	ErrFirewallUnknown         = fmt.Errorf("failed to evaluate the firewall rule")
 	ErrHost                    = fmt.Errorf("failed to get the device address")
 	ErrFindDevice              = fmt.Errorf("failed to find the device")
+	ErrFindNamespace           = fmt.Errorf("failed to find the device's namespace")
 	ErrDial                    = fmt.Errorf("failed to connect to device agent, please check the device connection")
+	ErrDeviceVia               = fmt.Errorf("failed to find the devices which the device is reached through")
+	ErrDialVia                 = fmt.Errorf("failed to connect to the device through the device it is reached via")
 	ErrInvalidVersion          = fmt.Errorf("failed to parse device version")
 	ErrUnsuportedPublicKeyAuth = fmt.Errorf("connections using public keys are not permitted when the agent version is 0.5.x or earlier")
 	ErrUnexpectedAuthMethod    = fmt.Errorf("failed to authenticate the session due to a unexpected method")
 	ErrEvaluatePublicKey       = fmt.Errorf("failed to evaluate the provided public key")
+	ErrEvaluateCertificate     = fmt.Errorf("failed to evaluate the provided certificate")
+	ErrCommandNotAllowed       = fmt.Errorf("the command is not allowed by the public key used to authenticate")
+	ErrShareInputNotAllowed    = fmt.Errorf("the guest is not allowed to type on the session")
+	ErrShareNoTerminal         = fmt.Errorf("the session has no terminal to type on")
 )
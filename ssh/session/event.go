@@ -0,0 +1,59 @@
+package session
+
+import (
+	"sync"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// eventQueueSize is the number of events queued to be sent before recording another waits for them to be sent.
+const eventQueueSize = 256
+
+// eventQueue sends the session's events from a single goroutine, in the order they happened, so recording them
+// doesn't hold the session.
+type eventQueue struct {
+	mu     sync.RWMutex
+	closed bool
+	events chan *models.SessionEvent
+	wg     sync.WaitGroup
+}
+
+func newEventQueue(send func(event *models.SessionEvent)) *eventQueue {
+	q := &eventQueue{events: make(chan *models.SessionEvent, eventQueueSize)}
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+
+		for event := range q.events {
+			send(event)
+		}
+	}()
+
+	return q
+}
+
+// push queues the event to be sent, waiting while the queue is full. It returns false, without queuing the event, when
+// the queue is already closed.
+func (q *eventQueue) push(event *models.SessionEvent) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	q.events <- event
+
+	return true
+}
+
+// close stops the queue, waiting for the events already queued to be sent.
+func (q *eventQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	close(q.events)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventQueue(t *testing.T) {
+	sent := make([]models.SessionEventType, 0)
+	release := make(chan struct{})
+
+	queue := newEventQueue(func(event *models.SessionEvent) {
+		<-release
+
+		sent = append(sent, event.Type)
+	})
+
+	expected := []models.SessionEventType{
+		models.SessionEventTypePty,
+		models.SessionEventTypeWindowChange,
+		models.SessionEventTypeShell,
+		models.SessionEventTypeWindowChange,
+		models.SessionEventTypeExec,
+	}
+
+	for _, typ := range expected {
+		// NOTICE: The events are pushed while the first one is still being sent, so pushing doesn't wait for it.
+		assert.True(t, queue.push(&models.SessionEvent{Type: typ}))
+	}
+
+	close(release)
+	queue.close()
+
+	assert.Equal(t, expected, sent)
+
+	assert.False(t, queue.push(&models.SessionEvent{Type: models.SessionEventTypeExecResult}))
+	assert.Equal(t, expected, sent)
+}
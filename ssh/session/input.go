@@ -0,0 +1,107 @@
+package session
+
+import (
+	"encoding/binary"
+	"regexp"
+	"sync"
+)
+
+const (
+	// ttyOpEnd ends the encoded terminal modes.
+	ttyOpEnd byte = 0
+	// ttyOpEcho enables or disables the echo of the input.
+	ttyOpEcho byte = 53
+	// ttyOpLast is the last opcode defined by the encoded terminal modes. The opcodes after it have no defined
+	// argument, so the modes cannot be parsed beyond them.
+	ttyOpLast byte = 159
+)
+
+// inputMask replaces each byte of the input typed while the terminal doesn't echo it.
+const inputMask = '*'
+
+// passwordPrompt matches the terminal's output asking for a password or a passphrase, like the prompts of sudo, su and
+// ssh, after which the device's terminal doesn't echo the input.
+var passwordPrompt = regexp.MustCompile(`(?i)(password|passphrase)[^\r\n]*:\s*$`)
+
+// inputs is how the input typed on the session's terminal is recorded.
+type inputs struct {
+	mu sync.Mutex
+	// enabled records the input even when the session isn't shared.
+	enabled bool
+	// echoOff is set when the terminal modes requested by the client disable the echo, masking every input.
+	echoOff bool
+	// prompt is set when the terminal asked for a password, masking the input until the line typed ends.
+	prompt bool
+}
+
+// echoOff checks if the encoded terminal modes disable the echo of the input.
+//
+// https://www.rfc-editor.org/rfc/rfc4254#section-8
+func echoOff(modes string) bool {
+	for len(modes) >= 5 {
+		opcode := modes[0]
+		if opcode == ttyOpEnd || opcode > ttyOpLast {
+			break
+		}
+
+		if opcode == ttyOpEcho {
+			return binary.BigEndian.Uint32([]byte(modes[1:5])) == 0
+		}
+
+		modes = modes[5:]
+	}
+
+	return false
+}
+
+// mask masks the input typed while the terminal doesn't echo it. The line breaks are kept, ending the password prompt.
+func (i *inputs) mask(input []byte) []byte {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.echoOff && !i.prompt {
+		return input
+	}
+
+	masked := make([]byte, len(input))
+	for n, b := range input {
+		if !i.echoOff && !i.prompt {
+			masked[n] = b
+
+			continue
+		}
+
+		switch b {
+		// NOTICE: Besides the line's end, interrupting or ending the input also leaves the password prompt.
+		case '\r', '\n', 0x03, 0x04:
+			masked[n] = b
+			i.prompt = false
+		default:
+			masked[n] = inputMask
+		}
+	}
+
+	return masked
+}
+
+// RecordInput sets if the input typed on the session's terminal is recorded even when the session isn't shared. The
+// input is masked when the terminal modes requested by the client disable the echo.
+func (s *Session) RecordInput(enabled bool) {
+	s.inputs.mu.Lock()
+	defer s.inputs.mu.Unlock()
+
+	s.inputs.enabled = enabled
+	s.inputs.echoOff = echoOff(s.Pty.Modelist)
+}
+
+// Displayed checks the terminal's output for a password prompt, masking the input typed after it until the line ends.
+func (s *Session) Displayed(output []byte) {
+	if !passwordPrompt.Match(output) {
+		return
+	}
+
+	s.inputs.mu.Lock()
+	defer s.inputs.mu.Unlock()
+
+	s.inputs.prompt = true
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
@@ -44,8 +47,22 @@ type Data struct {
 	// SSHID is the combination of device's name and namespace name.
 	SSHID string
 	// Device is the device connected.
-	Device    *models.Device
+	Device *models.Device
+	// Hops are the devices the connection jumps through to reach the device, starting from the one reached by its
+	// agent. It is empty when the device is reached by its own agent.
+	Hops      []*models.Device
 	IPAddress string
+	// Fingerprint is the fingerprint of the public key used to authenticate the session. It is empty when the session
+	// was authenticated by password.
+	Fingerprint string
+	// Certificate is the SSH certificate used to authenticate the session. It is nil when the session wasn't
+	// authenticated by a certificate.
+	Certificate *requests.SessionCertificate
+	// ForceCommand is the command forced by the public key used to authenticate the session.
+	ForceCommand string
+	// AllowedCommands are the regular expressions of the commands allowed by the public key used to authenticate the
+	// session.
+	AllowedCommands []string
 	// Type is the connection type.
 	Type string
 	// Term is the terminal used for the client.
@@ -70,10 +87,23 @@ type Session struct {
 	// AgentGlobalReqs is the channel to handle global request like "keepalive".
 	AgentGlobalReqs <-chan *gossh.Request
 
+	// StartedAt is the time when the client connected.
+	StartedAt time.Time
+
 	api    internalclient.Client
 	tunnel *httptunnel.Tunnel
 
 	once *sync.Once
+	// activity is the Unix time, in nanoseconds, of the last input from the client.
+	activity atomic.Int64
+	// shadows are the users watching the session's terminal output.
+	shadows shadows
+	// shares are the guests invited to the session.
+	shares shares
+	// inputs is how the input typed on the session's terminal is recorded.
+	inputs inputs
+	// events are the session's events waiting to be sent to the API.
+	events *eventQueue
 
 	Data
 }
@@ -119,6 +149,11 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 		return nil, errs[0]
 	}
 
+	hops, err := resolveHops(api, device)
+	if err != nil {
+		return nil, err
+	}
+
 	hos, err := host.NewHost(ctx.RemoteAddr().String())
 	if err != nil {
 		log.WithError(err).
@@ -128,19 +163,23 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 	}
 
 	session := &Session{
-		UID:    ctx.SessionID(),
-		api:    api,
-		tunnel: tunnel,
+		UID:       ctx.SessionID(),
+		StartedAt: clock.Now(),
+		api:       api,
+		tunnel:    tunnel,
 		Data: Data{
 			IPAddress: hos.Host,
 			Target:    target,
 			Device:    device,
+			Hops:      hops,
 			Lookup:    lookup,
 			SSHID:     ctx.User(),
 		},
 		once: new(sync.Once),
 	}
 
+	session.events = newEventQueue(session.sendEvent)
+
 	session.Data.Lookup["username"] = target.Username
 	session.Data.Lookup["ip_address"] = hos.Host
 
@@ -149,6 +188,39 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 	return session, nil
 }
 
+// maxHops is the maximum number of devices the connection jumps through to reach a device.
+const maxHops = 4
+
+// resolveHops resolves the devices the connection jumps through to reach the device, following each device's via
+// until the one reached by its own agent.
+func resolveHops(api internalclient.Client, device *models.Device) ([]*models.Device, error) {
+	// NOTICE: An agentless target has no agent of its own, so it can only be reached through another device.
+	if device.Agentless && device.Via == nil {
+		return nil, ErrDeviceVia
+	}
+
+	hops := []*models.Device{}
+	for via := device.Via; via != nil; {
+		if len(hops) == maxHops {
+			return nil, ErrDeviceVia
+		}
+
+		hop, err := api.GetDevice(via.Device)
+		if err != nil {
+			return nil, errors.Join(ErrDeviceVia, err)
+		}
+
+		if hop.TenantID != device.TenantID {
+			return nil, ErrDeviceVia
+		}
+
+		hops = append([]*models.Device{hop}, hops...)
+		via = hop.Via
+	}
+
+	return hops, nil
+}
+
 func (s *Session) checkFirewall() (bool, error) {
 	if err := s.api.FirewallEvaluate(s.Data.Lookup); err != nil {
 		defer log.WithError(err).WithFields(log.Fields{
@@ -194,13 +266,20 @@ func (s *Session) checkBilling() (bool, error) {
 
 // registerAPISession registers a new session on the API.
 func (s *Session) register() error {
+	hops := make([]string, 0, len(s.Hops))
+	for _, hop := range s.Hops {
+		hops = append(hops, hop.UID)
+	}
+
 	err := s.api.SessionCreate(requests.SessionCreate{
-		UID:       s.UID,
-		DeviceUID: s.Device.UID,
-		Username:  s.Target.Username,
-		IPAddress: s.IPAddress,
-		Type:      "none",
-		Term:      "none",
+		UID:         s.UID,
+		DeviceUID:   s.Device.UID,
+		Username:    s.Target.Username,
+		IPAddress:   s.IPAddress,
+		Type:        "none",
+		Term:        "none",
+		Certificate: s.Certificate,
+		Hops:        hops,
 	})
 	if err != nil {
 		log.WithError(err).
@@ -225,6 +304,9 @@ func (s *Session) authenticate() error {
 }
 
 // connect connects the session's client to the session's agent.
+//
+// When the device is reached through other devices, the agent and the devices jumped through are authenticated with
+// the namespace's device key, and the device itself with the session's authentication.
 func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 	config := &gossh.ClientConfig{
 		User:            s.Target.Username,
@@ -235,6 +317,14 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 		return errors.New("fail to generate the authentication information")
 	}
 
+	agentConfig := config
+	if len(s.Hops) > 0 {
+		var err error
+		if agentConfig, err = s.hopConfig(); err != nil {
+			return err
+		}
+	}
+
 	const Addr = "tcp"
 
 	// NOTICE: When the agent connection is closed, we should redial this connection before try to authenticate.
@@ -244,8 +334,8 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 		}
 	}
 
-	if config.Timeout > 0 {
-		if err := s.AgentConn.SetReadDeadline(clock.Now().Add(config.Timeout)); err != nil {
+	if agentConfig.Timeout > 0 {
+		if err := s.AgentConn.SetReadDeadline(clock.Now().Add(agentConfig.Timeout)); err != nil {
 			log.WithError(err).
 				WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
 				Error("Error when trying to set dial deadline")
@@ -254,7 +344,7 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 		}
 	}
 
-	conn, chans, reqs, err := gossh.NewClientConn(s.AgentConn, Addr, config)
+	conn, chans, reqs, err := gossh.NewClientConn(s.AgentConn, Addr, agentConfig)
 	if err != nil {
 		log.WithError(err).
 			WithFields(log.Fields{"session": s.UID}).
@@ -267,7 +357,7 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 		return err
 	}
 
-	if config.Timeout > 0 {
+	if agentConfig.Timeout > 0 {
 		if err := s.AgentConn.SetReadDeadline(time.Time{}); err != nil {
 			log.WithError(err).
 				WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
@@ -277,6 +367,23 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 		}
 	}
 
+	if len(s.Hops) > 0 {
+		agent := gossh.NewClient(conn, chans, reqs)
+
+		if conn, chans, reqs, err = s.jump(agent, config); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
+				Error("Error when trying to jump to the device")
+
+			// NOTICE: The agent's connection is already used by the SSH connection to the agent, so it must be dialed
+			// again on the next attempt.
+			agent.Close() // nolint: errcheck
+			s.AgentConn = nil
+
+			return err
+		}
+	}
+
 	ch := make(chan *gossh.Request)
 	close(ch)
 
@@ -286,11 +393,66 @@ func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 	return nil
 }
 
+// hopConfig creates the client's configuration to authenticate on the devices the connection jumps through.
+func (s *Session) hopConfig() (*gossh.ClientConfig, error) {
+	config := &gossh.ClientConfig{
+		User:            s.Target.Username,
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint: gosec
+	}
+
+	if err := deviceKeyAuth(s, config); err != nil {
+		return nil, errors.New("fail to generate the authentication information")
+	}
+
+	return config, nil
+}
+
+// jump connects to the device from the client connected to the first hop's agent, opening a direct-tcpip to each
+// next device's SSH port on the network of the device before it.
+func (s *Session) jump(client *gossh.Client, config *gossh.ClientConfig) (gossh.Conn, <-chan gossh.NewChannel, <-chan *gossh.Request, error) {
+	devices := make([]*models.Device, 0, len(s.Hops))
+	devices = append(devices, s.Hops[1:]...)
+	devices = append(devices, s.Device)
+
+	for i, device := range devices {
+		addr := net.JoinHostPort(device.Via.Host, strconv.FormatUint(uint64(device.Via.Port), 10))
+
+		tcp, err := client.Dial("tcp", addr)
+		if err != nil {
+			return nil, nil, nil, errors.Join(ErrDialVia, err)
+		}
+
+		if i == len(devices)-1 {
+			return gossh.NewClientConn(tcp, addr, config)
+		}
+
+		hop, err := s.hopConfig()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		conn, chans, reqs, err := gossh.NewClientConn(tcp, addr, hop)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		client = gossh.NewClient(conn, chans, reqs)
+	}
+
+	return nil, nil, nil, ErrDialVia
+}
+
 func (s *Session) Dial(ctx gliderssh.Context) error {
 	var err error
 
+	// NOTICE: When the device is reached through other devices, the connection starts from the first hop's agent.
+	agent := s.Device
+	if len(s.Hops) > 0 {
+		agent = s.Hops[0]
+	}
+
 	ctx.Lock()
-	s.AgentConn, err = s.tunnel.Dial(ctx, s.Device.UID)
+	s.AgentConn, err = s.tunnel.Dial(ctx, agent.UID)
 	if err != nil {
 		return errors.Join(ErrDial, err)
 	}
@@ -307,15 +469,15 @@ func (s *Session) Dial(ctx gliderssh.Context) error {
 func (s *Session) Evaluate(ctx gliderssh.Context) error {
 	snap := getSnapshot(ctx)
 
-	if envs.IsCloud() || envs.IsEnterprise() {
-		if ok, err := s.checkFirewall(); err != nil || !ok {
-			return err
-		}
+	// NOTICE: The firewall is evaluated on every edition, as the community API also serves the evaluation, answering
+	// with 200 when the connection is allowed and 403 when a rule denies it.
+	if ok, err := s.checkFirewall(); err != nil || !ok {
+		return err
+	}
 
-		if envs.HasBilling() {
-			if ok, err := s.checkBilling(); err != nil || !ok {
-				return err
-			}
+	if (envs.IsCloud() || envs.IsEnterprise()) && envs.HasBilling() {
+		if ok, err := s.checkBilling(); err != nil || !ok {
+			return err
 		}
 	}
 
@@ -367,6 +529,10 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 		if err := sess.authenticate(); err != nil {
 			return err
 		}
+
+		if pk, ok := auth.(*publicKeyAuth); ok {
+			sess.Fingerprint = gossh.FingerprintLegacyMD5(pk.pk)
+		}
 	default:
 		// The default arm is intended to avoid [StateNil] and [StateCreated], what are used before the authentication.
 		return errors.New("invalid session state")
@@ -377,6 +543,49 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 	return nil
 }
 
+// EvaluateCommand evaluates the command policy of the public key used to authenticate the session against a request
+// to start a program on the device. The command is empty when a shell or a subsystem is requested.
+//
+// It returns the command forced by the public key, if any, to be executed instead of the requested one, or
+// [ErrCommandNotAllowed] when the command isn't one of the commands allowed.
+func (s *Session) EvaluateCommand(command string) (string, error) {
+	if s.ForceCommand != "" {
+		return s.ForceCommand, nil
+	}
+
+	if len(s.AllowedCommands) == 0 {
+		return "", nil
+	}
+
+	if command == "" {
+		return "", ErrCommandNotAllowed
+	}
+
+	for _, allowed := range s.AllowedCommands {
+		// NOTICE: The expressions are anchored to not allow commands chained to an allowed one.
+		if ok, err := regexp.MatchString("^(?:"+allowed+")$", command); err == nil && ok {
+			return "", nil
+		}
+	}
+
+	return "", ErrCommandNotAllowed
+}
+
+// Activity marks the client's input to the session, what restarts the namespace's idle timeout.
+func (s *Session) Activity() {
+	s.activity.Store(clock.Now().UnixNano())
+}
+
+// LastActivity returns the time of the last input from the client. When the client has sent no input yet, it is the
+// time when the client connected.
+func (s *Session) LastActivity() time.Time {
+	if activity := s.activity.Load(); activity != 0 {
+		return time.Unix(0, activity)
+	}
+
+	return s.StartedAt
+}
+
 // Record records the current session state.
 //
 // It returns an error if any.
@@ -384,6 +593,28 @@ func (s *Session) Record(req *models.SessionRecorded, url string) error {
 	return s.api.RecordSession(req, url)
 }
 
+// Event records something done in the session besides its terminal, as it happens. The events are sent to the API in
+// background, in the order they happened, so recording them doesn't hold the session. An event recorded after the
+// session finished, as the result of a command whose channel closes with the connection, is sent right away.
+func (s *Session) Event(t models.SessionEventType, data interface{}) {
+	event := &models.SessionEvent{
+		Type:      t,
+		Timestamp: clock.Now(),
+		Data:      data,
+	}
+
+	if !s.events.push(event) {
+		s.sendEvent(event)
+	}
+}
+
+// sendEvent sends the session's event to the API. A failure is only reported, as the session goes on without it.
+func (s *Session) sendEvent(event *models.SessionEvent) {
+	if err := s.api.SessionEvent(s.UID, event); err != nil {
+		log.WithError(err).WithFields(log.Fields{"uid": s.UID, "type": event.Type}).Warn("failed to record the session's event")
+	}
+}
+
 func (s *Session) KeepAlive() error {
 	if errs := s.api.KeepAliveSession(s.UID); len(errs) > 0 {
 		log.Error(errs[0])
@@ -394,6 +625,34 @@ func (s *Session) KeepAlive() error {
 	return nil
 }
 
+// EvaluateForwarding evaluates the namespace's forwarding rules against a local port forwarding to host and port
+// from the device.
+//
+// It returns an error if the evaluation fails or if the forwarding is not allowed.
+func (s *Session) EvaluateForwarding(host string, port uint32) error {
+	return s.api.ForwardingEvaluate(requests.ForwardingEvaluate{
+		TenantID:    s.Device.TenantID,
+		Username:    s.Target.Username,
+		Fingerprint: s.Fingerprint,
+		Host:        host,
+		Port:        port,
+	})
+}
+
+// NamespaceSettings retrieves the settings of the device's namespace.
+func (s *Session) NamespaceSettings() (*models.NamespaceSettings, error) {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return nil, ErrFindNamespace
+	}
+
+	if namespace.Settings == nil {
+		return &models.NamespaceSettings{}, nil
+	}
+
+	return namespace.Settings, nil
+}
+
 // Announce is a custom message provided by the end user that can be printed when a new connection within the namespace
 // is established.
 //
@@ -436,8 +695,23 @@ func (s *Session) Announce(client gossh.Channel) error {
 }
 
 // Finish terminate the session between Agent and Client, sending a request to Agent to closes it.
-func (s *Session) Finish() (err error) {
+func (s *Session) Finish() error {
+	return s.finish("")
+}
+
+// Close finishes the session closed by ShellHub, recording the reason on the API.
+func (s *Session) Close(reason models.SessionCloseReason) error {
+	return s.finish(reason)
+}
+
+func (s *Session) finish(reason models.SessionCloseReason) (err error) {
 	s.once.Do(func() {
+		// NOTICE: The events queued until now are sent before the session is finished on the API.
+		s.events.close()
+
+		s.unshadow()
+		s.unshare()
+
 		if s.AgentConn != nil {
 			request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/ssh/close/%s", s.UID), nil)
 
@@ -448,7 +722,7 @@ func (s *Session) Finish() (err error) {
 			}
 		}
 
-		if errs := s.api.FinishSession(s.UID); len(errs) > 0 {
+		if errs := s.api.FinishSession(s.UID, reason); len(errs) > 0 {
 			log.WithError(errs[0]).
 				WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
 				Error("Error when trying to finish the session")
@@ -462,6 +736,7 @@ func (s *Session) Finish() (err error) {
 				"device":   s.Device.UID,
 				"username": s.Target.Username,
 				"ip":       s.IPAddress,
+				"reason":   reason,
 			}).Info("session finished")
 	})
 
@@ -0,0 +1,286 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateCommand(t *testing.T) {
+	cases := []struct {
+		description     string
+		forceCommand    string
+		allowedCommands []string
+		command         string
+		expected        string
+		err             error
+	}{
+		{
+			description: "allows any command when the public key has no policy",
+			command:     "rm -rf /tmp/cache",
+		},
+		{
+			description:  "forces the public key's command",
+			forceCommand: "/usr/local/bin/backup",
+			command:      "ls",
+			expected:     "/usr/local/bin/backup",
+		},
+		{
+			description:     "allows a command matching an expression",
+			allowedCommands: []string{"uptime", `systemctl status \w+`},
+			command:         "systemctl status nginx",
+		},
+		{
+			description:     "denies a command chained to an allowed one",
+			allowedCommands: []string{"uptime"},
+			command:         "uptime; rm -rf /",
+			err:             ErrCommandNotAllowed,
+		},
+		{
+			description:     "denies the shell when the commands are restricted",
+			allowedCommands: []string{"uptime"},
+			command:         "",
+			err:             ErrCommandNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			session := &Session{Data: Data{ForceCommand: tc.forceCommand, AllowedCommands: tc.allowedCommands}}
+
+			command, err := session.EvaluateCommand(tc.command)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, command)
+		})
+	}
+}
+
+func TestShadow(t *testing.T) {
+	sess := &Session{}
+	sess.ShadowResize(80, 24)
+
+	frames, stop := sess.Shadow("john")
+
+	assert.Equal(t, ShadowFrame{Columns: 80, Rows: 24}, <-frames)
+
+	output := []byte("ls\r\n")
+	sess.ShadowOutput(output)
+	copy(output, "xx")
+
+	assert.Equal(t, ShadowFrame{Output: []byte("ls\r\n"), Columns: 80, Rows: 24}, <-frames)
+
+	sess.ShadowResize(120, 40)
+
+	assert.Equal(t, ShadowFrame{Columns: 120, Rows: 40}, <-frames)
+
+	sess.unshadow()
+
+	_, ok := <-frames
+	assert.False(t, ok)
+
+	assert.NotPanics(t, stop)
+}
+
+func TestShare(t *testing.T) {
+	type typed struct {
+		participant string
+		input       string
+	}
+
+	terminal := new(bytes.Buffer)
+	records := []typed{}
+
+	sess := &Session{Data: Data{Target: &target.Target{Username: "root"}}}
+	sess.ShareTerminal(terminal, func(participant string, input []byte) {
+		records = append(records, typed{participant, string(input)})
+	})
+
+	// The owner's input isn't recorded while the session isn't shared.
+	sess.Typed([]byte("ls\r"))
+
+	_, ok := sess.Guest("token")
+	assert.False(t, ok)
+
+	sess.Invite("token", "guest", false)
+
+	guest, ok := sess.Guest("token")
+	assert.True(t, ok)
+	assert.Equal(t, "guest", guest)
+
+	assert.ErrorIs(t, sess.Type("guest", []byte("whoami\r")), ErrShareInputNotAllowed)
+
+	sess.AllowInput("guest", true)
+	sess.Typed([]byte("pwd\r"))
+
+	assert.NoError(t, sess.Type("guest", []byte("whoami\r")))
+	assert.Equal(t, "whoami\r", terminal.String())
+	assert.Equal(t, []typed{{"root", "pwd\r"}, {"guest", "whoami\r"}}, records)
+
+	sess.AllowInput("guest", false)
+
+	assert.ErrorIs(t, sess.Type("guest", []byte("exit\r")), ErrShareInputNotAllowed)
+
+	sess.unshare()
+
+	_, ok = sess.Guest("token")
+	assert.False(t, ok)
+}
+
+func TestRecordInput(t *testing.T) {
+	type typed struct {
+		participant string
+		input       string
+	}
+
+	records := []typed{}
+
+	sess := &Session{Data: Data{Target: &target.Target{Username: "root"}}}
+	sess.ShareTerminal(new(bytes.Buffer), func(participant string, input []byte) {
+		records = append(records, typed{participant, string(input)})
+	})
+
+	sess.RecordInput(true)
+
+	sess.Typed([]byte("sudo -i\r"))
+	sess.Displayed([]byte("[sudo] password for root: "))
+	sess.Typed([]byte("se"))
+	sess.Typed([]byte("cret\rls\r"))
+	sess.Displayed([]byte("Enter passphrase for key '/root/.ssh/id_ed25519': "))
+	sess.Typed([]byte("secret\x03"))
+	sess.Typed([]byte("exit\r"))
+
+	assert.Equal(t, []typed{
+		{"", "sudo -i\r"},
+		{"", "**"},
+		{"", "****\rls\r"},
+		{"", "******\x03"},
+		{"", "exit\r"},
+	}, records)
+
+	// The input is masked entirely when the client requested the terminal without echo.
+	records = []typed{}
+
+	sess.Pty.Modelist = string([]byte{ttyOpEcho, 0, 0, 0, 0, ttyOpEnd})
+	sess.RecordInput(true)
+	sess.Typed([]byte("secret\r"))
+	sess.Typed([]byte("secret"))
+
+	assert.Equal(t, []typed{{"", "******\r"}, {"", "******"}}, records)
+}
+
+func TestEchoOff(t *testing.T) {
+	cases := []struct {
+		description string
+		modes       []byte
+		expected    bool
+	}{
+		{
+			description: "echoes when the modes are empty",
+			modes:       nil,
+			expected:    false,
+		},
+		{
+			description: "echoes when the modes enable it",
+			modes:       []byte{1, 0, 0, 0, 3, ttyOpEcho, 0, 0, 0, 1, ttyOpEnd},
+			expected:    false,
+		},
+		{
+			description: "doesn't echo when the modes disable it",
+			modes:       []byte{1, 0, 0, 0, 3, ttyOpEcho, 0, 0, 0, 0, ttyOpEnd},
+			expected:    true,
+		},
+		{
+			description: "echoes when the echo mode is after the end",
+			modes:       []byte{ttyOpEnd, ttyOpEcho, 0, 0, 0, 0},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, echoOff(string(tc.modes)))
+		})
+	}
+}
+
+func TestResolveHops(t *testing.T) {
+	cases := []struct {
+		description   string
+		device        *models.Device
+		requiredMocks func(api *mocks.Client)
+		expected      []string
+		err           error
+	}{
+		{
+			description:   "succeeds without hops when the device is reached by its agent",
+			device:        &models.Device{UID: "device", TenantID: "tenant"},
+			requiredMocks: func(api *mocks.Client) {},
+			expected:      []string{},
+			err:           nil,
+		},
+		{
+			description: "fails when a device to jump through is not found",
+			device:      &models.Device{UID: "device", TenantID: "tenant", Via: &models.DeviceVia{Device: "gateway"}},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "gateway").Return(nil, errors.New("not found")).Once()
+			},
+			err: ErrDeviceVia,
+		},
+		{
+			description: "fails when a device to jump through is from another namespace",
+			device:      &models.Device{UID: "device", TenantID: "tenant", Via: &models.DeviceVia{Device: "gateway"}},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "gateway").Return(&models.Device{UID: "gateway", TenantID: "other"}, nil).Once()
+			},
+			err: ErrDeviceVia,
+		},
+		{
+			description: "fails when the devices jump through each other",
+			device:      &models.Device{UID: "device", TenantID: "tenant", Via: &models.DeviceVia{Device: "gateway"}},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "gateway").
+					Return(&models.Device{UID: "gateway", TenantID: "tenant", Via: &models.DeviceVia{Device: "device"}}, nil)
+				api.On("GetDevice", "device").
+					Return(&models.Device{UID: "device", TenantID: "tenant", Via: &models.DeviceVia{Device: "gateway"}}, nil)
+			},
+			err: ErrDeviceVia,
+		},
+		{
+			description: "succeeds with the hops starting from the device reached by its agent",
+			device:      &models.Device{UID: "device", TenantID: "tenant", Via: &models.DeviceVia{Device: "router"}},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "router").
+					Return(&models.Device{UID: "router", TenantID: "tenant", Via: &models.DeviceVia{Device: "gateway"}}, nil).Once()
+				api.On("GetDevice", "gateway").Return(&models.Device{UID: "gateway", TenantID: "tenant"}, nil).Once()
+			},
+			expected: []string{"gateway", "router"},
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			hops, err := resolveHops(api, tc.device)
+			assert.ErrorIs(t, err, tc.err)
+
+			if tc.err == nil {
+				uids := []string{}
+				for _, hop := range hops {
+					uids = append(uids, hop.UID)
+				}
+
+				assert.Equal(t, tc.expected, uids)
+			}
+
+			api.AssertExpectations(t)
+		})
+	}
+}
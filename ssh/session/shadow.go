@@ -0,0 +1,131 @@
+package session
+
+import (
+	"io"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// shadowFrames is the number of frames buffered to each user shadowing the session. When a user doesn't keep up with
+// the session's output, the frames are dropped instead of slowing down the session.
+const shadowFrames = 256
+
+// ShadowFrame is a piece of the session's terminal output sent to the users shadowing it. A frame without output
+// informs the terminal's dimensions.
+type ShadowFrame struct {
+	Output  []byte
+	Columns uint32
+	Rows    uint32
+}
+
+// shadows are the users watching the session's terminal output.
+type shadows struct {
+	mu       sync.Mutex
+	watchers map[chan ShadowFrame]struct{}
+	columns  uint32
+	rows     uint32
+	// notice is where the session's user is notified when someone starts to shadow the session.
+	notice io.Writer
+}
+
+// broadcast sends the frame to every user shadowing the session without blocking.
+func (s *shadows) broadcast(frame ShadowFrame) {
+	for watcher := range s.watchers {
+		select {
+		case watcher <- frame:
+		default:
+		}
+	}
+}
+
+// Shadow starts to shadow the session's terminal output, as a read-only copy of it. The first frame informs the
+// terminal's current dimensions. When the namespace requires, the session's user is notified that someone is
+// watching.
+//
+// The frames' channel is closed when the session finishes or when the returned function is called.
+func (s *Session) Shadow(watcher string) (<-chan ShadowFrame, func()) {
+	frames := make(chan ShadowFrame, shadowFrames)
+
+	s.shadows.mu.Lock()
+	if s.shadows.watchers == nil {
+		s.shadows.watchers = make(map[chan ShadowFrame]struct{})
+	}
+
+	s.shadows.watchers[frames] = struct{}{}
+	frames <- ShadowFrame{Columns: s.shadows.columns, Rows: s.shadows.rows}
+
+	notice := s.shadows.notice
+	s.shadows.mu.Unlock()
+
+	if notice != nil {
+		if settings, err := s.NamespaceSettings(); err == nil && settings.ShadowNotice {
+			if watcher == "" {
+				watcher = "someone"
+			}
+
+			if _, err := notice.Write([]byte("\r\n" + watcher + " is watching this session\r\n")); err != nil {
+				log.WithError(err).WithField("uid", s.UID).Warn("failed to notify the session's user about the shadowing")
+			}
+		}
+	}
+
+	return frames, func() {
+		s.shadows.mu.Lock()
+		defer s.shadows.mu.Unlock()
+
+		if _, ok := s.shadows.watchers[frames]; ok {
+			delete(s.shadows.watchers, frames)
+			close(frames)
+		}
+	}
+}
+
+// ShadowNotice sets where the session's user is notified when someone starts to shadow the session.
+func (s *Session) ShadowNotice(notice io.Writer) {
+	s.shadows.mu.Lock()
+	defer s.shadows.mu.Unlock()
+
+	s.shadows.notice = notice
+}
+
+// ShadowOutput sends the terminal's output to the users shadowing the session.
+func (s *Session) ShadowOutput(output []byte) {
+	s.shadows.mu.Lock()
+	defer s.shadows.mu.Unlock()
+
+	if len(s.shadows.watchers) == 0 {
+		return
+	}
+
+	// NOTICE: The output's buffer is reused by the caller, so each frame gets its own copy.
+	s.shadows.broadcast(ShadowFrame{
+		Output:  append([]byte(nil), output...),
+		Columns: s.shadows.columns,
+		Rows:    s.shadows.rows,
+	})
+}
+
+// ShadowResize sends the terminal's new dimensions to the users shadowing the session.
+func (s *Session) ShadowResize(columns, rows uint32) {
+	s.shadows.mu.Lock()
+	defer s.shadows.mu.Unlock()
+
+	s.shadows.columns = columns
+	s.shadows.rows = rows
+
+	s.shadows.broadcast(ShadowFrame{Columns: columns, Rows: rows})
+}
+
+// unshadow stops every user shadowing the session.
+func (s *Session) unshadow() {
+	s.shadows.mu.Lock()
+	defer s.shadows.mu.Unlock()
+
+	for watcher := range s.shadows.watchers {
+		delete(s.shadows.watchers, watcher)
+		close(watcher)
+	}
+
+	s.shadows.notice = nil
+}
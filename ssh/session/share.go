@@ -0,0 +1,131 @@
+package session
+
+import (
+	"io"
+	"sync"
+)
+
+// shares are the guests invited to the session and the terminal where the participants type.
+type shares struct {
+	mu sync.Mutex
+	// invites are the guests invited to the session by the invites' tokens.
+	invites map[string]string
+	// input are the guests allowed to type on the session.
+	input map[string]bool
+	// terminal is where the participants' input is written.
+	terminal io.Writer
+	// record records the participants' input while the session is shared.
+	record func(participant string, input []byte)
+}
+
+// Invite invites a guest to attach to the session through the invite's token, allowing it to type on the session or
+// not.
+func (s *Session) Invite(token, guest string, input bool) {
+	s.shares.mu.Lock()
+	defer s.shares.mu.Unlock()
+
+	if s.shares.invites == nil {
+		s.shares.invites = make(map[string]string)
+		s.shares.input = make(map[string]bool)
+	}
+
+	s.shares.invites[token] = guest
+	s.shares.input[guest] = input
+}
+
+// AllowInput grants or revokes the guest's input control.
+func (s *Session) AllowInput(guest string, input bool) {
+	s.shares.mu.Lock()
+	defer s.shares.mu.Unlock()
+
+	if _, ok := s.shares.input[guest]; !ok {
+		return
+	}
+
+	s.shares.input[guest] = input
+}
+
+// Guest gets the guest invited to the session through the invite's token.
+func (s *Session) Guest(token string) (string, bool) {
+	s.shares.mu.Lock()
+	defer s.shares.mu.Unlock()
+
+	guest, ok := s.shares.invites[token]
+
+	return guest, ok
+}
+
+// ShareTerminal sets where the participants' input is written and how it is recorded while the session is shared.
+func (s *Session) ShareTerminal(terminal io.Writer, record func(participant string, input []byte)) {
+	s.shares.mu.Lock()
+	defer s.shares.mu.Unlock()
+
+	s.shares.terminal = terminal
+	s.shares.record = record
+}
+
+// Typed records the input typed by the session's owner, when the session is shared or when its input is recorded.
+func (s *Session) Typed(input []byte) {
+	s.shares.mu.Lock()
+	record := s.shares.record
+	shared := len(s.shares.invites) > 0
+	s.shares.mu.Unlock()
+
+	// NOTICE: The input is masked even when it isn't recorded, so the password prompt ends with the line typed.
+	input = s.inputs.mask(input)
+
+	s.inputs.mu.Lock()
+	enabled := s.inputs.enabled
+	s.inputs.mu.Unlock()
+
+	if record == nil || (!shared && !enabled) {
+		return
+	}
+
+	var participant string
+	if shared {
+		participant = s.Target.Username
+	}
+
+	record(participant, input)
+}
+
+// Type writes the guest's input to the session's terminal, recording it attributed to the guest.
+//
+// It returns [ErrShareInputNotAllowed] when the guest has no input control.
+func (s *Session) Type(guest string, input []byte) error {
+	s.shares.mu.Lock()
+	allowed, terminal, record := s.shares.input[guest], s.shares.terminal, s.shares.record
+	s.shares.mu.Unlock()
+
+	if !allowed {
+		return ErrShareInputNotAllowed
+	}
+
+	if terminal == nil {
+		return ErrShareNoTerminal
+	}
+
+	if _, err := terminal.Write(input); err != nil {
+		return err
+	}
+
+	s.Activity()
+
+	if record != nil {
+		record(guest, s.inputs.mask(input))
+	}
+
+	return nil
+}
+
+// unshare revokes every invite to the session.
+func (s *Session) unshare() {
+	s.shares.mu.Lock()
+	defer s.shares.mu.Unlock()
+
+	s.shares.invites = nil
+	s.shares.input = nil
+	s.shares.terminal = nil
+	s.shares.record = nil
+}
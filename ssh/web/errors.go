@@ -50,6 +50,13 @@ var (
 
 var ErrBridgeCredentialsNotFound = errors.New("failed to find the credentials")
 
+var (
+	ErrShadowDenied          = errors.New("not allowed to shadow the session")
+	ErrShadowAuthorize       = errors.New("failed to authorize the session shadowing")
+	ErrShadowSessionNotFound = errors.New("session not found or not open on this server")
+	ErrShareInviteNotFound   = errors.New("invite not found for the session")
+)
+
 var (
 	ErrGetToken      = errors.New("token not found on request query")
 	ErrGetIP         = errors.New("ip not found on request query")
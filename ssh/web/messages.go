@@ -8,6 +8,9 @@ const (
 	// messageKindResize is the identifier to a resize request message. This kind of message contains the number of
 	// columns and rows what the terminal should have.
 	messageKindResize
+	// messageKindOutput is the identifier to a output message. This kind of message contains the terminal's output
+	// sent to the users shadowing a session.
+	messageKindOutput
 )
 
 type Message struct {
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	session "github.com/shellhub-io/shellhub/ssh/session"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sessions is an autogenerated mock type for the Sessions type
+type Sessions struct {
+	mock.Mock
+}
+
+// Session provides a mock function with given fields: uid
+func (_m *Sessions) Session(uid string) (*session.Session, bool) {
+	ret := _m.Called(uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Session")
+	}
+
+	var r0 *session.Session
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (*session.Session, bool)); ok {
+		return rf(uid)
+	}
+	if rf, ok := ret.Get(0).(func(string) *session.Session); ok {
+		r0 = rf(uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(uid)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// NewSessions creates a new instance of Sessions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sessions {
+	mock := &Sessions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+// Sessions finds the sessions held by this server.
+//
+//go:generate mockery --name Sessions --filename sessions.go
+type Sessions interface {
+	// Session gets the session with the UID when this server holds its connection.
+	Session(uid string) (*session.Session, bool)
+}
+
+// NewSessionShadowBridge creates routes into a [echo.Router] to shadow a session through a websocket, receiving a
+// read-only copy of its terminal output.
+//
+// The user is identified by the headers set by the gateway from its token, and the API authorizes the shadowing.
+func NewSessionShadowBridge(router *echo.Router, api internalclient.Client, sessions Sessions) {
+	const WebsocketSessionShadowRoute = "/ws/session/:uid"
+
+	router.Add(http.MethodGet, WebsocketSessionShadowRoute, func(c echo.Context) error {
+		uid := c.Param("uid")
+
+		logger := log.WithFields(log.Fields{
+			"uid":      uid,
+			"tenant":   c.Request().Header.Get("X-Tenant-ID"),
+			"username": c.Request().Header.Get("X-Username"),
+		})
+
+		if err := api.ShadowSession(uid, c.Request().Header.Get("X-Tenant-ID"), c.Request().Header.Get("X-Role")); err != nil {
+			logger.WithError(err).Warn("failed to authorize the session shadowing")
+
+			if errors.Is(err, internalclient.ErrSessionShadowDenied) {
+				return c.JSON(http.StatusForbidden, ErrShadowDenied.Error())
+			}
+
+			return c.JSON(http.StatusInternalServerError, ErrShadowAuthorize.Error())
+		}
+
+		sess, ok := sessions.Session(uid)
+		if !ok {
+			return c.JSON(http.StatusNotFound, ErrShadowSessionNotFound.Error())
+		}
+
+		websocket.Handler(func(wsconn *websocket.Conn) {
+			conn := NewConn(wsconn)
+			defer conn.Close()
+
+			go conn.KeepAlive()
+
+			frames, stop := sess.Shadow(c.Request().Header.Get("X-Username"))
+			defer stop()
+
+			logger.Info("session shadowing started")
+			defer logger.Info("session shadowing done")
+
+			// NOTICE: The shadowing is read-only, so anything sent by the user is discarded, but reading from the
+			// websocket is required to know when it is closed.
+			go func() {
+				defer stop()
+
+				buffer := make([]byte, 1024)
+				for {
+					if _, err := conn.Read(buffer); err != nil {
+						return
+					}
+				}
+			}()
+
+			if err := writeFrames(conn, frames); err != nil {
+				logger.WithError(err).Warn("failed to write the frame to the shadowing user")
+			}
+		}).ServeHTTP(c.Response(), c.Request())
+
+		return nil
+	})
+}
+
+// writeFrames writes the session's frames to the websocket until the frames' channel is closed.
+func writeFrames(conn *Conn, frames <-chan session.ShadowFrame) error {
+	for frame := range frames {
+		message := &Message{Kind: messageKindResize, Data: Dimensions{int(frame.Columns), int(frame.Rows)}}
+		if frame.Output != nil {
+			message = &Message{Kind: messageKindOutput, Data: frame.Output}
+		}
+
+		if _, err := conn.WriteMessage(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	internalclientMocks "github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/ssh/web/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSessionShadowBridge(t *testing.T) {
+	api := new(internalclientMocks.Client)
+	sessions := new(mocks.Sessions)
+
+	tests := []struct {
+		description   string
+		uid           string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when the user is not allowed to shadow the session",
+			uid:         "denied",
+			requiredMocks: func() {
+				api.On("ShadowSession", "denied", "tenant", "observer").Return(internalclient.ErrSessionShadowDenied).Once()
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when the API cannot authorize the shadowing",
+			uid:         "unknown",
+			requiredMocks: func() {
+				api.On("ShadowSession", "unknown", "tenant", "observer").Return(internalclient.ErrUnknown).Once()
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			description: "fails when the session is not open on this server",
+			uid:         "closed",
+			requiredMocks: func() {
+				api.On("ShadowSession", "closed", "tenant", "observer").Return(nil).Once()
+				sessions.On("Session", "closed").Return(nil, false).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+	}
+
+	e := echo.New()
+	NewSessionShadowBridge(e.Router(), api, sessions)
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/ws/session/"+test.uid, nil)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			req.Header.Set("X-Role", "observer")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expected, rec.Code)
+		})
+	}
+
+	api.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+// NewSessionShareBridge creates routes into a [echo.Router] to attach a guest, invited to a shared session, through a
+// websocket. The guest receives the session's terminal output and types on it while it has the input control.
+//
+// The guest is identified by the invite's token, sent on the "token" query parameter.
+func NewSessionShareBridge(router *echo.Router, sessions Sessions) {
+	const WebsocketSessionShareRoute = "/ws/share/:uid"
+
+	router.Add(http.MethodGet, WebsocketSessionShareRoute, func(c echo.Context) error {
+		uid := c.Param("uid")
+
+		token, err := getToken(c.Request())
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrWebSocketGetToken.Error())
+		}
+
+		sess, ok := sessions.Session(uid)
+		if !ok {
+			return c.JSON(http.StatusNotFound, ErrShadowSessionNotFound.Error())
+		}
+
+		guest, ok := sess.Guest(token)
+		if !ok {
+			return c.JSON(http.StatusForbidden, ErrShareInviteNotFound.Error())
+		}
+
+		logger := log.WithFields(log.Fields{"uid": uid, "guest": guest})
+
+		websocket.Handler(func(wsconn *websocket.Conn) {
+			conn := NewConn(wsconn)
+			defer conn.Close()
+
+			go conn.KeepAlive()
+
+			frames, stop := sess.Shadow(guest)
+			defer stop()
+
+			logger.Info("guest attached to the session")
+			defer logger.Info("guest detached from the session")
+
+			go func() {
+				defer stop()
+
+				for {
+					var message Message
+					if _, err := conn.ReadMessage(&message); err != nil {
+						if errors.Is(err, ErrConnReadMessageSocketRead) {
+							return
+						}
+
+						logger.WithError(err).Debug("failed to read the guest's message")
+
+						continue
+					}
+
+					// NOTICE: The terminal's dimensions are the owner's, so the guest's resizes are ignored.
+					if message.Kind != messageKindInput {
+						continue
+					}
+
+					if err := sess.Type(guest, message.Data.([]byte)); err != nil {
+						logger.WithError(err).Debug("failed to type the guest's input on the session")
+					}
+				}
+			}()
+
+			if err := writeFrames(conn, frames); err != nil {
+				logger.WithError(err).Warn("failed to write the frame to the guest")
+			}
+		}).ServeHTTP(c.Response(), c.Request())
+
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	"github.com/shellhub-io/shellhub/ssh/web/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSessionShareBridge(t *testing.T) {
+	sessions := new(mocks.Sessions)
+
+	shared := &session.Session{}
+	shared.Invite("token", "guest", true)
+
+	tests := []struct {
+		description   string
+		uri           string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the token is not set",
+			uri:           "/ws/share/uid",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the session is not open on this server",
+			uri:         "/ws/share/closed?token=token",
+			requiredMocks: func() {
+				sessions.On("Session", "closed").Return(nil, false).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "fails when the token is not an invite to the session",
+			uri:         "/ws/share/uid?token=other",
+			requiredMocks: func() {
+				sessions.On("Session", "uid").Return(shared, true).Once()
+			},
+			expected: http.StatusForbidden,
+		},
+	}
+
+	e := echo.New()
+	NewSessionShareBridge(e.Router(), sessions)
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, test.uri, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expected, rec.Code)
+		})
+	}
+
+	sessions.AssertExpectations(t)
+}